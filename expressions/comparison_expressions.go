@@ -0,0 +1,224 @@
+package expressions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/samlitowitz/expressions"
+)
+
+var _ expressions.Expression = (*NotEquals)(nil)
+var _ expressions.Expression = (*LessThan)(nil)
+var _ expressions.Expression = (*LessThanOrEqual)(nil)
+var _ expressions.Expression = (*GreaterThan)(nil)
+var _ expressions.Expression = (*GreaterThanOrEqual)(nil)
+var _ expressions.Expression = (*Between)(nil)
+var _ expressions.Expression = (*In)(nil)
+var _ expressions.Expression = (*IsNull)(nil)
+var _ expressions.Expression = (*IsNotNull)(nil)
+
+// NotEquals represents an expression of inequality.
+type NotEquals struct {
+	*expressions.Binary
+}
+
+// NewNotEquals creates a new [NotEquals] expression.
+func NewNotEquals(left, right expressions.Expression) *NotEquals {
+	return &NotEquals{
+		expressions.NewBinary(left, right),
+	}
+}
+
+func (e NotEquals) String() string {
+	return fmt.Sprintf("%s != %s", e.Left(), e.Right())
+}
+
+// LessThan represents a less than comparison expression.
+type LessThan struct {
+	*expressions.Binary
+}
+
+// NewLessThan creates a new [LessThan] expression.
+func NewLessThan(left, right expressions.Expression) *LessThan {
+	return &LessThan{
+		expressions.NewBinary(left, right),
+	}
+}
+
+func (e LessThan) String() string {
+	return fmt.Sprintf("%s < %s", e.Left(), e.Right())
+}
+
+// LessThanOrEqual represents a less than or equal comparison expression.
+type LessThanOrEqual struct {
+	*expressions.Binary
+}
+
+// NewLessThanOrEqual creates a new [LessThanOrEqual] expression.
+func NewLessThanOrEqual(left, right expressions.Expression) *LessThanOrEqual {
+	return &LessThanOrEqual{
+		expressions.NewBinary(left, right),
+	}
+}
+
+func (e LessThanOrEqual) String() string {
+	return fmt.Sprintf("%s <= %s", e.Left(), e.Right())
+}
+
+// GreaterThan represents a greater than comparison expression.
+type GreaterThan struct {
+	*expressions.Binary
+}
+
+// NewGreaterThan creates a new [GreaterThan] expression.
+func NewGreaterThan(left, right expressions.Expression) *GreaterThan {
+	return &GreaterThan{
+		expressions.NewBinary(left, right),
+	}
+}
+
+func (e GreaterThan) String() string {
+	return fmt.Sprintf("%s > %s", e.Left(), e.Right())
+}
+
+// GreaterThanOrEqual represents a greater than or equal comparison expression.
+type GreaterThanOrEqual struct {
+	*expressions.Binary
+}
+
+// NewGreaterThanOrEqual creates a new [GreaterThanOrEqual] expression.
+func NewGreaterThanOrEqual(left, right expressions.Expression) *GreaterThanOrEqual {
+	return &GreaterThanOrEqual{
+		expressions.NewBinary(left, right),
+	}
+}
+
+func (e GreaterThanOrEqual) String() string {
+	return fmt.Sprintf("%s >= %s", e.Left(), e.Right())
+}
+
+// Between represents an inclusive range expression, i.e. low <= operand <= high.
+type Between struct {
+	operand expressions.Expression
+	low     expressions.Expression
+	high    expressions.Expression
+}
+
+// NewBetween creates a new [Between] expression.
+func NewBetween(operand, low, high expressions.Expression) *Between {
+	return &Between{
+		operand: operand,
+		low:     low,
+		high:    high,
+	}
+}
+
+func (e Between) Operands() []expressions.Expression {
+	return []expressions.Expression{e.operand, e.low, e.high}
+}
+
+// Operand returns the operand being tested.
+func (e Between) Operand() expressions.Expression {
+	return e.operand
+}
+
+// Low returns the inclusive lower bound.
+func (e Between) Low() expressions.Expression {
+	return e.low
+}
+
+// High returns the inclusive upper bound.
+func (e Between) High() expressions.Expression {
+	return e.high
+}
+
+func (e Between) String() string {
+	return fmt.Sprintf("%s BETWEEN %s AND %s", e.operand, e.low, e.high)
+}
+
+// In represents a set membership expression.
+// An [In] expression without any values is never satisfied.
+type In struct {
+	operand expressions.Expression
+	values  []expressions.Expression
+}
+
+// NewIn creates a new [In] expression.
+func NewIn(operand expressions.Expression, values ...expressions.Expression) *In {
+	return &In{
+		operand: operand,
+		values:  values,
+	}
+}
+
+func (e In) Operands() []expressions.Expression {
+	return append([]expressions.Expression{e.operand}, e.values...)
+}
+
+// Operand returns the operand being tested.
+func (e In) Operand() expressions.Expression {
+	return e.operand
+}
+
+// Values returns the set of values the operand is tested against.
+func (e In) Values() []expressions.Expression {
+	return e.values
+}
+
+func (e In) String() string {
+	values := make([]string, 0, len(e.values))
+	for _, value := range e.values {
+		values = append(values, fmt.Sprintf("%s", value))
+	}
+	return fmt.Sprintf("%s IN (%s)", e.operand, strings.Join(values, ", "))
+}
+
+// IsNull represents an expression testing for the absence of a value.
+type IsNull struct {
+	operand expressions.Expression
+}
+
+// NewIsNull creates a new [IsNull] expression.
+func NewIsNull(operand expressions.Expression) *IsNull {
+	return &IsNull{
+		operand: operand,
+	}
+}
+
+func (e IsNull) Operands() []expressions.Expression {
+	return []expressions.Expression{e.operand}
+}
+
+// Operand returns the operand being tested.
+func (e IsNull) Operand() expressions.Expression {
+	return e.operand
+}
+
+func (e IsNull) String() string {
+	return fmt.Sprintf("%s IS NULL", e.operand)
+}
+
+// IsNotNull represents an expression testing for the presence of a value.
+type IsNotNull struct {
+	operand expressions.Expression
+}
+
+// NewIsNotNull creates a new [IsNotNull] expression.
+func NewIsNotNull(operand expressions.Expression) *IsNotNull {
+	return &IsNotNull{
+		operand: operand,
+	}
+}
+
+func (e IsNotNull) Operands() []expressions.Expression {
+	return []expressions.Expression{e.operand}
+}
+
+// Operand returns the operand being tested.
+func (e IsNotNull) Operand() expressions.Expression {
+	return e.operand
+}
+
+func (e IsNotNull) String() string {
+	return fmt.Sprintf("%s IS NOT NULL", e.operand)
+}
@@ -0,0 +1,61 @@
+package expressions_test
+
+import (
+	"fmt"
+
+	"github.com/samlitowitz/expressions"
+
+	crudexpressions "github.com/samlitowitz/protoc-gen-crud/expressions"
+)
+
+func ExampleNotEquals_aNotEqualsB() {
+	fmt.Println(
+		crudexpressions.NewNotEquals(
+			expressions.NewIdentifier("A"),
+			expressions.NewIdentifier("B"),
+		),
+	)
+	// Output: A != B
+}
+
+func ExampleLessThanOrEqual_aLessThanOrEqualOne() {
+	fmt.Println(
+		crudexpressions.NewLessThanOrEqual(
+			expressions.NewIdentifier("A"),
+			expressions.NewScalar(1),
+		),
+	)
+	// Output: A <= 1
+}
+
+func ExampleBetween_aBetweenOneAndTwo() {
+	fmt.Println(
+		crudexpressions.NewBetween(
+			expressions.NewIdentifier("A"),
+			expressions.NewScalar(1),
+			expressions.NewScalar(2),
+		),
+	)
+	// Output: A BETWEEN 1 AND 2
+}
+
+func ExampleIn_aInOneTwoThree() {
+	fmt.Println(
+		crudexpressions.NewIn(
+			expressions.NewIdentifier("A"),
+			expressions.NewScalar(1),
+			expressions.NewScalar(2),
+			expressions.NewScalar(3),
+		),
+	)
+	// Output: A IN (1, 2, 3)
+}
+
+func ExampleIsNull_aIsNull() {
+	fmt.Println(
+		crudexpressions.NewIsNull(
+			expressions.NewIdentifier("A"),
+		),
+	)
+	// Output: A IS NULL
+}
@@ -0,0 +1,18 @@
+/*
+Package expressions extends [github.com/samlitowitz/expressions] with the comparison, range, set, pattern and null
+expressions understood by the generated repositories.
+
+The expressions defined here are used alongside those of [github.com/samlitowitz/expressions] when building the
+criteria passed to the generated Read and Delete methods, e.g.
+
+	expressions.NewAnd(
+		crudexpressions.NewGreaterThanOrEqual(
+			expressions.NewIdentifier(example.Example_CreatedAt_Field),
+			expressions.NewTimestamp(from),
+		),
+		crudexpressions.NewIsNotNull(
+			expressions.NewIdentifier(example.Example_Data_Field),
+		),
+	)
+*/
+package expressions
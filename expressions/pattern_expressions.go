@@ -0,0 +1,45 @@
+package expressions
+
+import (
+	"fmt"
+
+	"github.com/samlitowitz/expressions"
+)
+
+var _ expressions.Expression = (*Like)(nil)
+var _ expressions.Expression = (*ILike)(nil)
+
+// Like represents a pattern matching expression using SQL LIKE wildcards, i.e. `%` and `_`.
+//
+// Matching is case-sensitive with PgSQL. SQLite matches ASCII characters case-insensitively
+// unless the `case_sensitive_like` pragma is enabled.
+type Like struct {
+	*expressions.Binary
+}
+
+// NewLike creates a new [Like] expression, right being the pattern.
+func NewLike(left, right expressions.Expression) *Like {
+	return &Like{
+		expressions.NewBinary(left, right),
+	}
+}
+
+func (e Like) String() string {
+	return fmt.Sprintf("%s LIKE %s", e.Left(), e.Right())
+}
+
+// ILike represents a case-insensitive pattern matching expression using SQL LIKE wildcards, i.e. `%` and `_`.
+type ILike struct {
+	*expressions.Binary
+}
+
+// NewILike creates a new [ILike] expression, right being the pattern.
+func NewILike(left, right expressions.Expression) *ILike {
+	return &ILike{
+		expressions.NewBinary(left, right),
+	}
+}
+
+func (e ILike) String() string {
+	return fmt.Sprintf("%s ILIKE %s", e.Left(), e.Right())
+}
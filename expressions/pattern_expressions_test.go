@@ -0,0 +1,29 @@
+package expressions_test
+
+import (
+	"fmt"
+
+	"github.com/samlitowitz/expressions"
+
+	crudexpressions "github.com/samlitowitz/protoc-gen-crud/expressions"
+)
+
+func ExampleLike_aLikePattern() {
+	fmt.Println(
+		crudexpressions.NewLike(
+			expressions.NewIdentifier("A"),
+			expressions.NewScalar("a%"),
+		),
+	)
+	// Output: A LIKE "a%"
+}
+
+func ExampleILike_aILikePattern() {
+	fmt.Println(
+		crudexpressions.NewILike(
+			expressions.NewIdentifier("A"),
+			expressions.NewScalar("a%"),
+		),
+	)
+	// Output: A ILIKE "a%"
+}
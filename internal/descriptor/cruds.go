@@ -44,6 +44,18 @@ func (r *Registry) loadCRUDs(file *File) error {
 				msg.NonPrimeAttributesByFQFN[field.FQFN()] = field
 			}
 
+			isVersion := msg.HasVersion() && msg.Version.FQFN() == field.FQFN()
+			if isVersion && (!field.IsNumeric() || field.IsFloatingPoint() || field.IsRepeated()) {
+				return fmt.Errorf("%s: field designed as `version` must be an integer", field.FQFN())
+			}
+			if isVersion && isPrimeAttribute {
+				return fmt.Errorf("%s: field designed as `version` cannot be part of a primary key", field.FQFN())
+			}
+
+			if field.HasPresence() && (isPrimeAttribute || isVersion) {
+				return fmt.Errorf("%s: primary key and version fields cannot be optional or wrapper types", field.FQFN())
+			}
+
 			fieldOpts, err := extractFieldOptions(field.FieldDescriptorProto)
 			if err != nil {
 				return fmt.Errorf("%s: %v", field.FQFN(), err)
@@ -62,6 +74,13 @@ func (r *Registry) loadCRUDs(file *File) error {
 				return fmt.Errorf("%s: inlined field must be of type message", field.FQFN())
 			}
 
+			if field.IsAutoGenerated() {
+				err = validateAutoGeneratedField(msg, field)
+				if err != nil {
+					return fmt.Errorf("%s: %v", field.FQFN(), err)
+				}
+			}
+
 			isCreatedAt := msg.HasCreatedAt() && msg.CreatedAt.FQFN() == field.FQFN()
 			if isCreatedAt && !field.AsTimestamp {
 				return fmt.Errorf("%s: field designed as `createdAt` must be a timestamp", field.FQFN())
@@ -70,6 +89,33 @@ func (r *Registry) loadCRUDs(file *File) error {
 			if isUpdatedAt && !field.AsTimestamp {
 				return fmt.Errorf("%s: field designed as `createdAt` must be a timestamp", field.FQFN())
 			}
+			isDeletedAt := msg.HasDeletedAt() && msg.DeletedAt.FQFN() == field.FQFN()
+			if isDeletedAt && !field.AsTimestamp {
+				return fmt.Errorf("%s: field designed as `deletedAt` must be a timestamp", field.FQFN())
+			}
+			if isDeletedAt && field.IsPrimeAttribute {
+				return fmt.Errorf("%s: field designed as `deletedAt` cannot be part of a primary key", field.FQFN())
+			}
+			if isVersion && field.AsTimestamp {
+				return fmt.Errorf("%s: field designed as `version` must be an integer", field.FQFN())
+			}
+			if isVersion && field.Ignore {
+				return fmt.Errorf("%s: field designed as `version` cannot be ignored", field.FQFN())
+			}
+			if field.Nullable && (isPrimeAttribute || isVersion) {
+				return fmt.Errorf("%s: primary key and version fields cannot be nullable", field.FQFN())
+			}
+			if field.NotNull && field.HasPresence() {
+				return fmt.Errorf("%s: optional or wrapper type field cannot be not nullable", field.FQFN())
+			}
+			hasReferentialAction := field.OnDelete != crudOptions.ReferentialAction_REFERENTIAL_ACTION_UNSPECIFIED ||
+				field.OnUpdate != crudOptions.ReferentialAction_REFERENTIAL_ACTION_UNSPECIFIED
+			if hasReferentialAction && field.GetType() != descriptorpb.FieldDescriptorProto_TYPE_ENUM {
+				return fmt.Errorf("%s: referential actions are only supported on enum fields", field.FQFN())
+			}
+			if setsNull(field.OnDelete, field.OnUpdate) && (isPrimeAttribute || field.NotNull) {
+				return fmt.Errorf("%s: primary key and not nullable fields cannot be set to NULL", field.FQFN())
+			}
 
 			err = assignRelationships(r, msg, field, fieldOpts)
 			if err != nil {
@@ -81,6 +127,29 @@ func (r *Registry) loadCRUDs(file *File) error {
 	return nil
 }
 
+// loadEnums assigns the CRUD options of the enums in "file"
+// It is called for every file, not only those generated, as enums may be used by messages in other files
+func (r *Registry) loadEnums(file *File) error {
+	fileOpts, err := extractFileOptions(file.FileDescriptorProto)
+	if err != nil {
+		return err
+	}
+	for _, enum := range file.Enums {
+		enumOpts, err := extractEnumOptions(enum.EnumDescriptorProto)
+		if err != nil {
+			return fmt.Errorf("%s: %v", enum.FQEN(), err)
+		}
+		enum.Storage = enumOpts.GetStorage()
+		if enum.Storage == crudOptions.EnumStorage_ENUM_STORAGE_UNSPECIFIED {
+			enum.Storage = fileOpts.GetEnumStorage()
+		}
+		if enum.Storage == crudOptions.EnumStorage_ENUM_STORAGE_UNSPECIFIED {
+			enum.Storage = crudOptions.EnumStorage_ENUM_STORAGE_LOOKUP_TABLE
+		}
+	}
+	return nil
+}
+
 func assignMessageOptions(msg *Message, msgOpts *crudOptions.MessageOptions) error {
 	msg.Implementations = make(map[crudOptions.Implementation]struct{})
 	msg.PrimaryKeyByFQFN = make(map[string]*Field)
@@ -143,6 +212,23 @@ func assignMessageOptions(msg *Message, msgOpts *crudOptions.MessageOptions) err
 		}
 		msg.UpdatedAt = field
 	}
+	if msgOpts.GetDeletedAt() != "" {
+		field, err := msg.LookupField(msgOpts.GetDeletedAt())
+		if err != nil {
+			return err
+		}
+		msg.DeletedAt = field
+	}
+	if msgOpts.GetVersion() != "" {
+		field, err := msg.LookupField(msgOpts.GetVersion())
+		if err != nil {
+			return err
+		}
+		msg.Version = field
+	}
+
+	msg.IsolationLevel = msgOpts.GetIsolationLevel()
+	msg.TimestampSource = msgOpts.GetTimestampSource()
 
 	return nil
 }
@@ -165,6 +251,10 @@ func assignRelationships(r *Registry, msg *Message, field *Field, fieldOpts *cru
 		return err
 	}
 
+	if setsNull(fieldOpts.GetRelationship().GetOnDelete(), fieldOpts.GetRelationship().GetOnUpdate()) {
+		return fmt.Errorf("relationship columns are a primary key and cannot be set to NULL")
+	}
+
 	switch fieldOpts.GetRelationship().GetType() {
 	case relationshipOptions.Type_MANY_TO_ONE:
 	case relationshipOptions.Type_MANY_TO_MANY:
@@ -191,13 +281,156 @@ func assignRelationships(r *Registry, msg *Message, field *Field, fieldOpts *cru
 	return nil
 }
 
+// loadForeignKeys resolves the foreign keys of the messages in "file"
+// It must be called after loadCRUDs is called for all files so that the primary keys
+// of the referenced messages are known
+func (r *Registry) loadForeignKeys(file *File) error {
+	for _, msg := range file.Messages {
+		if !msg.GenerateCRUD {
+			continue
+		}
+		msgOpts, err := extractMessageOptions(msg.DescriptorProto)
+		if err != nil {
+			return fmt.Errorf("%s: %v", msg.FQMN(), err)
+		}
+		for i, fkOpts := range msgOpts.GetForeignKeys() {
+			fk, err := r.resolveForeignKey(msg, fkOpts)
+			if err != nil {
+				return fmt.Errorf("%s: foreign key %d: %v", msg.FQMN(), i, err)
+			}
+			msg.ForeignKeys = append(msg.ForeignKeys, fk)
+		}
+	}
+	return nil
+}
+
+func (r *Registry) resolveForeignKey(msg *Message, fkOpts *crudOptions.ForeignKey) (*ForeignKey, error) {
+	references, err := r.LookupMsg(msg.File.GetPackage(), fkOpts.GetReferences())
+	if err != nil {
+		return nil, err
+	}
+	if !references.GenerateCRUD {
+		return nil, fmt.Errorf("%s: referenced message must have CRUD message options", references.FQMN())
+	}
+	for impl := range msg.Implementations {
+		if _, ok := references.Implementations[impl]; !ok {
+			return nil, fmt.Errorf("%s: referenced message is not stored by %s", references.FQMN(), impl)
+		}
+	}
+	if len(fkOpts.GetFields()) != len(references.PrimaryKey()) {
+		return nil, fmt.Errorf(
+			"%s: expected %d fields matching the primary key of the referenced message, got %d",
+			references.FQMN(),
+			len(references.PrimaryKey()),
+			len(fkOpts.GetFields()),
+		)
+	}
+
+	fk := &ForeignKey{
+		ForeignKey: fkOpts,
+		References: references,
+	}
+	for _, fieldName := range fkOpts.GetFields() {
+		field, err := msg.LookupField(fieldName)
+		if err != nil {
+			return nil, fmt.Errorf("field `%s`: %v", fieldName, err)
+		}
+		if field.Ignore || field.Inline || field.IsRepeated() || field.AsTimestamp {
+			return nil, fmt.Errorf("%s: foreign key field must be a stored scalar field", field.FQFN())
+		}
+		if field.GetType() == descriptorpb.FieldDescriptorProto_TYPE_MESSAGE && !field.IsWrapper() {
+			return nil, fmt.Errorf("%s: foreign key field must be a stored scalar field", field.FQFN())
+		}
+		if setsNull(fkOpts.GetOnDelete(), fkOpts.GetOnUpdate()) && (field.IsPrimeAttribute || field.NotNull) {
+			return nil, fmt.Errorf("%s: primary key and not nullable fields cannot be set to NULL", field.FQFN())
+		}
+		fk.Fields = append(fk.Fields, field)
+	}
+	return fk, nil
+}
+
+// setsNull is true if either action sets the referencing columns to NULL
+func setsNull(onDelete, onUpdate crudOptions.ReferentialAction) bool {
+	return onDelete == crudOptions.ReferentialAction_REFERENTIAL_ACTION_SET_NULL ||
+		onUpdate == crudOptions.ReferentialAction_REFERENTIAL_ACTION_SET_NULL
+}
+
 func assignFieldOptions(field *Field, fieldOpts *crudOptions.FieldOptions) error {
 	field.Ignore = fieldOpts.GetIgnore()
 	field.Inline = fieldOpts.GetInline()
 	field.AsTimestamp = fieldOpts.GetAsTimestamp()
+	field.AutoGenerate = fieldOpts.GetAutoGenerate()
+	field.Nullable = fieldOpts.HasNullable() && fieldOpts.GetNullable()
+	field.NotNull = fieldOpts.HasNullable() && !fieldOpts.GetNullable()
+	field.Default = fieldOpts.GetDefault()
+	field.Check = fieldOpts.GetCheck()
+	field.OnDelete = fieldOpts.GetOnDelete()
+	field.OnUpdate = fieldOpts.GetOnUpdate()
 	return nil
 }
 
+func validateAutoGeneratedField(msg *Message, field *Field) error {
+	if !field.IsPrimeAttribute {
+		return fmt.Errorf("auto-generated field must be part of the primary key")
+	}
+	switch field.AutoGenerate {
+	case crudOptions.AutoGenerationStrategy_AUTO_GENERATION_STRATEGY_UUID_V4:
+		fallthrough
+	case crudOptions.AutoGenerationStrategy_AUTO_GENERATION_STRATEGY_UUID_V7:
+		if field.GetType() != descriptorpb.FieldDescriptorProto_TYPE_STRING {
+			return fmt.Errorf("auto-generated UUID field must be of type string")
+		}
+
+	case crudOptions.AutoGenerationStrategy_AUTO_GENERATION_STRATEGY_SEQUENTIAL:
+		if len(msg.PrimaryKeyByFQFN) != 1 {
+			return fmt.Errorf("sequential auto-generated field must be the only primary key field")
+		}
+		switch field.GetType() {
+		case descriptorpb.FieldDescriptorProto_TYPE_INT32:
+		case descriptorpb.FieldDescriptorProto_TYPE_INT64:
+		case descriptorpb.FieldDescriptorProto_TYPE_SINT32:
+		case descriptorpb.FieldDescriptorProto_TYPE_SINT64:
+		case descriptorpb.FieldDescriptorProto_TYPE_UINT32:
+		default:
+			return fmt.Errorf("unsupported sequential auto-generated field type %s", field.GetType())
+		}
+
+	default:
+		return fmt.Errorf("unsupported auto-generation strategy %s", field.AutoGenerate)
+	}
+	return nil
+}
+
+func extractFileOptions(file *descriptorpb.FileDescriptorProto) (*crudOptions.FileOptions, error) {
+	if file.GetOptions() == nil {
+		return nil, nil
+	}
+	if !proto.HasExtension(file.GetOptions(), crudOptions.E_CrudFileOptions) {
+		return nil, nil
+	}
+	ext := proto.GetExtension(file.GetOptions(), crudOptions.E_CrudFileOptions)
+	opts, ok := ext.(*crudOptions.FileOptions)
+	if !ok {
+		return nil, fmt.Errorf("extension is %T; want FileOptions", ext)
+	}
+	return opts, nil
+}
+
+func extractEnumOptions(enum *descriptorpb.EnumDescriptorProto) (*crudOptions.EnumOptions, error) {
+	if enum.GetOptions() == nil {
+		return nil, nil
+	}
+	if !proto.HasExtension(enum.GetOptions(), crudOptions.E_CrudEnumOptions) {
+		return nil, nil
+	}
+	ext := proto.GetExtension(enum.GetOptions(), crudOptions.E_CrudEnumOptions)
+	opts, ok := ext.(*crudOptions.EnumOptions)
+	if !ok {
+		return nil, fmt.Errorf("extension is %T; want EnumOptions", ext)
+	}
+	return opts, nil
+}
+
 func extractMessageOptions(msg *descriptorpb.DescriptorProto) (*crudOptions.MessageOptions, error) {
 	if msg.GetOptions() == nil {
 		return nil, nil
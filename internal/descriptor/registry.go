@@ -47,6 +47,11 @@ func (r *Registry) load(gen *protogen.Plugin) error {
 	for _, filePath := range filePaths {
 		r.loadFile(filePath, gen.FilesByPath[filePath])
 	}
+	for _, filePath := range filePaths {
+		if err := r.loadEnums(r.files[filePath]); err != nil {
+			return fmt.Errorf("%s: %v", filePath, err)
+		}
+	}
 	for _, filePath := range filePaths {
 		if !gen.FilesByPath[filePath].Generate {
 			continue
@@ -71,6 +76,15 @@ func (r *Registry) load(gen *protogen.Plugin) error {
 			return fmt.Errorf("%s: %v", file.GetName(), err)
 		}
 	}
+	for _, filePath := range filePaths {
+		if !gen.FilesByPath[filePath].Generate {
+			continue
+		}
+		file := r.files[filePath]
+		if err := r.loadForeignKeys(file); err != nil {
+			return fmt.Errorf("%s: %v", file.GetName(), err)
+		}
+	}
 	return nil
 }
 
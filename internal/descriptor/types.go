@@ -3,6 +3,7 @@ package descriptor
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/samlitowitz/protoc-gen-crud/options"
@@ -73,6 +74,30 @@ func (f *File) Pkg() string {
 	return pkg
 }
 
+// MessagesInReferenceOrder orders the messages of this file so those referenced by foreign keys precede the messages
+// referencing them, otherwise following declaration order, so their tables can be created in order
+func (f *File) MessagesInReferenceOrder() []*Message {
+	msgs := make([]*Message, 0, len(f.Messages))
+	visited := make(map[*Message]struct{}, len(f.Messages))
+	var visit func(msg *Message)
+	visit = func(msg *Message) {
+		if _, ok := visited[msg]; ok {
+			return
+		}
+		visited[msg] = struct{}{}
+		for _, fk := range msg.ForeignKeys {
+			if fk.References.File == f {
+				visit(fk.References)
+			}
+		}
+		msgs = append(msgs, msg)
+	}
+	for _, msg := range f.Messages {
+		visit(msg)
+	}
+	return msgs
+}
+
 // proto2 determines if the syntax of the file is proto2.
 func (f *File) proto2() bool {
 	return f.Syntax == nil || f.GetSyntax() == "proto2"
@@ -109,6 +134,16 @@ type Message struct {
 	CreatedAt *Field
 	// UpdatedAt is the field definition of created at
 	UpdatedAt *Field
+	// DeletedAt is the field definition of deleted at, when set deletes are soft deletes
+	DeletedAt *Field
+	// Version is the field definition of the version, when set updates use optimistic locking
+	Version *Field
+	// IsolationLevel is the default isolation level of the transactions begun by creates and updates
+	IsolationLevel options.IsolationLevel
+	// TimestampSource is the clock the created, updated and deleted at times are taken from
+	TimestampSource options.TimestampSource
+	// ForeignKeys is the list of foreign keys referencing the primary keys of other messages
+	ForeignKeys []*ForeignKey
 
 	// primaryKey is a local cache
 	primaryKey []*Field
@@ -144,10 +179,7 @@ func (m *Message) PrimaryKey() []*Field {
 	if m.primaryKey != nil {
 		return m.primaryKey
 	}
-	m.primaryKey = make([]*Field, 0, len(m.PrimaryKeyByFQFN))
-	for _, field := range m.PrimaryKeyByFQFN {
-		m.primaryKey = append(m.primaryKey, field)
-	}
+	m.primaryKey = m.fieldsInDeclarationOrder(m.PrimaryKeyByFQFN)
 	return m.primaryKey
 }
 
@@ -157,7 +189,7 @@ func (m *Message) NonPrimeAttributes() []*Field {
 		return m.nonPrimeAttributes
 	}
 	m.nonPrimeAttributes = make([]*Field, 0, len(m.NonPrimeAttributesByFQFN))
-	for _, field := range m.NonPrimeAttributesByFQFN {
+	for _, field := range m.fieldsInDeclarationOrder(m.NonPrimeAttributesByFQFN) {
 		if field.Ignore {
 			continue
 		}
@@ -173,6 +205,45 @@ func (m *Message) NonPrimeAttributes() []*Field {
 	return m.nonPrimeAttributes
 }
 
+// fieldsInDeclarationOrder orders the fields of byFQFN as they are declared on the message so generated code is stable
+func (m *Message) fieldsInDeclarationOrder(byFQFN map[string]*Field) []*Field {
+	fields := make([]*Field, 0, len(byFQFN))
+	declared := make(map[string]struct{}, len(byFQFN))
+	for _, field := range m.Fields {
+		if _, ok := byFQFN[field.FQFN()]; !ok {
+			continue
+		}
+		fields = append(fields, field)
+		declared[field.FQFN()] = struct{}{}
+	}
+	// fields not declared on the message, e.g. those of generated relationship messages, follow in name order
+	var undeclared []string
+	for fqfn := range byFQFN {
+		if _, ok := declared[fqfn]; !ok {
+			undeclared = append(undeclared, fqfn)
+		}
+	}
+	sort.Strings(undeclared)
+	for _, fqfn := range undeclared {
+		fields = append(fields, byFQFN[fqfn])
+	}
+	return fields
+}
+
+// SequentialKey is the primary key field assigned by the database on create, if any
+func (m *Message) SequentialKey() *Field {
+	for _, field := range m.PrimaryKeyByFQFN {
+		if field.IsSequential() {
+			return field
+		}
+	}
+	return nil
+}
+
+func (m *Message) HasSequentialKey() bool {
+	return m.SequentialKey() != nil
+}
+
 func (m *Message) HasFieldMask() bool {
 	return m.FieldMask != nil
 }
@@ -185,6 +256,19 @@ func (m *Message) HasUpdatedAt() bool {
 	return m.UpdatedAt != nil
 }
 
+func (m *Message) HasDeletedAt() bool {
+	return m.DeletedAt != nil
+}
+
+func (m *Message) HasVersion() bool {
+	return m.Version != nil
+}
+
+// UsesDatabaseClock is true if the created, updated and deleted at times are taken from the database clock
+func (m *Message) UsesDatabaseClock() bool {
+	return m.TimestampSource == options.TimestampSource_TIMESTAMP_SOURCE_DATABASE
+}
+
 func (m *Message) FQMN() string {
 	components := []string{""}
 	if m.File.Package != nil {
@@ -234,6 +318,15 @@ type Enum struct {
 	Index int
 	// ForcePrefixedName when set to true, prefixes a type with a package prefix.
 	ForcePrefixedName bool
+
+	// CRUD Enum Options
+	// Storage is how the values of this enum are stored, it is never unspecified
+	Storage options.EnumStorage
+}
+
+// IsStoredByName is true if the values of this enum are stored as their names, as a native enum type or text
+func (e *Enum) IsStoredByName() bool {
+	return e.Storage == options.EnumStorage_ENUM_STORAGE_NATIVE || e.Storage == options.EnumStorage_ENUM_STORAGE_TEXT
 }
 
 // FQEN returns a fully qualified enum name of this enum.
@@ -269,6 +362,16 @@ type Relationship struct {
 	With      *Message
 }
 
+// ForeignKey wraps options.ForeignKey with the resolved fields and referenced message.
+type ForeignKey struct {
+	*options.ForeignKey
+
+	// Fields are the fields of the referencing message making up the foreign key
+	Fields []*Field
+	// References is the referenced message, the foreign key references its primary key
+	References *Message
+}
+
 // Field wraps descriptorpb.FieldDescriptorProto for richer features.
 type Field struct {
 	*descriptorpb.FieldDescriptorProto
@@ -284,12 +387,26 @@ type Field struct {
 	// CRUD Field Options
 	// AsTimestamp when set to true indicates that this field is to be treated as an implementation of `google.golang.org/protobuf/types/known/timestamppb.Timestamp`
 	AsTimestamp bool
+	// AutoGenerate is the strategy used to generate the value of this field on create
+	AutoGenerate options.AutoGenerationStrategy
 	// Ignore when set to true indicates this field is not to be used for or by and generated CRUD code
 	Ignore bool
 	// Inline when set to true indicates all scalar value fields on the message type associated with this field will be treated as if they were on the parent message
 	Inline bool
 	// Relationships contains meta-data defining the relationships with a non-scalar field
 	Relationships []*Relationship
+	// Nullable when set to true indicates the column of this field explicitly accepts NULL
+	Nullable bool
+	// NotNull when set to true indicates the column of this field is declared NOT NULL
+	NotNull bool
+	// Default is the SQL expression used as the default of the column of this field
+	Default string
+	// Check is the SQL expression the column of this field is checked against
+	Check string
+	// OnDelete is the action taken when the enum value referenced by this field is deleted
+	OnDelete options.ReferentialAction
+	// OnUpdate is the action taken when the id of the enum value referenced by this field is updated
+	OnUpdate options.ReferentialAction
 
 	// CRUD Derived Values
 	// IsPrimeAttribute is true if this field is a prime attribute, i.e. part of the primary key for the message it belongs to
@@ -309,10 +426,116 @@ func (f *Field) IsRepeated() bool {
 	return f.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REPEATED
 }
 
+// IsAutoGenerated is true if the value of this field is generated on create when unset
+func (f *Field) IsAutoGenerated() bool {
+	return f.AutoGenerate != options.AutoGenerationStrategy_AUTO_GENERATION_STRATEGY_UNSPECIFIED
+}
+
+// GeneratesUUID is true if the value of this field is a UUID generated on create when unset
+func (f *Field) GeneratesUUID() bool {
+	return f.AutoGenerate == options.AutoGenerationStrategy_AUTO_GENERATION_STRATEGY_UUID_V4 ||
+		f.AutoGenerate == options.AutoGenerationStrategy_AUTO_GENERATION_STRATEGY_UUID_V7
+}
+
+// IsSequential is true if the value of this field is assigned by the database on create when unset
+func (f *Field) IsSequential() bool {
+	return f.AutoGenerate == options.AutoGenerationStrategy_AUTO_GENERATION_STRATEGY_SEQUENTIAL
+}
+
+// IsVersion is true if this field is the version of its message used for optimistic locking
+func (f *Field) IsVersion() bool {
+	return f.Message != nil && f.Message.HasVersion() && f.Message.Version.FQFN() == f.FQFN()
+}
+
+// IsCreatedOrUpdatedAt is true if this field is the created or updated at time of its message
+func (f *Field) IsCreatedOrUpdatedAt() bool {
+	if f.Message == nil {
+		return false
+	}
+	return (f.Message.HasCreatedAt() && f.Message.CreatedAt.FQFN() == f.FQFN()) ||
+		(f.Message.HasUpdatedAt() && f.Message.UpdatedAt.FQFN() == f.FQFN())
+}
+
+// IsOptional is true if this field is a proto3 `optional` field, i.e. tracks presence, its column is nullable with
+// NULL read as the field being unset
+func (f *Field) IsOptional() bool {
+	return f.GetProto3Optional()
+}
+
+// IsWrapper is true if this field is a well-known wrapper type, e.g. google.protobuf.StringValue, stored as the value
+// it wraps
+func (f *Field) IsWrapper() bool {
+	if f.FieldMessage == nil {
+		return false
+	}
+	_, ok := wrapperTypes[f.FieldMessage.FQMN()]
+	return ok
+}
+
+// wrapperTypes are the well-known wrapper types, each wrapping a single field named value
+var wrapperTypes = map[string]struct{}{
+	".google.protobuf.DoubleValue": {},
+	".google.protobuf.FloatValue":  {},
+	".google.protobuf.Int64Value":  {},
+	".google.protobuf.UInt64Value": {},
+	".google.protobuf.Int32Value":  {},
+	".google.protobuf.UInt32Value": {},
+	".google.protobuf.BoolValue":   {},
+	".google.protobuf.StringValue": {},
+	".google.protobuf.BytesValue":  {},
+}
+
+// HasPresence is true if this field is optional or a wrapper type, its column is nullable with NULL read as the field
+// being unset
+func (f *Field) HasPresence() bool {
+	return f.IsOptional() || f.IsWrapper()
+}
+
+// ValueType is the type of the value stored for this field, the type wrapped by a wrapper type or the type of the
+// field otherwise
+func (f *Field) ValueType() descriptorpb.FieldDescriptorProto_Type {
+	if f.IsWrapper() {
+		return f.FieldMessage.Fields[0].GetType()
+	}
+	return f.GetType()
+}
+
+// ScansNullAsZero is true if NULL is read as the zero value of this field, i.e. its column is nullable but the field
+// does not track presence
+func (f *Field) ScansNullAsZero() bool {
+	return f.Nullable && !f.HasPresence() && !f.AsTimestamp
+}
+
 func (f *Field) HasRelationship() bool {
 	return len(f.Relationships) > 0
 }
 
+// IsNumeric is true if the field is an integer or floating point number, enums are not numeric
+func (f *Field) IsNumeric() bool {
+	switch f.ValueType() {
+	case descriptorpb.FieldDescriptorProto_TYPE_BOOL,
+		descriptorpb.FieldDescriptorProto_TYPE_ENUM,
+		descriptorpb.FieldDescriptorProto_TYPE_STRING,
+		descriptorpb.FieldDescriptorProto_TYPE_BYTES,
+		descriptorpb.FieldDescriptorProto_TYPE_MESSAGE,
+		descriptorpb.FieldDescriptorProto_TYPE_GROUP:
+		return false
+	}
+	return true
+}
+
+// IsUnsigned64 is true if the field is an unsigned 64 bit integer, whose values may not fit an int64
+func (f *Field) IsUnsigned64() bool {
+	return f.ValueType() == descriptorpb.FieldDescriptorProto_TYPE_UINT64 ||
+		f.ValueType() == descriptorpb.FieldDescriptorProto_TYPE_FIXED64
+}
+
+// IsFloatingPoint is true if the field is a floating point number
+func (f *Field) IsFloatingPoint() bool {
+	return f.ValueType() == descriptorpb.FieldDescriptorProto_TYPE_DOUBLE ||
+		f.ValueType() == descriptorpb.FieldDescriptorProto_TYPE_FLOAT
+}
+
 func (f *Field) GoType() string {
 	switch f.GetType() {
 	case descriptorpb.FieldDescriptorProto_TYPE_DOUBLE:
@@ -325,20 +548,24 @@ func (f *Field) GoType() string {
 		return ""
 
 	case descriptorpb.FieldDescriptorProto_TYPE_UINT32:
+		fallthrough
+	case descriptorpb.FieldDescriptorProto_TYPE_FIXED32:
 		return "uint32"
 	case descriptorpb.FieldDescriptorProto_TYPE_UINT64:
+		fallthrough
+	case descriptorpb.FieldDescriptorProto_TYPE_FIXED64:
 		return "uint64"
 
 	case descriptorpb.FieldDescriptorProto_TYPE_INT32:
 		fallthrough
-	case descriptorpb.FieldDescriptorProto_TYPE_FIXED32:
+	case descriptorpb.FieldDescriptorProto_TYPE_SFIXED32:
 		fallthrough
 	case descriptorpb.FieldDescriptorProto_TYPE_SINT32:
 		return "int32"
 
 	case descriptorpb.FieldDescriptorProto_TYPE_INT64:
 		fallthrough
-	case descriptorpb.FieldDescriptorProto_TYPE_FIXED64:
+	case descriptorpb.FieldDescriptorProto_TYPE_SFIXED64:
 		fallthrough
 	case descriptorpb.FieldDescriptorProto_TYPE_SINT64:
 		return "int64"
@@ -511,12 +738,16 @@ func isScalarGoType(typ descriptorpb.FieldDescriptorProto_Type) bool {
 		fallthrough
 	case descriptorpb.FieldDescriptorProto_TYPE_FIXED32:
 		fallthrough
+	case descriptorpb.FieldDescriptorProto_TYPE_SFIXED32:
+		fallthrough
 	case descriptorpb.FieldDescriptorProto_TYPE_SINT32:
 		fallthrough
 	case descriptorpb.FieldDescriptorProto_TYPE_INT64:
 		fallthrough
 	case descriptorpb.FieldDescriptorProto_TYPE_FIXED64:
 		fallthrough
+	case descriptorpb.FieldDescriptorProto_TYPE_SFIXED64:
+		fallthrough
 	case descriptorpb.FieldDescriptorProto_TYPE_SINT64:
 		fallthrough
 	case descriptorpb.FieldDescriptorProto_TYPE_BYTES:
@@ -34,7 +34,13 @@ func New(reg *descriptor.Registry, opts ...Option) gen.Generator {
 	var imports []descriptor.GoPackage
 	for _, pkgpath := range []string{
 		"context",
+		"encoding/base64",
+		"encoding/json",
+		"fmt",
+		"iter",
+		"strings",
 		"github.com/samlitowitz/expressions",
+		"google.golang.org/protobuf/types/known/fieldmaskpb",
 	} {
 		pkg := descriptor.GoPackage{
 			Path: pkgpath,
@@ -52,6 +58,13 @@ func New(reg *descriptor.Registry, opts ...Option) gen.Generator {
 		}
 		imports = append(imports, pkg)
 	}
+	// aliased as in the implementation specific files
+	runtimePkg := descriptor.GoPackage{
+		Path:  "github.com/samlitowitz/protoc-gen-crud/runtime",
+		Name:  "runtime",
+		Alias: "crudruntime",
+	}
+	imports = append(imports, runtimePkg)
 	return &generator{
 		reg:         reg,
 		baseImports: imports,
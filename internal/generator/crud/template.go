@@ -10,9 +10,11 @@ import (
 	"text/template"
 
 	"github.com/iancoleman/strcase"
+	"google.golang.org/protobuf/types/descriptorpb"
 
 	"github.com/samlitowitz/protoc-gen-crud/internal/casing"
 	"github.com/samlitowitz/protoc-gen-crud/internal/descriptor"
+	crudOptions "github.com/samlitowitz/protoc-gen-crud/options"
 )
 
 func init() {
@@ -44,6 +46,45 @@ func FieldIDConstantValue(f *QueryableField) string {
 	return hex.EncodeToString(h.Sum(nil))
 }
 
+// KeyFieldGoType is the Go type of a primary key field within a generated key struct, bytes are held as a string so
+// the key stays comparable
+func KeyFieldGoType(f *descriptor.Field, currentPackage string) string {
+	if f.FieldEnum != nil {
+		return f.FieldEnum.GoType(currentPackage)
+	}
+	return f.GoType()
+}
+
+// KeyFieldValue converts value, of the Go type of the primary key field f, to its type within a generated key struct
+func KeyFieldValue(f *descriptor.Field, value string) string {
+	if f.GetType() == descriptorpb.FieldDescriptorProto_TYPE_BYTES {
+		return fmt.Sprintf("string(%s)", value)
+	}
+	return value
+}
+
+// KeyFieldBind converts value, a primary key field f of a generated key struct, back to the Go type of f
+func KeyFieldBind(f *descriptor.Field, value string) string {
+	if f.GetType() == descriptorpb.FieldDescriptorProto_TYPE_BYTES {
+		return fmt.Sprintf("[]byte(%s)", value)
+	}
+	return value
+}
+
+// IsolationLevelConstant is the database/sql isolation level constant of level
+func IsolationLevelConstant(level crudOptions.IsolationLevel) string {
+	switch level {
+	case crudOptions.IsolationLevel_ISOLATION_LEVEL_READ_UNCOMMITTED:
+		return "sql.LevelReadUncommitted"
+	case crudOptions.IsolationLevel_ISOLATION_LEVEL_READ_COMMITTED:
+		return "sql.LevelReadCommitted"
+	case crudOptions.IsolationLevel_ISOLATION_LEVEL_REPEATABLE_READ:
+		return "sql.LevelRepeatableRead"
+	default:
+		return "sql.LevelSerializable"
+	}
+}
+
 type param struct {
 	*descriptor.File
 	Imports []descriptor.GoPackage
@@ -98,6 +139,10 @@ import (
 		"fieldIDConstantName":        FieldIDConstantName,
 		"fieldIDConstantValue":       FieldIDConstantValue,
 		"queryableFieldsFromMessage": QueryableFieldsFromMessage,
+		"queryableFieldsFromFields":  QueryableFieldsFromFields,
+		"toLowerCamel":               strcase.ToLowerCamel,
+		"keyFieldGoType":             KeyFieldGoType,
+		"keyFieldValue":              KeyFieldValue,
 	}
 
 	repositoryConstantsAndInterfaceTemplate = template.Must(template.New("repository-constants-and-interface").Funcs(funcMap).Parse(`
@@ -114,20 +159,256 @@ var valid{{camelIdentifier .GetName}}Fields = map[expressions.ID]struct{}{
 {{- end}}
 }
 
+// {{.GetName}}OrderBy orders {{.GetName}}s by a single field
+type {{.GetName}}OrderBy struct {
+	// Field is one of the {{.GetName}}_*_Field constants
+	Field expressions.ID
+	// Descending orders from greatest to least
+	Descending bool
+}
+
+// {{.GetName}}ReadOptions control the ordering and pagination of ReadWithOptions
+type {{.GetName}}ReadOptions struct {
+	// OrderBy is applied in sequence, primary key fields not present are appended as tie breakers
+	OrderBy []{{.GetName}}OrderBy
+	// Limit is the maximum number of {{.GetName}}s returned, zero is unlimited
+	Limit int
+	// Offset is the number of {{.GetName}}s skipped, it cannot be combined with PageToken
+	Offset int
+	// PageToken is the next page token returned by a previous call with the same criteria and ordering
+	PageToken string
+	// Fields are the {{.GetName}}_*_Field constants of the fields read, all fields are read when empty.
+	// Primary key fields and those ordered by are always read, all others are left unset.
+	Fields []expressions.ID
+}
+
+// {{toLowerCamel .GetName}}FieldMaskPathByFieldID maps field ID constants to the paths selecting them in a field mask
+var {{toLowerCamel .GetName}}FieldMaskPathByFieldID = map[expressions.ID]string{
+{{- range $field := queryableFieldsFromMessage .Message}}
+	{{fieldIDConstantName $field}}: "{{if $field.IsInlined}}{{$field.Parent.GetName}}.{{end}}{{$field.GetName}}",
+{{- end}}
+}
+
+// {{.GetName}}FieldsFromFieldMask returns the field ID constants of the fields selected by fieldMask for
+// {{.GetName}}ReadOptions.Fields, a path to an inlined message selects all of its fields
+func {{.GetName}}FieldsFromFieldMask(fieldMask *fieldmaskpb.FieldMask) ([]expressions.ID, error) {
+	var fields []expressions.ID
+	for _, path := range fieldMask.GetPaths() {
+		matched := false
+		for _, field := range []expressions.ID{
+		{{- range $field := queryableFieldsFromMessage .Message}}
+			{{fieldIDConstantName $field}},
+		{{- end}}
+		} {
+			fieldPath := {{toLowerCamel .GetName}}FieldMaskPathByFieldID[field]
+			if fieldPath != path && !strings.HasPrefix(fieldPath, path + ".") {
+				continue
+			}
+			fields = append(fields, field)
+			matched = true
+		}
+		if !matched {
+			return nil, fmt.Errorf("invalid field mask path: %s", path)
+		}
+	}
+	return fields, nil
+}
+
+// {{toLowerCamel .GetName}}FieldsWithOrderBy validates fields and appends the fields of orderBy it does not include,
+// nil is returned when fields is empty as all fields are read
+func {{toLowerCamel .GetName}}FieldsWithOrderBy(fields []expressions.ID, orderBy []{{.GetName}}OrderBy) ([]expressions.ID, error) {
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	selected := make(map[expressions.ID]struct{}, len(fields) + len(orderBy))
+	withOrderBy := make([]expressions.ID, 0, len(fields) + len(orderBy))
+	for _, field := range fields {
+		if _, ok := valid{{camelIdentifier .GetName}}Fields[field]; !ok {
+			return nil, fmt.Errorf("invalid field id: %s", field)
+		}
+		if _, ok := selected[field]; ok {
+			continue
+		}
+		selected[field] = struct{}{}
+		withOrderBy = append(withOrderBy, field)
+	}
+	for _, by := range orderBy {
+		if _, ok := selected[by.Field]; ok {
+			continue
+		}
+		selected[by.Field] = struct{}{}
+		withOrderBy = append(withOrderBy, by.Field)
+	}
+	return withOrderBy, nil
+}
+
+// {{toLowerCamel .GetName}}PageToken is the decoded form of an opaque {{.GetName}} page token
+type {{toLowerCamel .GetName}}PageToken struct {
+	OrderBy []{{.GetName}}OrderBy ` + "`" + `json:"orderBy"` + "`" + `
+	// Values are the ordered field values of the last {{.GetName}} of the previous page
+	Values []json.RawMessage ` + "`" + `json:"values"` + "`" + `
+}
+
+func encode{{.GetName}}PageToken(token *{{toLowerCamel .GetName}}PageToken) (string, error) {
+	data, err := json.Marshal(token)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decode{{.GetName}}PageToken(pageToken string) (*{{toLowerCamel .GetName}}PageToken, error) {
+	data, err := base64.RawURLEncoding.DecodeString(pageToken)
+	if err != nil {
+		return nil, fmt.Errorf("invalid page token: %w", err)
+	}
+	token := &{{toLowerCamel .GetName}}PageToken{}
+	if err := json.Unmarshal(data, token); err != nil {
+		return nil, fmt.Errorf("invalid page token: %w", err)
+	}
+	if len(token.OrderBy) != len(token.Values) {
+		return nil, fmt.Errorf("invalid page token: ordering and values mismatch")
+	}
+	return token, nil
+}
+
+// {{toLowerCamel .GetName}}OrderByWithPrimaryKey validates orderBy and appends the primary key fields it does not order by,
+// the resulting ordering is total which keyset pagination relies on
+func {{toLowerCamel .GetName}}OrderByWithPrimaryKey(orderBy []{{.GetName}}OrderBy) ([]{{.GetName}}OrderBy, error) {
+	ordered := make(map[expressions.ID]struct{}, len(orderBy))
+	withPrimaryKey := make([]{{.GetName}}OrderBy, 0, len(orderBy) + {{len .PrimaryKey}})
+	for _, by := range orderBy {
+		if _, ok := valid{{camelIdentifier .GetName}}Fields[by.Field]; !ok {
+			return nil, fmt.Errorf("invalid field id: %s", by.Field)
+		}
+		if _, ok := ordered[by.Field]; ok {
+			return nil, fmt.Errorf("duplicate order by field id: %s", by.Field)
+		}
+		ordered[by.Field] = struct{}{}
+		withPrimaryKey = append(withPrimaryKey, by)
+	}
+	for _, field := range []expressions.ID{
+	{{- range $field := queryableFieldsFromFields .PrimaryKey}}
+		{{fieldIDConstantName $field}},
+	{{- end}}
+	} {
+		if _, ok := ordered[field]; ok {
+			continue
+		}
+		withPrimaryKey = append(withPrimaryKey, {{.GetName}}OrderBy{Field: field})
+	}
+	return withPrimaryKey, nil
+}
+
+// {{.GetName}}Aggregate applies an aggregate function to a numeric field
+type {{.GetName}}Aggregate struct {
+	Function crudruntime.AggregateFunction
+	// Field is one of the numeric {{.GetName}}_*_Field constants
+	Field expressions.ID
+}
+
+// {{.GetName}}AggregateOptions select the aggregates computed by Aggregate and how {{.GetName}}s are grouped
+type {{.GetName}}AggregateOptions struct {
+	Aggregates []{{.GetName}}Aggregate
+	// GroupBy are {{.GetName}}_*_Field constants, {{.GetName}}s sharing their values form a group.
+	// Without any all {{.GetName}}s form a single group.
+	GroupBy []expressions.ID
+}
+
+// {{.GetName}}AggregateResult holds the aggregates of a single group
+type {{.GetName}}AggregateResult struct {
+	// Group holds the values of the GroupBy fields shared by the group, all other fields are unset
+	Group *{{.GoType .File.GoPkg.Path}}
+	// Count is the number of {{.GetName}}s in the group
+	Count int64
+	// Values are the results of the Aggregates, in order
+	Values []crudruntime.AggregateValue
+}
+
+// {{.GetName}}Key identifies a single {{.GetName}} by its primary key
+type {{.GetName}}Key struct {
+{{- range $field := .PrimaryKey}}
+	{{camelIdentifier $field.GetName}} {{keyFieldGoType $field $.File.GoPkg.Path}}
+{{- end}}
+}
+
+// {{.GetName}}KeyOf returns the key identifying {{toLowerCamel .GetName}}
+func {{.GetName}}KeyOf({{toLowerCamel .GetName}} *{{.GoType .File.GoPkg.Path}}) {{.GetName}}Key {
+	return {{.GetName}}Key{
+	{{- range $field := .PrimaryKey}}
+		{{camelIdentifier $field.GetName}}: {{keyFieldValue $field (print (toLowerCamel $.GetName) ".Get" (camelIdentifier $field.GetName) "()")}},
+	{{- end}}
+	}
+}
+
 type {{.GetName}}Repository interface {
 	// Create creates new {{.GetName}}s.
 	// Successfully created {{.GetName}}s are returned along with any errors that may have occurred.
+	// The columns written are read back from the database, so values computed by the database, such as generated keys,
+	// are reflected in the {{.GetName}}s returned.
+	// When the repository's batch mode is runtime.BatchPartialSuccess each {{.GetName}} succeeds or fails on its own,
+	// the {{.GetName}}s returned are aligned with the input and the failures are returned as a *runtime.BatchError.
 	Create(context.Context, []*{{.GoType .File.GoPkg.Path}}) ([]*{{.GoType .File.GoPkg.Path}}, error)
 
 	// Read returns a set of {{.GetName}}s matching the provided criteria
 	Read(context.Context, expressions.Expression) ([]*{{.GoType .File.GoPkg.Path}}, error)
 
+	// Get returns the {{.GetName}} identified by key, runtime.ErrNotFound is returned when it does not exist
+	Get(context.Context, {{.GetName}}Key) (*{{.GoType .File.GoPkg.Path}}, error)
+
+	// GetMany returns the {{.GetName}}s identified by keys, in the order of keys, using a single query.
+	// When any does not exist its position is nil and runtime.ErrNotFound is returned along with the others.
+	GetMany(context.Context, []{{.GetName}}Key) ([]*{{.GoType .File.GoPkg.Path}}, error)
+
+	// ReadIter lazily yields the {{.GetName}}s matching the provided criteria.
+	// Rows are scanned as they are consumed and released when iteration stops.
+	ReadIter(context.Context, expressions.Expression) iter.Seq2[*{{.GoType .File.GoPkg.Path}}, error]
+
+	// ReadWithOptions returns an ordered page of {{.GetName}}s matching the provided criteria.
+	// The next page token is returned when more {{.GetName}}s remain, otherwise it is empty.
+	ReadWithOptions(context.Context, expressions.Expression, *{{.GetName}}ReadOptions) ([]*{{.GoType .File.GoPkg.Path}}, string, error)
+
+	// Count returns the number of {{.GetName}}s matching the provided criteria
+	Count(context.Context, expressions.Expression) (int64, error)
+
+	// Exists reports whether any {{.GetName}} matches the provided criteria
+	Exists(context.Context, expressions.Expression) (bool, error)
+
+	// Aggregate computes aggregates of the {{.GetName}}s matching the provided criteria, one result per group ordered by
+	// the GroupBy fields
+	Aggregate(context.Context, expressions.Expression, *{{.GetName}}AggregateOptions) ([]*{{.GetName}}AggregateResult, error)
+
 	// Update modifies existing {{.GetName}}s based on the defined unique identifiers.
-	// Successfully modified {{.GetName}}s are returned along with any errors that may have occurred.
+	// Successfully modified {{.GetName}}s are returned along with any errors that may have occurred, a {{.GetName}} which
+	// does not exist fails with runtime.ErrNotFound.
+	{{- if .HasVersion}}
+	// A {{.GetName}} whose version no longer matches fails with runtime.ErrVersionConflict, the versions of the
+	// {{.GetName}}s updated are incremented.
+	{{- end}}
+	// As for Create, the columns written are read back from the database.
+	// When the repository's batch mode is runtime.BatchPartialSuccess each {{.GetName}} succeeds or fails on its own,
+	// the {{.GetName}}s returned are aligned with the input and the failures are returned as a *runtime.BatchError.
 	Update(context.Context, []*{{.GoType .File.GoPkg.Path}}) ([]*{{.GoType .File.GoPkg.Path}}, error)
 
-	// Delete deletes {{.GetName}}s matching the provided criteria
-	Delete(context.Context,  expressions.Expression) error
+	// Upsert creates {{.GetName}}s or, when one with the same unique identifiers exists, updates it instead.
+	// Successfully upserted {{.GetName}}s are returned along with any errors that may have occurred.
+	// As for Create, the columns written are read back from the database.
+	Upsert(context.Context, []*{{.GoType .File.GoPkg.Path}}) ([]*{{.GoType .File.GoPkg.Path}}, error)
+
+	{{- if .HasDeletedAt}}
+	// Delete soft deletes {{.GetName}}s matching the provided criteria, the number soft deleted is returned
+	Delete(context.Context, expressions.Expression) (int64, error)
+
+	// Restore restores soft deleted {{.GetName}}s matching the provided criteria, the number restored is returned
+	Restore(context.Context, expressions.Expression) (int64, error)
+
+	// Purge permanently deletes {{.GetName}}s, soft deleted or not, matching the provided criteria, the number deleted
+	// is returned
+	Purge(context.Context, expressions.Expression) (int64, error)
+	{{- else}}
+	// Delete deletes {{.GetName}}s matching the provided criteria, the number deleted is returned
+	Delete(context.Context, expressions.Expression) (int64, error)
+	{{- end}}
 }
 `))
 )
@@ -119,6 +119,10 @@ func (g *generator) addMessagePathParamImports(file *descriptor.File, msg *descr
 		if f.Ignore {
 			continue
 		}
+		// deleted at is only ever written, never scanned
+		if f == msg.DeletedAt {
+			continue
+		}
 		if f.AsTimestamp && !pkgSeen["github.com/jackc/pgx/v5/pgtype"] {
 			pkgSeen["github.com/jackc/pgx/v5/pgtype"] = true
 			imports = append(imports, descriptor.GoPackage{Path: "github.com/jackc/pgx/v5/pgtype", Name: "pgtype"})
@@ -151,10 +155,22 @@ func (g *generator) addCrudPathParamImports(msg *descriptor.Message, pkgSeen map
 			pkgSeen["database/sql"] = true
 			imports = append(imports, descriptor.GoPackage{Path: "database/sql", Name: "sql"})
 		}
+		if !pkgSeen["encoding/json"] {
+			pkgSeen["encoding/json"] = true
+			imports = append(imports, descriptor.GoPackage{Path: "encoding/json", Name: "json"})
+		}
 		if !pkgSeen["fmt"] {
 			pkgSeen["fmt"] = true
 			imports = append(imports, descriptor.GoPackage{Path: "fmt", Name: "fmt"})
 		}
+		if !pkgSeen["iter"] {
+			pkgSeen["iter"] = true
+			imports = append(imports, descriptor.GoPackage{Path: "iter", Name: "iter"})
+		}
+		if !pkgSeen["slices"] {
+			pkgSeen["slices"] = true
+			imports = append(imports, descriptor.GoPackage{Path: "slices", Name: "slices"})
+		}
 		if !pkgSeen["strings"] {
 			pkgSeen["strings"] = true
 			imports = append(imports, descriptor.GoPackage{Path: "strings", Name: "strings"})
@@ -175,6 +191,18 @@ func (g *generator) addCrudPathParamImports(msg *descriptor.Message, pkgSeen map
 			pkgSeen["github.com/samlitowitz/expressions"] = true
 			imports = append(imports, descriptor.GoPackage{Path: "github.com/samlitowitz/expressions", Name: "expressions"})
 		}
+		if !pkgSeen["github.com/samlitowitz/protoc-gen-crud/expressions"] {
+			pkgSeen["github.com/samlitowitz/protoc-gen-crud/expressions"] = true
+			imports = append(imports, descriptor.GoPackage{Path: "github.com/samlitowitz/protoc-gen-crud/expressions", Name: "expressions", Alias: "crudexpressions"})
+		}
+		if !pkgSeen["github.com/samlitowitz/protoc-gen-crud/runtime"] {
+			pkgSeen["github.com/samlitowitz/protoc-gen-crud/runtime"] = true
+			imports = append(imports, descriptor.GoPackage{Path: "github.com/samlitowitz/protoc-gen-crud/runtime", Name: "runtime", Alias: "crudruntime"})
+		}
+		if !pkgSeen["github.com/samlitowitz/protoc-gen-crud/runtime/pgsql"] {
+			pkgSeen["github.com/samlitowitz/protoc-gen-crud/runtime/pgsql"] = true
+			imports = append(imports, descriptor.GoPackage{Path: "github.com/samlitowitz/protoc-gen-crud/runtime/pgsql", Name: "pgsql", Alias: "crudpgsql"})
+		}
 		if !pkgSeen["time"] {
 			pkgSeen["time"] = true
 			imports = append(imports, descriptor.GoPackage{Path: "time", Name: "time"})
@@ -2,9 +2,12 @@ package pgsql
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/samlitowitz/protoc-gen-crud/internal/generator/crud"
 
+	"github.com/samlitowitz/protoc-gen-crud/options"
+
 	"github.com/iancoleman/strcase"
 	"google.golang.org/protobuf/types/descriptorpb"
 )
@@ -42,7 +45,7 @@ func (col *Column) GetComment() string {
 		return ""
 	}
 
-	switch col.Field.GetType() {
+	switch col.Field.ValueType() {
 	case descriptorpb.FieldDescriptorProto_TYPE_DOUBLE:
 		fallthrough
 	case descriptorpb.FieldDescriptorProto_TYPE_FLOAT:
@@ -57,25 +60,24 @@ func (col *Column) GetComment() string {
 		fallthrough
 	case descriptorpb.FieldDescriptorProto_TYPE_FIXED32:
 		fallthrough
+	case descriptorpb.FieldDescriptorProto_TYPE_SFIXED32:
+		fallthrough
 	case descriptorpb.FieldDescriptorProto_TYPE_SINT32:
 		fallthrough
 	case descriptorpb.FieldDescriptorProto_TYPE_INT64:
 		fallthrough
 	case descriptorpb.FieldDescriptorProto_TYPE_FIXED64:
 		fallthrough
+	case descriptorpb.FieldDescriptorProto_TYPE_SFIXED64:
+		fallthrough
 	case descriptorpb.FieldDescriptorProto_TYPE_SINT64:
 		fallthrough
 	case descriptorpb.FieldDescriptorProto_TYPE_BYTES:
 		fallthrough
 	case descriptorpb.FieldDescriptorProto_TYPE_STRING:
-		return ""
-
+		fallthrough
 	case descriptorpb.FieldDescriptorProto_TYPE_ENUM:
-		return fmt.Sprintf(
-			" /* references %s.%s */",
-			QuotedIdent(col.FieldEnum.GetName()),
-			QuotedIdent("id"),
-		)
+		return ""
 
 	case descriptorpb.FieldDescriptorProto_TYPE_MESSAGE:
 		fallthrough
@@ -86,44 +88,112 @@ func (col *Column) GetComment() string {
 	}
 }
 
+// StoresEnumByName is true if the column stores the names of the values of an enum, as a native enum type or text
+func (col *Column) StoresEnumByName() bool {
+	return col.Field.GetType() == descriptorpb.FieldDescriptorProto_TYPE_ENUM && col.FieldEnum.IsStoredByName()
+}
+
+// GetReferences returns the REFERENCES clause of the column, enum columns stored as integers reference the id of their
+// look-up table while those stored as text are checked against the names of the values of the enum
+func (col *Column) GetReferences() string {
+	if col.Field.GetType() != descriptorpb.FieldDescriptorProto_TYPE_ENUM {
+		return ""
+	}
+	switch col.FieldEnum.Storage {
+	case options.EnumStorage_ENUM_STORAGE_NATIVE:
+		return ""
+	case options.EnumStorage_ENUM_STORAGE_TEXT:
+		names := make([]string, 0, len(col.FieldEnum.GetValue()))
+		for _, value := range col.FieldEnum.GetValue() {
+			names = append(names, "'"+value.GetName()+"'")
+		}
+		return fmt.Sprintf(" CHECK (%s IN (%s))", QuotedIdent(col.GetName()), strings.Join(names, ", "))
+	}
+	return fmt.Sprintf(
+		" REFERENCES %s (%s)%s",
+		QuotedIdent(col.FieldEnum.GetName()),
+		QuotedIdent("id"),
+		ReferentialActions(col.OnDelete, col.OnUpdate),
+	)
+}
+
+// ReferentialActions returns the ON DELETE and ON UPDATE clauses of a reference, unspecified actions are omitted
+func ReferentialActions(onDelete, onUpdate options.ReferentialAction) string {
+	var clauses string
+	if action := referentialAction(onDelete); action != "" {
+		clauses += " ON DELETE " + action
+	}
+	if action := referentialAction(onUpdate); action != "" {
+		clauses += " ON UPDATE " + action
+	}
+	return clauses
+}
+
+func referentialAction(action options.ReferentialAction) string {
+	switch action {
+	case options.ReferentialAction_REFERENTIAL_ACTION_CASCADE:
+		return "CASCADE"
+	case options.ReferentialAction_REFERENTIAL_ACTION_RESTRICT:
+		return "RESTRICT"
+	case options.ReferentialAction_REFERENTIAL_ACTION_SET_NULL:
+		return "SET NULL"
+	default:
+		return ""
+	}
+}
+
 func (col *Column) GetType() string {
 	if col.AsTimestamp {
 		return "TIMESTAMP WITH TIME ZONE"
 	}
 
-	switch col.Field.GetType() {
+	switch col.Field.ValueType() {
 	case descriptorpb.FieldDescriptorProto_TYPE_DOUBLE:
-		fallthrough
+		return "DOUBLE PRECISION"
 	case descriptorpb.FieldDescriptorProto_TYPE_FLOAT:
 		return "REAL"
 
 	case descriptorpb.FieldDescriptorProto_TYPE_BOOL:
-		return "INTEGER"
+		return "BOOLEAN"
 
-	case descriptorpb.FieldDescriptorProto_TYPE_UINT32:
-		fallthrough
-	case descriptorpb.FieldDescriptorProto_TYPE_UINT64:
-		fallthrough
 	case descriptorpb.FieldDescriptorProto_TYPE_INT32:
 		fallthrough
-	case descriptorpb.FieldDescriptorProto_TYPE_FIXED32:
+	case descriptorpb.FieldDescriptorProto_TYPE_SFIXED32:
 		fallthrough
 	case descriptorpb.FieldDescriptorProto_TYPE_SINT32:
+		return "INTEGER"
+
+	// unsigned 32-bit integers exceed INTEGER, which is signed
+	case descriptorpb.FieldDescriptorProto_TYPE_UINT32:
+		fallthrough
+	case descriptorpb.FieldDescriptorProto_TYPE_FIXED32:
 		fallthrough
 	case descriptorpb.FieldDescriptorProto_TYPE_INT64:
 		fallthrough
-	case descriptorpb.FieldDescriptorProto_TYPE_FIXED64:
+	case descriptorpb.FieldDescriptorProto_TYPE_SFIXED64:
 		fallthrough
 	case descriptorpb.FieldDescriptorProto_TYPE_SINT64:
-		return "INTEGER"
+		return "BIGINT"
+
+	// unsigned 64-bit integers exceed BIGINT, which is signed
+	case descriptorpb.FieldDescriptorProto_TYPE_UINT64:
+		fallthrough
+	case descriptorpb.FieldDescriptorProto_TYPE_FIXED64:
+		return "NUMERIC(20)"
 
 	case descriptorpb.FieldDescriptorProto_TYPE_BYTES:
-		return "BLOB"
+		return "BYTEA"
 
 	case descriptorpb.FieldDescriptorProto_TYPE_STRING:
 		return "TEXT"
 
 	case descriptorpb.FieldDescriptorProto_TYPE_ENUM:
+		switch col.FieldEnum.Storage {
+		case options.EnumStorage_ENUM_STORAGE_NATIVE:
+			return QuotedIdent(col.FieldEnum.GetName())
+		case options.EnumStorage_ENUM_STORAGE_TEXT:
+			return "TEXT"
+		}
 		// Enums will reference a look-up table
 		return "INTEGER"
 
@@ -38,7 +38,7 @@ func applyTemplate(p param, reg *descriptor.Registry) (string, error) {
 
 	//return "", fmt.Errorf("%v", p.Messages)
 
-	for _, msg := range p.Messages {
+	for _, msg := range p.MessagesInReferenceOrder() {
 		if !msg.GenerateCRUD {
 			continue
 		}
@@ -57,9 +57,19 @@ func applyTemplate(p param, reg *descriptor.Registry) (string, error) {
 				continue
 			}
 
-			if err := createTableForEnumTemplate.Execute(w, &enum{field.FieldEnum}); err != nil {
-				return "", fmt.Errorf("%s: %s: create enum table: %v", field.GetName(), field.FieldEnum.GetName(), err)
+			switch field.FieldEnum.Storage {
+			case options.EnumStorage_ENUM_STORAGE_NATIVE:
+				if err := createTypeForEnumTemplate.Execute(w, &enum{field.FieldEnum}); err != nil {
+					return "", fmt.Errorf("%s: %s: create enum type: %v", field.GetName(), field.FieldEnum.GetName(), err)
+				}
+			case options.EnumStorage_ENUM_STORAGE_TEXT:
+				// the values are checked by the column
+			default:
+				if err := createTableForEnumTemplate.Execute(w, &enum{field.FieldEnum}); err != nil {
+					return "", fmt.Errorf("%s: %s: create enum table: %v", field.GetName(), field.FieldEnum.GetName(), err)
+				}
 			}
+			completedEnums[field.FieldEnum.FQEN()] = struct{}{}
 		}
 
 		injected := &message{
@@ -76,12 +86,15 @@ func applyTemplate(p param, reg *descriptor.Registry) (string, error) {
 
 var (
 	funcMap template.FuncMap = map[string]interface{}{
-		"quotedIdent": genPgSQL.QuotedIdent,
+		"quotedIdent":        genPgSQL.QuotedIdent,
+		"referentialActions": genPgSQL.ReferentialActions,
 	}
 
 	// https://www.pgsql.org/lang_createtable.html
+	// CASCADE drops the foreign key constraints of the tables of other files referencing the table, those files must be
+	// run again to recreate them
 	createTableForMessageTemplate = template.Must(template.New("create-table-for-message").Funcs(funcMap).Parse(`
-DROP TABLE IF EXISTS {{quotedIdent .GetName}};
+DROP TABLE IF EXISTS {{quotedIdent .GetName}} CASCADE;
 CREATE TABLE IF NOT EXISTS {{quotedIdent .GetName}} (
 {{- range $i, $col := .PrimaryKeyCols -}}
     {{- if $i}},{{end}}
@@ -103,15 +116,29 @@ CREATE TABLE IF NOT EXISTS {{quotedIdent .GetName}} (
     {{- end}}
     )
     {{- end}}
+    {{- range $fk := .ForeignKeys -}}
+        ,
+
+    FOREIGN KEY (
+    {{- range $i, $field := $fk.Fields -}}
+        {{- if $i}}, {{end}}{{quotedIdent $field.GetName}}
+    {{- end -}}
+    ) REFERENCES {{quotedIdent $fk.References.GetName}} (
+    {{- range $i, $field := $fk.References.PrimaryKey -}}
+        {{- if $i}}, {{end}}{{quotedIdent $field.GetName}}
+    {{- end -}}
+    ){{referentialActions $fk.GetOnDelete $fk.GetOnUpdate}}
+    {{- end}}
 );
 `))
 
 	_ = template.Must(createTableForMessageTemplate.New("column-definition").Funcs(funcMap).Parse(`
-    {{- quotedIdent .GetName}} {{.GetType}}{{.GetComment -}}
+    {{- quotedIdent .GetName}} {{.GetType}}{{if .IsSequential}} GENERATED BY DEFAULT AS IDENTITY{{end}}{{if .IsVersion}} NOT NULL DEFAULT 0{{end}}{{if and .IsCreatedOrUpdatedAt .Message.UsesDatabaseClock}} NOT NULL DEFAULT now(){{end}}{{if .NotNull}} NOT NULL{{end}}{{with .Default}} DEFAULT ({{.}}){{end}}{{with .Check}} CHECK ({{.}}){{end}}{{.GetReferences}}{{.GetComment -}}
 `))
 
+	// enum look-up tables are referenced by the tables of other messages, possibly in other files, so they are upserted
+	// rather than dropped and recreated
 	createTableForEnumTemplate = template.Must(template.New("create-table-for-enum").Funcs(funcMap).Parse(`
-DROP TABLE IF EXISTS {{quotedIdent .GetName}};
 CREATE TABLE IF NOT EXISTS {{quotedIdent .GetName}} (
     "id" INTEGER PRIMARY KEY,
     "value" TEXT
@@ -122,6 +149,29 @@ INSERT INTO {{quotedIdent .GetName}} ("id", "value") VALUES
     {{- if $i}},{{end}}
     ({{$valDesc.GetNumber}}, '{{$valDesc.GetName}}')
 {{- end}}
+ON CONFLICT ("id") DO UPDATE SET "value" = excluded."value"
 ;
+`))
+
+	// enum types are used by the tables of other messages, possibly in other files, so they are only created when they
+	// do not exist and values missing from an existing type are added
+	createTypeForEnumTemplate = template.Must(template.New("create-type-for-enum").Funcs(funcMap).Parse(`
+DO $$
+BEGIN
+    CREATE TYPE {{quotedIdent .GetName}} AS ENUM (
+    {{- range $i, $valDesc := .GetValue}}
+        {{- if $i}},{{end}}
+        '{{$valDesc.GetName}}'
+    {{- end}}
+    );
+EXCEPTION
+    WHEN duplicate_object THEN NULL;
+END
+$$;
+{{- $prev := ""}}
+{{- range $valDesc := .GetValue}}
+ALTER TYPE {{quotedIdent $.GetName}} ADD VALUE IF NOT EXISTS '{{$valDesc.GetName}}'{{with $prev}} AFTER '{{.}}'{{end}};
+{{- $prev = $valDesc.GetName}}
+{{- end}}
 `))
 )
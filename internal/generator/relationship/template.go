@@ -75,6 +75,26 @@ func crudPrimaryKeyAnnotation(r *relationshipParam) string {
 	return fmt.Sprintf("[%s]", strings.Join(fields, ", "))
 }
 
+func crudForeignKeysAnnotation(r *relationshipParam) string {
+	foreignKeys := make([]string, 0, 2)
+	for _, msg := range []*descriptor.Message{r.DefinedOn, r.With} {
+		fields := make([]string, 0, len(msg.PrimaryKey()))
+		for _, field := range msg.PrimaryKey() {
+			fields = append(fields, `"`+protoFieldName(field)+`"`)
+		}
+		foreignKey := fmt.Sprintf(`fields: [%s] references: "%s"`, strings.Join(fields, ", "), msg.FQMN())
+		if r.GetOnDelete() != crudOptions.ReferentialAction_REFERENTIAL_ACTION_UNSPECIFIED {
+			foreignKey += " onDelete: " + r.GetOnDelete().String()
+		}
+		if r.GetOnUpdate() != crudOptions.ReferentialAction_REFERENTIAL_ACTION_UNSPECIFIED {
+			foreignKey += " onUpdate: " + r.GetOnUpdate().String()
+		}
+		foreignKeys = append(foreignKeys, "{"+foreignKey+"}")
+	}
+
+	return fmt.Sprintf("[%s]", strings.Join(foreignKeys, ", "))
+}
+
 func protoFieldName(f *descriptor.Field) string {
 	return strcase.ToLowerCamel(f.Message.GetName()) + "_" + f.GetName()
 }
@@ -97,6 +117,8 @@ func protoType(f *descriptor.Field) string {
 		return "int32"
 	case descriptorpb.FieldDescriptorProto_TYPE_FIXED32:
 		return "fixed32"
+	case descriptorpb.FieldDescriptorProto_TYPE_SFIXED32:
+		return "sfixed32"
 	case descriptorpb.FieldDescriptorProto_TYPE_SINT32:
 		return "sint32"
 
@@ -104,6 +126,8 @@ func protoType(f *descriptor.Field) string {
 		return "int64"
 	case descriptorpb.FieldDescriptorProto_TYPE_FIXED64:
 		return "fixed64"
+	case descriptorpb.FieldDescriptorProto_TYPE_SFIXED64:
+		return "sfixed64"
 	case descriptorpb.FieldDescriptorProto_TYPE_SINT64:
 		return "sint64"
 
@@ -168,6 +192,7 @@ import "{{.GetName}}";
 		"addOne":              addOne,
 		"crudImplementations": crudImplemenatationsAnnotation,
 		"crudPrimaryKey":      crudPrimaryKeyAnnotation,
+		"crudForeignKeys":     crudForeignKeysAnnotation,
 		"protoFieldName":      protoFieldName,
 		"protoType":           protoType,
 	}
@@ -177,6 +202,7 @@ message {{.GetName}} {
   option (protoc_gen_crud.options.crud_message_options) = {
     implementations: {{crudImplementations .}}
     primaryKey: {{crudPrimaryKey .}}
+    foreignKeys: {{crudForeignKeys .}}
   };
 {{- range $i, $field := .Fields }}
   {{protoType $field}} {{protoFieldName $field}} = {{addOne $i}};
@@ -119,6 +119,10 @@ func (g *generator) addMessagePathParamImports(file *descriptor.File, msg *descr
 		if f.Ignore {
 			continue
 		}
+		// deleted at is only ever written, never scanned
+		if f == msg.DeletedAt {
+			continue
+		}
 		t, err := g.reg.LookupMsg("", f.GetTypeName())
 		if err != nil {
 			continue
@@ -147,10 +151,22 @@ func (g *generator) addCrudPathParamImports(msg *descriptor.Message, pkgSeen map
 			pkgSeen["database/sql"] = true
 			imports = append(imports, descriptor.GoPackage{Path: "database/sql", Name: "sql"})
 		}
+		if !pkgSeen["encoding/json"] {
+			pkgSeen["encoding/json"] = true
+			imports = append(imports, descriptor.GoPackage{Path: "encoding/json", Name: "json"})
+		}
 		if !pkgSeen["fmt"] {
 			pkgSeen["fmt"] = true
 			imports = append(imports, descriptor.GoPackage{Path: "fmt", Name: "fmt"})
 		}
+		if !pkgSeen["iter"] {
+			pkgSeen["iter"] = true
+			imports = append(imports, descriptor.GoPackage{Path: "iter", Name: "iter"})
+		}
+		if !pkgSeen["slices"] {
+			pkgSeen["slices"] = true
+			imports = append(imports, descriptor.GoPackage{Path: "slices", Name: "slices"})
+		}
 		if !pkgSeen["strings"] {
 			pkgSeen["strings"] = true
 			imports = append(imports, descriptor.GoPackage{Path: "strings", Name: "strings"})
@@ -171,6 +187,18 @@ func (g *generator) addCrudPathParamImports(msg *descriptor.Message, pkgSeen map
 			pkgSeen["github.com/samlitowitz/expressions"] = true
 			imports = append(imports, descriptor.GoPackage{Path: "github.com/samlitowitz/expressions", Name: "expressions"})
 		}
+		if !pkgSeen["github.com/samlitowitz/protoc-gen-crud/expressions"] {
+			pkgSeen["github.com/samlitowitz/protoc-gen-crud/expressions"] = true
+			imports = append(imports, descriptor.GoPackage{Path: "github.com/samlitowitz/protoc-gen-crud/expressions", Name: "expressions", Alias: "crudexpressions"})
+		}
+		if !pkgSeen["github.com/samlitowitz/protoc-gen-crud/runtime"] {
+			pkgSeen["github.com/samlitowitz/protoc-gen-crud/runtime"] = true
+			imports = append(imports, descriptor.GoPackage{Path: "github.com/samlitowitz/protoc-gen-crud/runtime", Name: "runtime", Alias: "crudruntime"})
+		}
+		if !pkgSeen["github.com/samlitowitz/protoc-gen-crud/runtime/sqlite"] {
+			pkgSeen["github.com/samlitowitz/protoc-gen-crud/runtime/sqlite"] = true
+			imports = append(imports, descriptor.GoPackage{Path: "github.com/samlitowitz/protoc-gen-crud/runtime/sqlite", Name: "sqlite", Alias: "crudsqlite"})
+		}
 		if !pkgSeen["time"] {
 			pkgSeen["time"] = true
 			imports = append(imports, descriptor.GoPackage{Path: "time", Name: "time"})
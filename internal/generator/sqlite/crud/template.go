@@ -3,6 +3,8 @@ package crud
 import (
 	"bytes"
 	"fmt"
+	"path"
+	"strings"
 	"text/template"
 
 	"github.com/samlitowitz/protoc-gen-crud/internal/generator/crud"
@@ -25,10 +27,14 @@ func protoFieldAccessorFn(col *genSQLite.Column) string {
 	if col.AsTimestamp {
 		return fmt.Sprintf("Get%s().AsTime().Format(time.RFC3339)", casing.CamelIdentifier(col.GetName()))
 	}
+	accessor := fmt.Sprintf("Get%s()", casing.CamelIdentifier(col.GetName()))
 	if col.IsInlined {
-		return fmt.Sprintf("Get%s().Get%s()", casing.CamelIdentifier(col.Parent.GetName()), casing.CamelIdentifier(col.Field.GetName()))
+		accessor = fmt.Sprintf("Get%s().Get%s()", casing.CamelIdentifier(col.Parent.GetName()), casing.CamelIdentifier(col.Field.GetName()))
 	}
-	return fmt.Sprintf("Get%s()", casing.CamelIdentifier(col.GetName()))
+	if col.IsWrapper() {
+		return accessor + ".GetValue()"
+	}
+	return accessor
 }
 
 func protoFieldMutatorFn(col *genSQLite.Column, args string) string {
@@ -45,21 +51,113 @@ func protoFieldField(col *genSQLite.Column) string {
 	return casing.CamelIdentifier(col.GetName())
 }
 
+// protoFieldValueFn returns the value of the field of recv bound to its column, NULL when a field with presence is
+// unset
+func protoFieldValueFn(recv string, col *genSQLite.Column) string {
+	if !col.HasPresence() {
+		return recv + "." + protoFieldAccessorFn(col)
+	}
+	return fmt.Sprintf("crudruntime.Nullable(%s.%s, %s.%s)", recv, protoFieldPresenceFn(col), recv, protoFieldAccessorFn(col))
+}
+
+// protoFieldPresenceFn returns the method reporting whether the field of col, which tracks presence, is set
+func protoFieldPresenceFn(col *genSQLite.Column) string {
+	if col.IsInlined {
+		return fmt.Sprintf("Get%s().Has%s()", casing.CamelIdentifier(col.Parent.GetName()), casing.CamelIdentifier(col.Field.GetName()))
+	}
+	return fmt.Sprintf("Has%s()", casing.CamelIdentifier(col.GetName()))
+}
+
+// wrapFn returns the function wrapping a value in the wrapper type of the field of col, e.g. wrapperspb.String
+func wrapFn(col *genSQLite.Column) string {
+	msg := col.Message
+	if col.IsInlined {
+		msg = col.Parent.Message
+	}
+	return strings.TrimSuffix(col.FieldMessage.GoType(msg.File.GoPkg.Path), "Value")
+}
+
+// protoFieldScanDestFn returns where the column of a field is scanned into recv, a builder
+func protoFieldScanDestFn(recv string, col *genSQLite.Column) string {
+	if col.IsWrapper() {
+		return fmt.Sprintf("crudruntime.NullAsNil(&%s.%s, %s)", recv, protoFieldField(col), wrapFn(col))
+	}
+	if col.ScansNullAsZero() {
+		return fmt.Sprintf("crudruntime.NullAsZero(&%s.%s)", recv, protoFieldField(col))
+	}
+	return fmt.Sprintf("&%s.%s", recv, protoFieldField(col))
+}
+
+func withoutColumn(cols []*genSQLite.Column, without *genSQLite.Column) []*genSQLite.Column {
+	filtered := make([]*genSQLite.Column, 0, len(cols))
+	for _, col := range cols {
+		if !col.IsInlined && col.Field.FQFN() == without.Field.FQFN() {
+			continue
+		}
+		filtered = append(filtered, col)
+	}
+	return filtered
+}
+
+func uuidVersionFn(col *genSQLite.Column) string {
+	if col.AutoGenerate == crudOptions.AutoGenerationStrategy_AUTO_GENERATION_STRATEGY_UUID_V7 {
+		return "crudruntime.UUIDv7"
+	}
+	return "crudruntime.UUIDv4"
+}
+
+// binaryOperator is the SQL operator rendered for a binary expression type
+type binaryOperator struct {
+	Type     string
+	Operator string
+}
+
+var binaryOperators = []binaryOperator{
+	{Type: "*crudexpressions.NotEquals", Operator: "<>"},
+	{Type: "*crudexpressions.LessThan", Operator: "<"},
+	{Type: "*crudexpressions.LessThanOrEqual", Operator: "<="},
+	{Type: "*crudexpressions.GreaterThan", Operator: ">"},
+	{Type: "*crudexpressions.GreaterThanOrEqual", Operator: ">="},
+	{Type: "*crudexpressions.Like", Operator: "LIKE"},
+}
+
 type param struct {
 	*descriptor.File
 	Imports []descriptor.GoPackage
 }
 
+// unitOfWork is the set of repositories generated for a file which can share a transaction
+type unitOfWork struct {
+	*descriptor.File
+
+	// Name is derived from the base name of the file
+	Name     string
+	Messages []*descriptor.Message
+}
+
 type message struct {
 	*descriptor.Message
 
 	FieldMaskCol *genSQLite.Column
 	CreatedAtCol *genSQLite.Column
 	UpdatedAtCol *genSQLite.Column
+	DeletedAtCol *genSQLite.Column
+	VersionCol   *genSQLite.Column
+
+	// SequentialKeyCol is the primary key column assigned by the database, if any
+	SequentialKeyCol *genSQLite.Column
+	// NonSequentialCols are the queryable columns excluding the sequential key column
+	NonSequentialCols []*genSQLite.Column
+	// UUIDKeyCols are the primary key columns generated as UUIDs
+	UUIDKeyCols []*genSQLite.Column
 
 	QueryableCols         []*genSQLite.Column
 	PrimaryKeyCols        []*genSQLite.Column
 	NonPrimeAttributeCols []*genSQLite.Column
+	// ConflictUpdateCols are the columns an upsert updates when the row exists, the version column is incremented instead
+	ConflictUpdateCols []*genSQLite.Column
+	// UpdateCols are the columns an update sets, the version column is incremented instead
+	UpdateCols []*genSQLite.Column
 }
 
 func applyTemplate(p param, reg *descriptor.Registry) (string, error) {
@@ -68,6 +166,10 @@ func applyTemplate(p param, reg *descriptor.Registry) (string, error) {
 		return "", fmt.Errorf("header: %v", err)
 	}
 
+	uow := &unitOfWork{
+		File: p.File,
+		Name: strcase.ToCamel(strings.TrimSuffix(path.Base(p.GetName()), path.Ext(p.GetName()))),
+	}
 	for _, msg := range p.Messages {
 		if !msg.GenerateCRUD {
 			continue
@@ -75,6 +177,7 @@ func applyTemplate(p param, reg *descriptor.Registry) (string, error) {
 		if _, ok := msg.Implementations[crudOptions.Implementation_IMPLEMENTATION_SQLITE]; !ok {
 			continue
 		}
+		uow.Messages = append(uow.Messages, msg)
 
 		injected := &message{
 			Message:               msg,
@@ -91,10 +194,41 @@ func applyTemplate(p param, reg *descriptor.Registry) (string, error) {
 		if msg.UpdatedAt != nil {
 			injected.UpdatedAtCol = &genSQLite.Column{QueryableField: crud.QueryableFieldsFromFields([]*descriptor.Field{msg.UpdatedAt})[0]}
 		}
+		if msg.DeletedAt != nil {
+			injected.DeletedAtCol = &genSQLite.Column{QueryableField: crud.QueryableFieldsFromFields([]*descriptor.Field{msg.DeletedAt})[0]}
+			// deleted at is managed by Delete and Restore only
+			injected.QueryableCols = withoutColumn(injected.QueryableCols, injected.DeletedAtCol)
+			injected.NonPrimeAttributeCols = withoutColumn(injected.NonPrimeAttributeCols, injected.DeletedAtCol)
+		}
+		injected.ConflictUpdateCols = injected.NonPrimeAttributeCols
+		injected.UpdateCols = injected.NonPrimeAttributeCols
+		if msg.Version != nil {
+			injected.VersionCol = &genSQLite.Column{QueryableField: crud.QueryableFieldsFromFields([]*descriptor.Field{msg.Version})[0]}
+			injected.UpdateCols = withoutColumn(injected.UpdateCols, injected.VersionCol)
+			injected.ConflictUpdateCols = withoutColumn(injected.ConflictUpdateCols, injected.VersionCol)
+		}
+		if injected.CreatedAtCol != nil {
+			injected.ConflictUpdateCols = withoutColumn(injected.ConflictUpdateCols, injected.CreatedAtCol)
+		}
+		injected.NonSequentialCols = injected.QueryableCols
+		for _, col := range injected.PrimaryKeyCols {
+			if col.IsSequential() {
+				injected.SequentialKeyCol = col
+				injected.NonSequentialCols = withoutColumn(injected.QueryableCols, col)
+			}
+			if col.GeneratesUUID() {
+				injected.UUIDKeyCols = append(injected.UUIDKeyCols, col)
+			}
+		}
 		if err := repositoryTemplate.Execute(w, injected); err != nil {
 			return "", fmt.Errorf(" message %s: repository: %v", msg.GetName(), err)
 		}
 	}
+	if len(uow.Messages) > 0 {
+		if err := unitOfWorkTemplate.Execute(w, uow); err != nil {
+			return "", fmt.Errorf("unit of work: %v", err)
+		}
+	}
 
 	return w.String(), nil
 }
@@ -118,6 +252,40 @@ import (
 	{{range $i := .Imports}}{{if not $i.Standard}}{{$i | printf "%s\n"}}{{end}}{{end}}
 )
 {{end}}
+`))
+
+	unitOfWorkTemplate = template.Must(template.New("unit-of-work").Parse(`
+// SQLite{{.Name}}Repositories are the SQLite repositories of the messages defined in {{.GetName}}
+type SQLite{{.Name}}Repositories struct {
+{{- range $msg := .Messages}}
+	{{$msg.GetName}} *SQLite{{$msg.GetName}}Repository
+{{- end}}
+}
+
+// SQLite{{.Name}}UnitOfWork runs fn with repositories sharing a single transaction begun on db.
+// The transaction is committed when fn succeeds and rolled back otherwise.
+// When db is itself a transaction a savepoint within it is used instead.
+// The repositories are configured by opts as their constructors configure them.
+func SQLite{{.Name}}UnitOfWork(ctx context.Context, db crudruntime.Querier, txOpts *sql.TxOptions, fn func(*SQLite{{.Name}}Repositories) error, opts ...crudruntime.Option) (err error) {
+	defer func() { err = crudsqlite.WrapError(err) }()
+	tx, err := crudsqlite.BeginTx(ctx, db, txOpts)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	repos := &SQLite{{.Name}}Repositories{}
+	{{- range $msg := .Messages}}
+	repos.{{$msg.GetName}}, err = NewSQLite{{$msg.GetName}}Repository(tx, opts...)
+	if err != nil {
+		return err
+	}
+	{{- end}}
+	if err := fn(repos); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
 `))
 
 	repositoryTemplate = template.Must(template.New("repository").Parse(`
@@ -127,28 +295,51 @@ import (
 
 	{{template "repository-read" .}}
 
+	{{template "repository-aggregate" .}}
+
 	{{template "repository-update" .}}
 
+	{{template "repository-upsert" .}}
+
 	{{template "repository-delete" .}}
 
 	{{template "repository-misc" .}}
 	`))
 
-	_ = template.Must(repositoryTemplate.New("repository-struct").Parse(`
+	_ = template.Must(repositoryTemplate.New("repository-struct").Funcs(funcMap).Parse(`
 // InMemory{{.GetName}}Repository is an in memory implementation of the {{.GetName}}Repository interface.
 type SQLite{{.GetName}}Repository struct {
-	db *sql.DB
+	db      crudruntime.Querier
+	options crudruntime.Options
 }
 
 // NewInMemory creates a new InMemory{{.GetName}}Repository to be used.
-func NewSQLite{{.GetName}}Repository(db *sql.DB) (*SQLite{{.GetName}}Repository, error) {
-	_, ok := db.Driver().(*sqlite.Driver)
-	if !ok {
-		return nil, fmt.Errorf("invalid driver, must be of type *modernc.org/sqlite.Driver")
+// The db is either a *sql.DB or a *sql.Tx, in which case every operation takes part in that transaction.
+// Options such as crudruntime.WithIsolationLevel, crudruntime.WithRetryPolicy, crudruntime.WithClock and
+// crudruntime.WithIDGenerator configure the repository.
+func NewSQLite{{.GetName}}Repository(db crudruntime.Querier, opts ...crudruntime.Option) (*SQLite{{.GetName}}Repository, error) {
+	if sqlDB, ok := db.(*sql.DB); ok {
+		_, ok := sqlDB.Driver().(*sqlite.Driver)
+		if !ok {
+			return nil, fmt.Errorf("invalid driver, must be of type *modernc.org/sqlite.Driver")
+		}
 	}
-	return &SQLite{{.GetName}}Repository{
+	repo := &SQLite{{.GetName}}Repository{
 		db: db,
-	}, nil
+		options: crudruntime.Options{
+			IsolationLevel: {{isolationLevel .IsolationLevel}},
+		},
+	}
+	repo.options.Apply(opts...)
+	return repo, nil
+}
+
+// WithTx returns a copy of the repository whose operations take part in tx.
+// The caller remains responsible for committing or rolling back tx.
+func (repo *SQLite{{.GetName}}Repository) WithTx(tx *sql.Tx) *SQLite{{.GetName}}Repository {
+	withTx := *repo
+	withTx.db = tx
+	return &withTx
 }
 `))
 
@@ -158,32 +349,115 @@ func NewSQLite{{.GetName}}Repository(db *sql.DB) (*SQLite{{.GetName}}Repository,
 
 		"fieldIDConstantName":  crud.FieldIDConstantName,
 		"fieldIDConstantValue": crud.FieldIDConstantValue,
+		"isolationLevel":       crud.IsolationLevelConstant,
 		"protoFieldAccessor":   protoFieldAccessorFn,
 		"protoFieldMutatorFn":  protoFieldMutatorFn,
 		"protoFieldField":      protoFieldField,
+		"protoFieldValue":      protoFieldValueFn,
+		"protoFieldPresence":   protoFieldPresenceFn,
+		"protoFieldScanDest":   protoFieldScanDestFn,
+		"binaryOperators":      func() []binaryOperator { return binaryOperators },
+		"uuidVersion":          uuidVersionFn,
 		"sqlQuotedIdent":       genSQLite.QuotedIdent,
 		"sqlIdent":             genSQLite.Ident,
+		"keyFieldBind":         crud.KeyFieldBind,
 	}
 
 	_ = template.Must(repositoryTemplate.New("repository-create").Funcs(funcMap).Parse(`
 // Create creates new {{.GetName}}s.
-// Successfully created {{.GetName}}s are returned along with any errors that may have occurred.
-func (repo *SQLite{{.GetName}}Repository) Create(ctx context.Context, toCreate []*{{.GoType .File.GoPkg.Path}}) ([]*{{.GoType .File.GoPkg.Path}}, error) {
+// Successfully created {{.GetName}}s are returned along with any errors that may have occurred, the columns written
+// are read back using RETURNING so values computed by the database are reflected in them.
+// With crudruntime.BatchPartialSuccess the {{.GetName}}s returned are aligned with toCreate, those which failed are nil.
+func (repo *SQLite{{.GetName}}Repository) Create(ctx context.Context, toCreate []*{{.GoType .File.GoPkg.Path}}) (created []*{{.GoType .File.GoPkg.Path}}, err error) {
+	{{- if .SequentialKeyCol}}
+	var generateKeys []*{{.GoType .File.GoPkg.Path}}
+	for _, {{toLowerCamel .GetName}} := range toCreate {
+		if {{toLowerCamel .GetName}}.{{protoFieldAccessor .SequentialKeyCol}} == 0 {
+			generateKeys = append(generateKeys, {{toLowerCamel .GetName}})
+		}
+	}
+	{{- end}}
+	err = repo.options.RetryPolicy.Retry(ctx, repo.db, func() error {
+		{{- if .SequentialKeyCol}}
+		// keys generated by a failed attempt were rolled back with it
+		for _, {{toLowerCamel .GetName}} := range generateKeys {
+			{{toLowerCamel .GetName}}.{{protoFieldMutatorFn .SequentialKeyCol "0"}}
+		}
+		{{- end}}
+		if repo.options.BatchMode == crudruntime.BatchPartialSuccess {
+			created, err = repo.createEach(ctx, toCreate)
+			return err
+		}
+		created, err = repo.create(ctx, toCreate)
+		return err
+	})
+	return created, err
+}
+
+// createEach creates each {{.GetName}} within its own savepoint of a single transaction, a failing {{.GetName}} is
+// rolled back alone and its error is returned, by index, in a *crudruntime.BatchError.
+func (repo *SQLite{{.GetName}}Repository) createEach(ctx context.Context, toCreate []*{{.GoType .File.GoPkg.Path}}) (_ []*{{.GoType .File.GoPkg.Path}}, err error) {
+	defer func() { err = crudsqlite.WrapError(err) }()
+	if len(toCreate) == 0 {
+		return nil, nil
+	}
+	tx, err := crudsqlite.BeginTx(ctx, repo.db, &sql.TxOptions{Isolation: repo.options.IsolationLevel})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	// create begins a savepoint within tx
+	inTx := *repo
+	inTx.db = tx
+	created := make([]*{{.GoType .File.GoPkg.Path}}, len(toCreate))
+	batchErr := &crudruntime.BatchError{}
+	for i, {{toLowerCamel .GetName}} := range toCreate {
+		if _, err := inTx.create(ctx, []*{{.GoType .File.GoPkg.Path}}{ {{- toLowerCamel .GetName -}} }); err != nil {
+			batchErr.Add(i, err)
+			continue
+		}
+		created[i] = {{toLowerCamel .GetName}}
+	}
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+	return created, batchErr.ErrorOrNil()
+}
+
+// create creates new {{.GetName}}s in a single transaction.
+func (repo *SQLite{{.GetName}}Repository) create(ctx context.Context, toCreate []*{{.GoType .File.GoPkg.Path}}) (_ []*{{.GoType .File.GoPkg.Path}}, err error) {
+	defer func() { err = crudsqlite.WrapError(err) }()
 	if len(toCreate) == 0 {
 		return nil, nil
 	}
-	tx, err := repo.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	tx, err := crudsqlite.BeginTx(ctx, repo.db, &sql.TxOptions{Isolation: repo.options.IsolationLevel})
 	if err != nil {
 		return nil, err
 	}
 	defer tx.Rollback()
 
 	{{ if .HasCreatedAt -}}
+	{{template "repository-now" .}}
 	for _, {{toLowerCamel .GetName}} := range toCreate {
+		{{- if not .UsesDatabaseClock}}
 		if {{toLowerCamel .GetName}}.Get{{protoFieldField $.CreatedAtCol}}() != nil {
 			continue
 		}
-		{{toLowerCamel .GetName}}.{{protoFieldMutatorFn $.CreatedAtCol "timestamppb.New(time.Now())"}}
+		{{- end}}
+		{{toLowerCamel .GetName}}.{{protoFieldMutatorFn $.CreatedAtCol "timestamppb.New(now)"}}
+	}
+	{{- end -}}
+
+	{{- range $col := .UUIDKeyCols}}
+	for _, {{toLowerCamel $.GetName}} := range toCreate {
+		if {{toLowerCamel $.GetName}}.{{protoFieldAccessor $col}} != "" {
+			continue
+		}
+		generated, err := repo.options.NewUUID({{uuidVersion $col}})
+		if err != nil {
+			return nil, err
+		}
+		{{toLowerCamel $.GetName}}.{{protoFieldMutatorFn $col "generated"}}
 	}
 	{{- end -}}
 
@@ -205,9 +479,14 @@ func (repo *SQLite{{.GetName}}Repository) Create(ctx context.Context, toCreate [
 	_ = template.Must(repositoryTemplate.New("repository-create-no-field-mask").Funcs(funcMap).Parse(`
 	binds := []any{}
 	bindsStrs := []string{}
+	var batched []*{{.GoType .File.GoPkg.Path}}
 	for _, {{toLowerCamel .GetName}} := range toCreate {
+		{{- if .SequentialKeyCol -}}
+		{{template "repository-create-sequential-key" .}}
+		{{- end}}
+		batched = append(batched, {{toLowerCamel $.GetName}})
 		{{- range $col := .QueryableCols}}
-		binds = append(binds, {{toLowerCamel $.GetName}}.{{protoFieldAccessor $col}})
+		binds = append(binds, {{protoFieldValue (toLowerCamel $.GetName) $col}})
 		{{- end}}
 		bindsStrs = append(bindsStrs, "(
 			{{- range $i, $col := .QueryableCols -}}
@@ -215,31 +494,71 @@ func (repo *SQLite{{.GetName}}Repository) Create(ctx context.Context, toCreate [
 			{{- end -}}
 		)")
 	}
-	_, err = tx.ExecContext(
-		ctx,
-		fmt.Sprintf(
-			` + "`" + `INSERT INTO {{sqlQuotedIdent .GetName}} (
-			{{- range $i, $col := .QueryableCols -}}
-				{{- if $i}},{{end}}{{sqlQuotedIdent $col.GetName}}
-			{{- end -}}
-			) VALUES
-			%s` + "`" + `,
-			strings.Join(bindsStrs, ",\n"),
-		),
-		binds...
-	)
-	if err != nil {
-		return nil, err
+	if len(bindsStrs) > 0 {
+		rows, err := tx.QueryContext(
+			ctx,
+			fmt.Sprintf(
+				` + "`" + `INSERT INTO {{sqlQuotedIdent .GetName}} (
+				{{- range $i, $col := .QueryableCols -}}
+					{{- if $i}},{{end}}{{sqlQuotedIdent $col.GetName}}
+				{{- end -}}
+				) VALUES
+				%s{{template "repository-returning" .}}` + "`" + `,
+				strings.Join(bindsStrs, ",\n"),
+			),
+			binds...
+		)
+		if err != nil {
+			return nil, err
+		}
+		if _, err = sqlite{{.GetName}}Returned(rows, nil, batched); err != nil {
+			return nil, err
+		}
 	}
 `))
 
+	_ = template.Must(repositoryTemplate.New("repository-create-sequential-key").Funcs(funcMap).Parse(`
+		if {{toLowerCamel .GetName}}.{{protoFieldAccessor .SequentialKeyCol}} == 0 {
+			rows, err := tx.QueryContext(
+				ctx,
+				` + "`" + `INSERT INTO {{sqlQuotedIdent .GetName}}
+				{{- if .NonSequentialCols}} (
+				{{- range $i, $col := .NonSequentialCols -}}
+				{{if $i}},{{end}}{{sqlQuotedIdent $col.GetName}}
+				{{- end -}}
+				) VALUES (
+			{{- range $i, $col := .NonSequentialCols -}}
+			{{if $i}}, {{end}}?
+			{{- end -}}
+				)
+				{{- else}} DEFAULT VALUES
+				{{- end}}{{template "repository-returning" .}}` + "`" + `,
+				{{- range $col := .NonSequentialCols}}
+				{{protoFieldValue (toLowerCamel $.GetName) $col}},
+				{{- end}}
+			)
+			if err != nil {
+				return nil, err
+			}
+			if _, err = sqlite{{.GetName}}Returned(rows, nil, []*{{.GoType .File.GoPkg.Path}}{ {{- toLowerCamel .GetName -}} }); err != nil {
+				return nil, err
+			}
+			continue
+		}
+`))
+
 	_ = template.Must(repositoryTemplate.New("repository-create-field-mask").Funcs(funcMap).Parse(`
 	noMaskBinds := []any{}
 	noMaskBindsStrs := []string{}
+	var noMask []*{{.GoType .File.GoPkg.Path}}
 	for _, {{toLowerCamel $.GetName}} := range toCreate {
 		if {{toLowerCamel $.GetName}}.{{protoFieldAccessor $.FieldMaskCol}} == nil {
+			{{- if .SequentialKeyCol -}}
+			{{template "repository-create-sequential-key" .}}
+			{{- end}}
+			noMask = append(noMask, {{toLowerCamel $.GetName}})
 			{{- range $col := .QueryableCols}}
-			noMaskBinds = append(noMaskBinds, {{toLowerCamel $.GetName}}.{{protoFieldAccessor $col}})
+			noMaskBinds = append(noMaskBinds, {{protoFieldValue (toLowerCamel $.GetName) $col}})
 			{{- end}}
 			noMaskBindsStrs = append(noMaskBindsStrs, "(
 			{{- range $i, $col := .QueryableCols -}}
@@ -252,6 +571,10 @@ func (repo *SQLite{{.GetName}}Repository) Create(ctx context.Context, toCreate [
 		if err != nil {
 			return nil, err
 		}
+		{{- if and .UsesDatabaseClock .HasCreatedAt}}
+		// the created at time of the database clock is written whether masked or not
+		valuesByColName["{{sqlIdent .CreatedAtCol.GetName}}"] = {{toLowerCamel $.GetName}}.{{protoFieldAccessor .CreatedAtCol}}
+		{{- end}}
 		if len(valuesByColName) == 0 {
 			continue
 		}
@@ -267,23 +590,30 @@ func (repo *SQLite{{.GetName}}Repository) Create(ctx context.Context, toCreate [
 			strings.Join(cols, ", "),
 			strings.Join(params, ", "),
 		)
-		_, err = tx.ExecContext(ctx, query, binds...)
+		fields := sqlite{{.GetName}}WrittenFields(valuesByColName)
+		rows, err := tx.QueryContext(ctx, query + sqlite{{.GetName}}Returning(fields), binds...)
 		if err != nil {
 			return nil, err
 		}
+		if _, err = sqlite{{.GetName}}Returned(rows, fields, []*{{.GoType .File.GoPkg.Path}}{ {{- toLowerCamel .GetName -}} }); err != nil {
+			return nil, err
+		}
 	}
 	if len(noMaskBinds) > 0 {
 		query := fmt.Sprintf(` + "`" + `INSERT INTO {{sqlQuotedIdent .GetName}} (
 			{{- range $i, $col := .QueryableCols -}}
 			{{if $i}},{{end}}{{sqlQuotedIdent $col.GetName}}
 			{{- end -}}
-			) VALUES %s` + "`" + `,
+			) VALUES %s{{template "repository-returning" .}}` + "`" + `,
 			strings.Join(noMaskBindsStrs, ",\n"),
 		)
-		_, err = tx.ExecContext(ctx, query, noMaskBinds...)
+		rows, err := tx.QueryContext(ctx, query, noMaskBinds...)
 		if err != nil {
 			return nil, err
 		}
+		if _, err = sqlite{{.GetName}}Returned(rows, nil, noMask); err != nil {
+			return nil, err
+		}
 	}
 `))
 
@@ -291,205 +621,1438 @@ func (repo *SQLite{{.GetName}}Repository) Create(ctx context.Context, toCreate [
 // Read returns a set of {{.GetName}}s matching the provided criteria
 // Read is incomplete and it should be considered unstable
 func (repo *SQLite{{.GetName}}Repository) Read(ctx context.Context, expr expressions.Expression) ([]*{{.GoType .File.GoPkg.Path}}, error) {
-	query := ` + "`" + `SELECT {{ range $i, $col := .QueryableCols -}}
-		{{if $i}},{{end}}{{sqlQuotedIdent $col.GetName}}
-		{{- end}}
-		FROM {{sqlQuotedIdent .GetName -}}
-` + "`" + `
-	clauses, binds, err := whereClauseFromExpressionForSQLite{{.GetName}}(expr)
+	found, _, err := repo.ReadWithOptions(ctx, expr, nil)
+	return found, err
+}
+
+// Get returns the {{.GetName}} identified by key, crudruntime.ErrNotFound is returned when it does not exist
+func (repo *SQLite{{.GetName}}Repository) Get(ctx context.Context, key {{.GetName}}Key) (*{{.GoType .File.GoPkg.Path}}, error) {
+	found, err := repo.GetMany(ctx, []{{.GetName}}Key{key})
 	if err != nil {
 		return nil, err
 	}
-	if clauses != "" {
-		query += "\nWHERE\n" + clauses
+	return found[0], nil
+}
+
+// GetMany returns the {{.GetName}}s identified by keys, in the order of keys, using a single query.
+// When any does not exist its position is nil and crudruntime.ErrNotFound is returned along with the others.
+func (repo *SQLite{{.GetName}}Repository) GetMany(ctx context.Context, keys []{{.GetName}}Key) (_ []*{{.GoType .File.GoPkg.Path}}, err error) {
+	defer func() { err = crudsqlite.WrapError(err) }()
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	tuples := make([]string, 0, len(keys))
+	binds := make([]any, 0, len(keys) * {{len .PrimaryKeyCols}})
+	for _, key := range keys {
+		tuples = append(
+			tuples,
+			"({{range $i, $col := .PrimaryKeyCols}}{{if $i}}, {{end}}?{{end}})",
+		)
+		binds = append(
+			binds,
+			{{- range $i, $col := .PrimaryKeyCols}}
+			{{keyFieldBind $col.Field (print "key." (camelIdentifier $col.GetName))}},
+			{{- end}}
+		)
 	}
-	stmt, err := repo.db.Prepare(query)
+	query := ` + "`" + `SELECT {{ range $i, $col := .QueryableCols -}}
+		{{if $i}},{{end}}{{sqlQuotedIdent $col.GetName}}
+		{{- end}}
+		FROM {{sqlQuotedIdent .GetName}}
+WHERE ({{range $i, $col := .PrimaryKeyCols}}{{if $i}}, {{end}}{{sqlQuotedIdent $.GetName}}.{{sqlQuotedIdent $col.GetName}}{{end}}) IN (` + "`" + ` + strings.Join(tuples, ", ") + ")"
+	{{- if .HasDeletedAt}}
+	query += ` + "`" + ` AND {{sqlQuotedIdent .GetName}}.{{sqlQuotedIdent .DeletedAtCol.GetName}} IS NULL` + "`" + `
+	{{- end}}
+	stmt, err := repo.db.PrepareContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
+	defer stmt.Close()
 	rows, err := stmt.QueryContext(ctx, binds...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var found []*{{.GoType .File.GoPkg.Path}}
+	byKey := make(map[{{.GetName}}Key]*{{.GoType .File.GoPkg.Path}}, len(keys))
 	for rows.Next() {
-		{{toLowerCamel .GetName}} := &{{.GoType .File.GoPkg.Path}}_builder{
-			{{range $i, $field := .NonPrimeAttributes -}}
-			{{if $field.HasRelationship}}{{camelIdentifier $field.GetName}}: &{{$field.FieldMessage.GoType $.File.GoPkg.Path}}{},{{end}}
-			{{if $field.Inline}}{{camelIdentifier $field.GetName}}: &{{$field.FieldMessage.GoType $.File.GoPkg.Path}}{},{{end}}
-			{{- end}}
-		}
-		{{range $i, $field := .NonPrimeAttributes -}}
-		{{if $field.AsTimestamp}}var {{toLowerCamel $field.GetName}}TimeStr string
-		{{end}}
-		{{- end}}
-		if err = rows.Scan(
-		{{- range $i, $col := .QueryableCols -}}
-		{{if $i}},{{end}}
-		{{- if not $col.Field.AsTimestamp}} &{{toLowerCamel $.GetName}}.{{protoFieldField $col}} {{end -}}
-		{{- if $col.Field.AsTimestamp}} &{{toLowerCamel $col.Field.GetName}}TimeStr {{end -}}
-		{{- end -}}
-		); err != nil {
-			return nil, err
-		}
-		{{ range $i, $col := .QueryableCols -}}
-		{{ if $col.Field.AsTimestamp}}
-		{{toLowerCamel $col.Field.GetName}}Time, err := time.Parse(time.RFC3339, {{toLowerCamel $col.Field.GetName}}TimeStr)
+		{{toLowerCamel .GetName}}, err := sqliteScan{{.GetName}}(rows)
 		if err != nil {
 			return nil, err
 		}
-		{{toLowerCamel $.GetName}}.{{protoFieldField $col}} = timestamppb.New({{toLowerCamel $col.Field.GetName}}Time)
-		{{end }}
-		{{- end }}
-		found = append(found, {{toLowerCamel .GetName}}.Build())
+		byKey[{{.GetName}}KeyOf({{toLowerCamel .GetName}})] = {{toLowerCamel .GetName}}
 	}
 	if err = rows.Err(); err != nil {
 		return nil, err
 	}
+	found := make([]*{{.GoType .File.GoPkg.Path}}, len(keys))
+	missing := 0
+	for i, key := range keys {
+		{{toLowerCamel .GetName}}, ok := byKey[key]
+		if !ok {
+			missing++
+			continue
+		}
+		found[i] = {{toLowerCamel .GetName}}
+	}
+	if missing > 0 {
+		return found, fmt.Errorf("%w: %d of %d {{.GetName}}s", crudruntime.ErrNotFound, missing, len(keys))
+	}
 	return found, nil
 }
-`))
 
-	_ = template.Must(repositoryTemplate.New("repository-update").Funcs(funcMap).Parse(`
-// Update modifies existing {{.GetName}}s based on the defined unique identifiers.
-func (repo *SQLite{{.GetName}}Repository) Update(ctx context.Context, toUpdate []*{{.GoType .File.GoPkg.Path}}) ([]*{{.GoType .File.GoPkg.Path}}, error) {
-	{{- if eq (len .NonPrimeAttributes) 0 -}}
-	return nil, nil
-	{{- else -}}
-	if len(toUpdate) == 0 {
-		return nil, nil
+// ReadIter lazily yields the {{.GetName}}s matching the provided criteria.
+// Rows are scanned as they are consumed and released when iteration stops.
+func (repo *SQLite{{.GetName}}Repository) ReadIter(ctx context.Context, expr expressions.Expression) iter.Seq2[*{{.GoType .File.GoPkg.Path}}, error] {
+	return func(yield func(*{{.GoType .File.GoPkg.Path}}, error) bool) {
+		query, binds, _, _, err := repo.selectQuery(expr, nil)
+		if err != nil {
+			yield(nil, crudsqlite.WrapError(err))
+			return
+		}
+		stmt, err := repo.db.PrepareContext(ctx, query)
+		if err != nil {
+			yield(nil, crudsqlite.WrapError(err))
+			return
+		}
+		defer stmt.Close()
+		rows, err := stmt.QueryContext(ctx, binds...)
+		if err != nil {
+			yield(nil, crudsqlite.WrapError(err))
+			return
+		}
+		defer rows.Close()
+		for rows.Next() {
+			{{toLowerCamel .GetName}}, err := sqliteScan{{.GetName}}(rows)
+			if err != nil {
+				yield(nil, crudsqlite.WrapError(err))
+				return
+			}
+			if !yield({{toLowerCamel .GetName}}, nil) {
+				return
+			}
+		}
+		if err := rows.Err(); err != nil {
+			yield(nil, crudsqlite.WrapError(err))
+		}
 	}
-	tx, err := repo.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
+}
+
+// ReadWithOptions returns an ordered page of {{.GetName}}s matching the provided criteria.
+// The next page token is returned when more {{.GetName}}s remain, otherwise it is empty.
+func (repo *SQLite{{.GetName}}Repository) ReadWithOptions(ctx context.Context, expr expressions.Expression, opts *{{.GetName}}ReadOptions) (_ []*{{.GoType .File.GoPkg.Path}}, _ string, err error) {
+	defer func() { err = crudsqlite.WrapError(err) }()
+	if opts == nil {
+		opts = &{{.GetName}}ReadOptions{}
+	}
+	query, binds, orderBy, fields, err := repo.selectQuery(expr, opts)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
-	defer tx.Rollback()
-
-	stmt, err := tx.Prepare(
-		` + "`" + `UPDATE {{sqlQuotedIdent .GetName}} SET {{range $i, $col := .NonPrimeAttributeCols -}}
-		{{if $i}},{{end}}{{sqlQuotedIdent $col.GetName}} = ?
-		{{- end }} WHERE {{ range $i, $cols := .PrimaryKeyCols -}}
-		{{if $i}} AND {{end}}{{sqlQuotedIdent $cols.GetName}} = ?
-		{{- end }}` + "`" + `,
-	)
+	stmt, err := repo.db.PrepareContext(ctx, query)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 	defer stmt.Close()
-
-	{{ if .HasUpdatedAt -}}
-	for _, {{toLowerCamel .GetName}} := range toUpdate {
-		if {{toLowerCamel .GetName}}.Get{{protoFieldField $.UpdatedAtCol}}() != nil {
-			continue
+	rows, err := stmt.QueryContext(ctx, binds...)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+	var found []*{{.GoType .File.GoPkg.Path}}
+	for rows.Next() {
+		var {{toLowerCamel .GetName}} *{{.GoType .File.GoPkg.Path}}
+		if fields == nil {
+			{{toLowerCamel .GetName}}, err = sqliteScan{{.GetName}}(rows)
+		} else {
+			{{toLowerCamel .GetName}}, err = sqliteScan{{.GetName}}Fields(rows, fields)
+		}
+		if err != nil {
+			return nil, "", err
 		}
-		{{toLowerCamel .GetName}}.{{protoFieldMutatorFn $.UpdatedAtCol "timestamppb.New(time.Now())"}}
+		found = append(found, {{toLowerCamel .GetName}})
 	}
-	{{- end -}}
-
-	{{- if .HasFieldMask -}}
-	{{ template "repository-update-field-mask" .}}
-	{{- else -}}
-	{{ template "repository-update-no-field-mask" .}}
-	{{- end -}}
-
-	err = tx.Commit()
+	if err = rows.Err(); err != nil {
+		return nil, "", err
+	}
+	if opts.Limit == 0 || len(found) <= opts.Limit {
+		return found, "", nil
+	}
+	found = found[:opts.Limit]
+	values, err := sqlite{{.GetName}}KeysetValues(orderBy, found[len(found) - 1])
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
-	return toUpdate, nil
-	{{- end -}}
+	nextPageToken, err := encode{{.GetName}}PageToken(&{{toLowerCamel .GetName}}PageToken{OrderBy: orderBy, Values: values})
+	if err != nil {
+		return nil, "", err
+	}
+	return found, nextPageToken, nil
 }
-`))
 
-	_ = template.Must(repositoryTemplate.New("repository-update-no-field-mask").Funcs(funcMap).Parse(`
-	for _, {{toLowerCamel .GetName}} := range toUpdate {
-		_, err = stmt.ExecContext(ctx, {{ range $i, $col := .NonPrimeAttributeCols -}}
-		{{if $i}},{{end}}{{toLowerCamel $.GetName}}.{{protoFieldAccessor $col}}
-		{{- end }},{{ range $i, $col := .PrimaryKeyCols -}}
-		{{if $i}},{{end}}{{toLowerCamel $.GetName}}.{{protoFieldAccessor $col}}
-		{{- end }})
-		if err != nil {
-			return nil, err
+// selectQuery builds the query reading the {{.GetName}}s matching the provided criteria ordered and paginated by opts,
+// the ordering including primary key tie breakers is returned for encoding page tokens along with the fields selected,
+// which are nil when all are
+func (repo *SQLite{{.GetName}}Repository) selectQuery(expr expressions.Expression, opts *{{.GetName}}ReadOptions) (string, []any, []{{.GetName}}OrderBy, []expressions.ID, error) {
+	if opts == nil {
+		opts = &{{.GetName}}ReadOptions{}
+	}
+	if opts.Limit < 0 {
+		return "", nil, nil, nil, fmt.Errorf("invalid limit: %d", opts.Limit)
+	}
+	if opts.Offset < 0 {
+		return "", nil, nil, nil, fmt.Errorf("invalid offset: %d", opts.Offset)
+	}
+	if opts.Offset > 0 && opts.PageToken != "" {
+		return "", nil, nil, nil, fmt.Errorf("offset and page token are mutually exclusive")
+	}
+	orderBy, err := {{toLowerCamel .GetName}}OrderByWithPrimaryKey(opts.OrderBy)
+	if err != nil {
+		return "", nil, nil, nil, err
+	}
+	fields, err := {{toLowerCamel .GetName}}FieldsWithOrderBy(opts.Fields, orderBy)
+	if err != nil {
+		return "", nil, nil, nil, err
+	}
+	if opts.Limit > 0 || opts.PageToken != "" {
+		for _, by := range orderBy {
+			// page tokens cannot tell NULL apart from the zero value it is read as
+			if presence, ok := sqlite{{.GetName}}NullableFieldIDs[by.Field]; ok && !presence {
+				return "", nil, nil, nil, fmt.Errorf("unsupported paginated order by field id: %s: nullable without presence", by.Field)
+			}
 		}
 	}
-`))
 
-	_ = template.Must(repositoryTemplate.New("repository-update-field-mask").Funcs(funcMap).Parse(`
-	for _, {{toLowerCamel .GetName}} := range toUpdate {
-		if {{toLowerCamel .GetName}}.{{protoFieldAccessor $.FieldMaskCol}} == nil {
-			_, err = stmt.ExecContext(ctx, {{ range $i, $col := .NonPrimeAttributeCols -}}
-			{{if $i}},{{end}}{{toLowerCamel $.GetName}}.{{protoFieldAccessor $col}}
-			{{- end }},{{ range $i, $col := .PrimaryKeyCols -}}
-			{{if $i}},{{end}}{{toLowerCamel $.GetName}}.{{protoFieldAccessor $col}}
-			{{- end }})
-			if err != nil {
-				return nil, err
-			}
-			continue
+	query := ` + "`" + `SELECT {{ range $i, $col := .QueryableCols -}}
+		{{if $i}},{{end}}{{sqlQuotedIdent $col.GetName}}
+		{{- end}}
+		FROM {{sqlQuotedIdent .GetName -}}
+` + "`" + `
+	if fields != nil {
+		columns := make([]string, 0, len(fields))
+		for _, field := range fields {
+			columns = append(columns, fmt.Sprintf(` + "`" + `"%s"` + "`" + `, sqlite{{.GetName}}ColumnNameByFieldID[field]))
+		}
+		query = "SELECT " + strings.Join(columns, ",") + ` + "`" + `
+		FROM {{sqlQuotedIdent .GetName}}` + "`" + `
+	}
+	conditions, binds, err := sqlite{{.GetName}}Conditions(expr)
+	if err != nil {
+		return "", nil, nil, nil, err
+	}
+	if opts.PageToken != "" {
+		token, err := decode{{.GetName}}PageToken(opts.PageToken)
+		if err != nil {
+			return "", nil, nil, nil, err
+		}
+		if !slices.Equal(token.OrderBy, orderBy) {
+			return "", nil, nil, nil, fmt.Errorf("invalid page token: ordering mismatch")
+		}
+		keyset, keysetBinds, err := sqlite{{.GetName}}KeysetClause(orderBy, token.Values)
+		if err != nil {
+			return "", nil, nil, nil, err
+		}
+		conditions = append(conditions, keyset)
+		binds = append(binds, keysetBinds...)
+	}
+	if len(conditions) > 0 {
+		query += "\nWHERE\n" + strings.Join(conditions, " AND ")
+	}
+	if len(opts.OrderBy) > 0 || opts.Limit > 0 || opts.Offset > 0 || opts.PageToken != "" {
+		orderByClause, err := sqlite{{.GetName}}OrderByClause(orderBy)
+		if err != nil {
+			return "", nil, nil, nil, err
+		}
+		query += "\nORDER BY " + orderByClause
+	}
+	if opts.Limit > 0 {
+		// one extra row is read to determine whether there is a next page
+		query += fmt.Sprintf("\nLIMIT %d", opts.Limit + 1)
+	}
+	if opts.Offset > 0 {
+		if opts.Limit == 0 {
+			// SQLite only accepts OFFSET after LIMIT, a negative LIMIT is unlimited
+			query += "\nLIMIT -1"
+		}
+		query += fmt.Sprintf("\nOFFSET %d", opts.Offset)
+	}
+	return query, binds, orderBy, fields, nil
+}
+
+// sqlite{{.GetName}}Conditions returns the conditions matching the {{.GetName}}s meeting the provided criteria
+{{- if .HasDeletedAt}}, soft deleted {{.GetName}}s never match{{end}}
+func sqlite{{.GetName}}Conditions(expr expressions.Expression) ([]string, []any, error) {
+	clauses, binds, err := whereClauseFromExpressionForSQLite{{.GetName}}(expr)
+	if err != nil {
+		return nil, nil, err
+	}
+	var conditions []string
+	{{- if .HasDeletedAt}}
+	conditions = append(conditions, ` + "`" + `{{sqlQuotedIdent .GetName}}.{{sqlQuotedIdent .DeletedAtCol.GetName}} IS NULL` + "`" + `)
+	{{- end}}
+	if clauses != "" {
+		conditions = append(conditions, "(" + clauses + ")")
+	}
+	return conditions, binds, nil
+}
+
+// sqliteScan{{.GetName}} scans the current row of a query built by selectQuery
+func sqliteScan{{.GetName}}(rows *sql.Rows) (*{{.GoType .File.GoPkg.Path}}, error) {
+	{{toLowerCamel .GetName}} := &{{.GoType .File.GoPkg.Path}}_builder{
+		{{range $i, $field := .NonPrimeAttributes -}}
+		{{if $field.HasRelationship}}{{camelIdentifier $field.GetName}}: &{{$field.FieldMessage.GoType $.File.GoPkg.Path}}{},{{end}}
+		{{if $field.Inline}}{{camelIdentifier $field.GetName}}: &{{$field.FieldMessage.GoType $.File.GoPkg.Path}}{},{{end}}
+		{{- end}}
+	}
+	{{range $i, $col := .QueryableCols -}}
+	{{if $col.Field.AsTimestamp}}var {{toLowerCamel $col.Field.GetName}}TimeStr string
+	{{end}}
+	{{- end}}
+	if err := rows.Scan(
+	{{- range $i, $col := .QueryableCols -}}
+	{{if $i}},{{end}}
+	{{- if not $col.Field.AsTimestamp}} {{protoFieldScanDest (toLowerCamel $.GetName) $col}} {{end -}}
+	{{- if $col.Field.AsTimestamp}} &{{toLowerCamel $col.Field.GetName}}TimeStr {{end -}}
+	{{- end -}}
+	); err != nil {
+		return nil, err
+	}
+	{{ range $i, $col := .QueryableCols -}}
+	{{ if $col.Field.AsTimestamp}}
+	{{toLowerCamel $col.Field.GetName}}Time, err := time.Parse(time.RFC3339, {{toLowerCamel $col.Field.GetName}}TimeStr)
+	if err != nil {
+		return nil, err
+	}
+	{{toLowerCamel $.GetName}}.{{protoFieldField $col}} = timestamppb.New({{toLowerCamel $col.Field.GetName}}Time)
+	{{end }}
+	{{- end }}
+	return {{toLowerCamel .GetName}}.Build(), nil
+}
+
+// sqliteScan{{.GetName}}Fields scans the current row of a query built by selectQuery reading only fields
+{{- if .HasFieldMask}}, the field
+// mask is set to the fields read{{end}}
+func sqliteScan{{.GetName}}Fields(rows *sql.Rows, fields []expressions.ID) (*{{.GoType .File.GoPkg.Path}}, error) {
+	{{toLowerCamel .GetName}} := &{{.GoType .File.GoPkg.Path}}_builder{
+		{{range $i, $field := .NonPrimeAttributes -}}
+		{{if $field.HasRelationship}}{{camelIdentifier $field.GetName}}: &{{$field.FieldMessage.GoType $.File.GoPkg.Path}}{},{{end}}
+		{{if $field.Inline}}{{camelIdentifier $field.GetName}}: &{{$field.FieldMessage.GoType $.File.GoPkg.Path}}{},{{end}}
+		{{- end}}
+	}
+	dest := make([]any, 0, len(fields))
+	var apply []func() error
+	for _, field := range fields {
+		fieldDest, fieldApply, err := sqlite{{.GetName}}ScanDest({{toLowerCamel .GetName}}, field)
+		if err != nil {
+			return nil, err
+		}
+		dest = append(dest, fieldDest)
+		if fieldApply != nil {
+			apply = append(apply, fieldApply)
+		}
+	}
+	if err := rows.Scan(dest...); err != nil {
+		return nil, err
+	}
+	for _, fn := range apply {
+		if err := fn(); err != nil {
+			return nil, err
+		}
+	}
+	{{- if .HasFieldMask}}
+	paths := make([]string, 0, len(fields))
+	for _, field := range fields {
+		paths = append(paths, {{toLowerCamel .GetName}}FieldMaskPathByFieldID[field])
+	}
+	{{toLowerCamel .GetName}}.{{protoFieldField .FieldMaskCol}} = &fieldmaskpb.FieldMask{Paths: paths}
+	{{- end}}
+	return {{toLowerCamel .GetName}}.Build(), nil
+}
+
+// sqlite{{.GetName}}ScanDest returns where to scan the column of a field into def, along with a function applying the
+// scanned value when it cannot be scanned into def directly
+func sqlite{{.GetName}}ScanDest(def *{{.GoType .File.GoPkg.Path}}_builder, fieldID expressions.ID) (any, func() error, error) {
+	switch fieldID {
+	{{- range $col := .QueryableCols}}
+	case {{fieldIDConstantName $col.QueryableField}}:
+		{{- if $col.Field.AsTimestamp}}
+		var value string
+		return &value, func() error {
+			valueTime, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return err
+			}
+			def.{{protoFieldField $col}} = timestamppb.New(valueTime)
+			return nil
+		}, nil
+		{{- else}}
+		return {{protoFieldScanDest "def" $col}}, nil, nil
+		{{- end}}
+	{{- end}}
+	default:
+		return nil, nil, fmt.Errorf("unsupported field id: %s", fieldID)
+	}
+}
+`))
+
+	_ = template.Must(repositoryTemplate.New("repository-aggregate").Funcs(funcMap).Parse(`
+// Count returns the number of {{.GetName}}s matching the provided criteria
+func (repo *SQLite{{.GetName}}Repository) Count(ctx context.Context, expr expressions.Expression) (_ int64, err error) {
+	defer func() { err = crudsqlite.WrapError(err) }()
+	conditions, binds, err := sqlite{{.GetName}}Conditions(expr)
+	if err != nil {
+		return 0, err
+	}
+	query := ` + "`" + `SELECT COUNT(*) FROM {{sqlQuotedIdent .GetName}}` + "`" + `
+	if len(conditions) > 0 {
+		query += "\nWHERE\n" + strings.Join(conditions, " AND ")
+	}
+	stmt, err := repo.db.PrepareContext(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+	defer stmt.Close()
+	var count int64
+	if err := stmt.QueryRowContext(ctx, binds...).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// Exists reports whether any {{.GetName}} matches the provided criteria
+func (repo *SQLite{{.GetName}}Repository) Exists(ctx context.Context, expr expressions.Expression) (_ bool, err error) {
+	defer func() { err = crudsqlite.WrapError(err) }()
+	conditions, binds, err := sqlite{{.GetName}}Conditions(expr)
+	if err != nil {
+		return false, err
+	}
+	query := ` + "`" + `SELECT 1 FROM {{sqlQuotedIdent .GetName}}` + "`" + `
+	if len(conditions) > 0 {
+		query += "\nWHERE\n" + strings.Join(conditions, " AND ")
+	}
+	stmt, err := repo.db.PrepareContext(ctx, "SELECT EXISTS (" + query + ")")
+	if err != nil {
+		return false, err
+	}
+	defer stmt.Close()
+	var exists bool
+	if err := stmt.QueryRowContext(ctx, binds...).Scan(&exists); err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+// Aggregate computes aggregates of the {{.GetName}}s matching the provided criteria, one result per group ordered by
+// the GroupBy fields
+func (repo *SQLite{{.GetName}}Repository) Aggregate(ctx context.Context, expr expressions.Expression, opts *{{.GetName}}AggregateOptions) (_ []*{{.GetName}}AggregateResult, err error) {
+	defer func() { err = crudsqlite.WrapError(err) }()
+	if opts == nil {
+		opts = &{{.GetName}}AggregateOptions{}
+	}
+	groupBy := make([]string, 0, len(opts.GroupBy))
+	for _, field := range opts.GroupBy {
+		colName, ok := sqlite{{.GetName}}ColumnNameByFieldID[field]
+		if !ok {
+			return nil, fmt.Errorf("missing meta-data: field id: %s", field)
+		}
+		groupBy = append(groupBy, fmt.Sprintf(` + "`" + `{{sqlQuotedIdent .GetName}}."%s"` + "`" + `, colName))
+	}
+	columns := append(slices.Clone(groupBy), "COUNT(*)")
+	isFloat := make([]bool, len(opts.Aggregates))
+	for i, aggregate := range opts.Aggregates {
+		function, err := aggregate.Function.SQL()
+		if err != nil {
+			return nil, err
+		}
+		floatingPoint, ok := sqlite{{.GetName}}NumericFieldIDs[aggregate.Field]
+		if !ok {
+			return nil, fmt.Errorf("unsupported aggregate field id: %s", aggregate.Field)
+		}
+		isFloat[i] = floatingPoint || aggregate.Function == crudruntime.AggregateAvg
+		sqlType := "INTEGER"
+		if isFloat[i] {
+			sqlType = "REAL"
+		}
+		columns = append(
+			columns,
+			fmt.Sprintf(
+				` + "`" + `CAST(%s({{sqlQuotedIdent .GetName}}."%s") AS %s)` + "`" + `,
+				function,
+				sqlite{{.GetName}}ColumnNameByFieldID[aggregate.Field],
+				sqlType,
+			),
+		)
+	}
+	conditions, binds, err := sqlite{{.GetName}}Conditions(expr)
+	if err != nil {
+		return nil, err
+	}
+	query := "SELECT " + strings.Join(columns, ", ") + ` + "`" + `
+FROM {{sqlQuotedIdent .GetName}}` + "`" + `
+	if len(conditions) > 0 {
+		query += "\nWHERE\n" + strings.Join(conditions, " AND ")
+	}
+	if len(groupBy) > 0 {
+		query += "\nGROUP BY " + strings.Join(groupBy, ", ") + "\nORDER BY " + strings.Join(groupBy, ", ")
+	}
+	stmt, err := repo.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+	rows, err := stmt.QueryContext(ctx, binds...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var results []*{{.GetName}}AggregateResult
+	for rows.Next() {
+		group := &{{.GoType .File.GoPkg.Path}}_builder{
+			{{range $i, $field := .NonPrimeAttributes -}}
+			{{if $field.HasRelationship}}{{camelIdentifier $field.GetName}}: &{{$field.FieldMessage.GoType $.File.GoPkg.Path}}{},{{end}}
+			{{if $field.Inline}}{{camelIdentifier $field.GetName}}: &{{$field.FieldMessage.GoType $.File.GoPkg.Path}}{},{{end}}
+			{{- end}}
+		}
+		result := &{{.GetName}}AggregateResult{Values: make([]crudruntime.AggregateValue, len(opts.Aggregates))}
+		dest := make([]any, 0, len(columns))
+		var apply []func() error
+		for _, field := range opts.GroupBy {
+			groupDest, groupApply, err := sqlite{{.GetName}}ScanDest(group, field)
+			if err != nil {
+				return nil, err
+			}
+			dest = append(dest, groupDest)
+			if groupApply != nil {
+				apply = append(apply, groupApply)
+			}
+		}
+		dest = append(dest, &result.Count)
+		ints := make([]sql.NullInt64, len(opts.Aggregates))
+		floats := make([]sql.NullFloat64, len(opts.Aggregates))
+		for i := range opts.Aggregates {
+			if isFloat[i] {
+				dest = append(dest, &floats[i])
+				continue
+			}
+			dest = append(dest, &ints[i])
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		for _, fn := range apply {
+			if err := fn(); err != nil {
+				return nil, err
+			}
+		}
+		for i := range opts.Aggregates {
+			result.Values[i] = crudruntime.AggregateValue{
+				Int:     ints[i].Int64,
+				Float:   floats[i].Float64,
+				IsFloat: isFloat[i],
+				Valid:   ints[i].Valid || floats[i].Valid,
+			}
+		}
+		result.Group = group.Build()
+		results = append(results, result)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// sqlite{{.GetName}}NumericFieldIDs are the fields which can be aggregated, mapped to whether they are floating point.
+// Unsigned 64 bit integers are left out, their aggregates may not fit the int64 of crudruntime.AggregateValue.
+var sqlite{{.GetName}}NumericFieldIDs = map[expressions.ID]bool{
+{{- range $col := .QueryableCols}}
+{{- if and $col.Field.IsNumeric (not $col.Field.IsUnsigned64)}}
+	{{fieldIDConstantName $col.QueryableField}}: {{$col.Field.IsFloatingPoint}},
+{{- end}}
+{{- end}}
+}
+`))
+
+	_ = template.Must(repositoryTemplate.New("repository-update").Funcs(funcMap).Parse(`
+// Update modifies existing {{.GetName}}s based on the defined unique identifiers, a {{.GetName}} which does not exist
+// fails with crudruntime.ErrNotFound.
+{{- if .VersionCol}}
+// A {{.GetName}} whose version no longer matches fails with crudruntime.ErrVersionConflict.
+{{- end}}
+// The columns written are read back using RETURNING, as they are by Create.
+// With crudruntime.BatchPartialSuccess the {{.GetName}}s returned are aligned with toUpdate, those which failed are nil.
+func (repo *SQLite{{.GetName}}Repository) Update(ctx context.Context, toUpdate []*{{.GoType .File.GoPkg.Path}}) (updated []*{{.GoType .File.GoPkg.Path}}, err error) {
+	{{- if .VersionCol}}
+	versions := make([]{{.VersionCol.GoType}}, len(toUpdate))
+	for i, {{toLowerCamel .GetName}} := range toUpdate {
+		versions[i] = {{toLowerCamel .GetName}}.{{protoFieldAccessor .VersionCol}}
+	}
+	{{- end}}
+	err = repo.options.RetryPolicy.Retry(ctx, repo.db, func() error {
+		{{- if .VersionCol}}
+		// versions returned by a failed attempt were rolled back with it
+		for i, {{toLowerCamel .GetName}} := range toUpdate {
+			{{toLowerCamel .GetName}}.{{protoFieldMutatorFn .VersionCol "versions[i]"}}
+		}
+		{{- end}}
+		if repo.options.BatchMode == crudruntime.BatchPartialSuccess {
+			updated, err = repo.updateEach(ctx, toUpdate)
+			return err
+		}
+		updated, err = repo.update(ctx, toUpdate)
+		return err
+	})
+	{{- if .VersionCol}}
+	// versions returned for {{.GetName}}s which then failed were rolled back with them
+	for i, {{toLowerCamel .GetName}} := range toUpdate {
+		if i >= len(updated) || updated[i] == nil {
+			{{toLowerCamel .GetName}}.{{protoFieldMutatorFn .VersionCol "versions[i]"}}
+		}
+	}
+	{{- end}}
+	return updated, err
+}
+
+// updateEach modifies each {{.GetName}} within its own savepoint of a single transaction, a failing {{.GetName}} is
+// rolled back alone and its error is returned, by index, in a *crudruntime.BatchError.
+func (repo *SQLite{{.GetName}}Repository) updateEach(ctx context.Context, toUpdate []*{{.GoType .File.GoPkg.Path}}) (_ []*{{.GoType .File.GoPkg.Path}}, err error) {
+	defer func() { err = crudsqlite.WrapError(err) }()
+	if len(toUpdate) == 0 {
+		return nil, nil
+	}
+	tx, err := crudsqlite.BeginTx(ctx, repo.db, &sql.TxOptions{Isolation: repo.options.IsolationLevel})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	// update begins a savepoint within tx
+	inTx := *repo
+	inTx.db = tx
+	updated := make([]*{{.GoType .File.GoPkg.Path}}, len(toUpdate))
+	batchErr := &crudruntime.BatchError{}
+	for i, {{toLowerCamel .GetName}} := range toUpdate {
+		if _, err := inTx.update(ctx, []*{{.GoType .File.GoPkg.Path}}{ {{- toLowerCamel .GetName -}} }); err != nil {
+			batchErr.Add(i, err)
+			continue
+		}
+		updated[i] = {{toLowerCamel .GetName}}
+	}
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+	return updated, batchErr.ErrorOrNil()
+}
+
+// update modifies existing {{.GetName}}s in a single transaction.
+func (repo *SQLite{{.GetName}}Repository) update(ctx context.Context, toUpdate []*{{.GoType .File.GoPkg.Path}}) (_ []*{{.GoType .File.GoPkg.Path}}, err error) {
+	defer func() { err = crudsqlite.WrapError(err) }()
+	{{if eq (len .NonPrimeAttributes) 0 -}}
+	return nil, nil
+	{{- else -}}
+	if len(toUpdate) == 0 {
+		return nil, nil
+	}
+	tx, err := crudsqlite.BeginTx(ctx, repo.db, &sql.TxOptions{Isolation: repo.options.IsolationLevel})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(
+		ctx,
+		` + "`" + `UPDATE {{sqlQuotedIdent .GetName}} SET {{range $i, $col := .UpdateCols -}}
+		{{if $i}},{{end}}{{sqlQuotedIdent $col.GetName}} = ?
+		{{- end }}
+		{{- if .VersionCol}}{{if .UpdateCols}},{{end}}{{sqlQuotedIdent .VersionCol.GetName}} = {{sqlQuotedIdent .VersionCol.GetName}} + 1{{end}} WHERE {{ range $i, $cols := .PrimaryKeyCols -}}
+		{{if $i}} AND {{end}}{{sqlQuotedIdent $cols.GetName}} = ?
+		{{- end }}
+		{{- if .VersionCol}} AND {{sqlQuotedIdent .VersionCol.GetName}} = ?{{end}}
+		{{- if .HasDeletedAt}} AND {{sqlQuotedIdent .DeletedAtCol.GetName}} IS NULL{{end}}
+		{{- template "repository-returning" .}}` + "`" + `,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	{{ if .HasUpdatedAt -}}
+	{{template "repository-now" .}}
+	for _, {{toLowerCamel .GetName}} := range toUpdate {
+		{{- if not .UsesDatabaseClock}}
+		if {{toLowerCamel .GetName}}.Get{{protoFieldField $.UpdatedAtCol}}() != nil {
+			continue
+		}
+		{{- end}}
+		{{toLowerCamel .GetName}}.{{protoFieldMutatorFn $.UpdatedAtCol "timestamppb.New(now)"}}
+	}
+	{{- end -}}
+
+	{{- if .HasFieldMask -}}
+	{{ template "repository-update-field-mask" .}}
+	{{- else -}}
+	{{ template "repository-update-no-field-mask" .}}
+	{{- end -}}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+	return toUpdate, nil
+	{{- end -}}
+}
+`))
+
+	_ = template.Must(repositoryTemplate.New("repository-update-no-field-mask").Funcs(funcMap).Parse(`
+	for _, {{toLowerCamel .GetName}} := range toUpdate {
+		rows, err := stmt.QueryContext(ctx, {{ range $i, $col := .UpdateCols -}}
+		{{protoFieldValue (toLowerCamel $.GetName) $col}},
+		{{- end }}{{ range $i, $col := .PrimaryKeyCols -}}
+		{{if $i}},{{end}}{{protoFieldValue (toLowerCamel $.GetName) $col}}
+		{{- end }}
+		{{- if .VersionCol}},{{toLowerCamel .GetName}}.{{protoFieldAccessor .VersionCol}}{{end}})
+		if err != nil {
+			return nil, err
+		}
+		affected, err := sqlite{{.GetName}}Returned(rows, nil, []*{{.GoType .File.GoPkg.Path}}{ {{- toLowerCamel .GetName -}} })
+		if err != nil {
+			return nil, err
+		}
+		if err = sqlite{{.GetName}}Updated(ctx, tx, affected, {{toLowerCamel .GetName}}); err != nil {
+			return nil, err
+		}
+	}
+`))
+
+	_ = template.Must(repositoryTemplate.New("repository-update-field-mask").Funcs(funcMap).Parse(`
+	for _, {{toLowerCamel .GetName}} := range toUpdate {
+		if {{toLowerCamel .GetName}}.{{protoFieldAccessor $.FieldMaskCol}} == nil {
+			rows, err := stmt.QueryContext(ctx, {{ range $i, $col := .UpdateCols -}}
+			{{protoFieldValue (toLowerCamel $.GetName) $col}},
+			{{- end }}{{ range $i, $col := .PrimaryKeyCols -}}
+			{{if $i}},{{end}}{{protoFieldValue (toLowerCamel $.GetName) $col}}
+			{{- end }}
+			{{- if .VersionCol}},{{toLowerCamel .GetName}}.{{protoFieldAccessor .VersionCol}}{{end}})
+			if err != nil {
+				return nil, err
+			}
+			affected, err := sqlite{{.GetName}}Returned(rows, nil, []*{{.GoType .File.GoPkg.Path}}{ {{- toLowerCamel .GetName -}} })
+			if err != nil {
+				return nil, err
+			}
+			if err = sqlite{{.GetName}}Updated(ctx, tx, affected, {{toLowerCamel .GetName}}); err != nil {
+				return nil, err
+			}
+			continue
 		}
 		valuesByColName, err := sqlite{{.GetName}}GetUpdateValuesByColumnName({{toLowerCamel .GetName}}, {{toLowerCamel .GetName}}.{{protoFieldAccessor $.FieldMaskCol}})
 		if err != nil {
 			return nil, err
 		}
-		if len(valuesByColName) == 0 {
-			continue
+		{{- if and .UsesDatabaseClock .HasUpdatedAt}}
+		// the updated at time of the database clock is written whether masked or not
+		valuesByColName["{{sqlIdent .UpdatedAtCol.GetName}}"] = {{toLowerCamel .GetName}}.{{protoFieldAccessor .UpdatedAtCol}}
+		{{- end}}
+		{{- if .VersionCol}}
+		// the version is incremented rather than set
+		delete(valuesByColName, "{{sqlIdent .VersionCol.GetName}}")
+		{{- else}}
+		if len(valuesByColName) == 0 {
+			// nothing is updated, a {{.GetName}} which does not exist still fails
+			exists, err := sqlite{{.GetName}}Exists(ctx, tx, {{toLowerCamel .GetName}})
+			if err != nil {
+				return nil, err
+			}
+			if !exists {
+				return nil, fmt.Errorf("%w: {{.GetName}} %+v", crudruntime.ErrNotFound, {{.GetName}}KeyOf({{toLowerCamel .GetName}}))
+			}
+			continue
+		}
+		{{- end}}
+		var binds []any
+		var setStmts []string
+		{{- if .VersionCol}}
+		setStmts = append(setStmts, "\"{{sqlIdent .VersionCol.GetName}}\" = \"{{sqlIdent .VersionCol.GetName}}\" + 1")
+		{{- end}}
+		for colName, value := range valuesByColName {
+			setStmts = append(setStmts, fmt.Sprintf("\"%s\" = ?", colName))
+			binds = append(binds, value)
+		}
+		fields := sqlite{{.GetName}}WrittenFields(valuesByColName)
+		rows, err := tx.QueryContext(
+			ctx,
+			fmt.Sprintf(
+				` + "`" + `UPDATE {{sqlQuotedIdent .GetName}} SET %s WHERE {{ range $i, $col := .PrimaryKeyCols -}}
+				{{if $i}} AND {{end}}{{sqlQuotedIdent $col.GetName}} = ?
+				{{- end }}
+				{{- if .VersionCol}} AND {{sqlQuotedIdent .VersionCol.GetName}} = ?{{end}}
+				{{- if .HasDeletedAt}} AND {{sqlQuotedIdent .DeletedAtCol.GetName}} IS NULL{{end}}%s` + "`" + `,
+				strings.Join(setStmts, ", "),
+				sqlite{{.GetName}}Returning(fields),
+			),
+			append(
+				binds,
+				{{ range $i, $field := .PrimaryKeyCols -}}
+				{{if $i}},{{end}}{{protoFieldValue (toLowerCamel $.GetName) $field}}
+				{{- end }},
+				{{- if .VersionCol}}
+				{{toLowerCamel .GetName}}.{{protoFieldAccessor .VersionCol}},
+				{{- end}}
+			)...
+		)
+		if err != nil {
+			return nil, err
+		}
+		affected, err := sqlite{{.GetName}}Returned(rows, fields, []*{{.GoType .File.GoPkg.Path}}{ {{- toLowerCamel .GetName -}} })
+		if err != nil {
+			return nil, err
+		}
+		if err = sqlite{{.GetName}}Updated(ctx, tx, affected, {{toLowerCamel .GetName}}); err != nil {
+			return nil, err
+		}
+	}
+`))
+
+	_ = template.Must(repositoryTemplate.New("repository-upsert").Funcs(funcMap).Parse(`
+// Upsert creates {{.GetName}}s or, when one with the same unique identifiers exists, updates it instead.
+// Field masks apply as they do to Create and Update and the created at time of an existing {{.GetName}} is kept.
+{{- if .HasDeletedAt}}
+// A soft deleted {{.GetName}} is left unchanged and fails with crudruntime.ErrNotFound.
+{{- end}}
+{{- if .VersionCol}}
+// An existing {{.GetName}} whose version no longer matches fails with crudruntime.ErrVersionConflict, the version of
+// one updated is incremented.
+{{- end}}
+func (repo *SQLite{{.GetName}}Repository) Upsert(ctx context.Context, toUpsert []*{{.GoType .File.GoPkg.Path}}) (upserted []*{{.GoType .File.GoPkg.Path}}, err error) {
+	{{- if .SequentialKeyCol}}
+	var generateKeys []*{{.GoType .File.GoPkg.Path}}
+	for _, {{toLowerCamel .GetName}} := range toUpsert {
+		if {{toLowerCamel .GetName}}.{{protoFieldAccessor .SequentialKeyCol}} == 0 {
+			generateKeys = append(generateKeys, {{toLowerCamel .GetName}})
+		}
+	}
+	{{- end}}
+	err = repo.options.RetryPolicy.Retry(ctx, repo.db, func() error {
+		{{- if .SequentialKeyCol}}
+		// keys generated by a failed attempt were rolled back with it
+		for _, {{toLowerCamel .GetName}} := range generateKeys {
+			{{toLowerCamel .GetName}}.{{protoFieldMutatorFn .SequentialKeyCol "0"}}
+		}
+		{{- end}}
+		upserted, err = repo.upsert(ctx, toUpsert)
+		return err
+	})
+	return upserted, err
+}
+
+// upsert creates or updates {{.GetName}}s in a single transaction.
+func (repo *SQLite{{.GetName}}Repository) upsert(ctx context.Context, toUpsert []*{{.GoType .File.GoPkg.Path}}) (_ []*{{.GoType .File.GoPkg.Path}}, err error) {
+	defer func() { err = crudsqlite.WrapError(err) }()
+	if len(toUpsert) == 0 {
+		return nil, nil
+	}
+	tx, err := crudsqlite.BeginTx(ctx, repo.db, &sql.TxOptions{Isolation: repo.options.IsolationLevel})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	toMerge := toUpsert
+	{{- if or .SequentialKeyCol .UUIDKeyCols}}
+	// {{.GetName}}s without generated unique identifiers cannot conflict, they are created
+	toMerge = nil
+	var toCreate []*{{.GoType .File.GoPkg.Path}}
+	for _, {{toLowerCamel .GetName}} := range toUpsert {
+		{{- if .SequentialKeyCol}}
+		if {{toLowerCamel .GetName}}.{{protoFieldAccessor .SequentialKeyCol}} == 0 {
+			toCreate = append(toCreate, {{toLowerCamel .GetName}})
+			continue
+		}
+		{{- end}}
+		{{- range $col := .UUIDKeyCols}}
+		if {{toLowerCamel $.GetName}}.{{protoFieldAccessor $col}} == "" {
+			toCreate = append(toCreate, {{toLowerCamel $.GetName}})
+			continue
+		}
+		{{- end}}
+		toMerge = append(toMerge, {{toLowerCamel .GetName}})
+	}
+	inTx := *repo
+	inTx.db = tx
+	_, err = inTx.create(ctx, toCreate)
+	if err != nil {
+		return nil, err
+	}
+	{{- end}}
+
+	{{ if or .HasCreatedAt .HasUpdatedAt -}}
+	{{template "repository-now" .}}
+	{{- end}}
+
+	{{ if .HasCreatedAt -}}
+	for _, {{toLowerCamel .GetName}} := range toMerge {
+		{{- if not .UsesDatabaseClock}}
+		if {{toLowerCamel .GetName}}.Get{{protoFieldField $.CreatedAtCol}}() != nil {
+			continue
+		}
+		{{- end}}
+		{{toLowerCamel .GetName}}.{{protoFieldMutatorFn $.CreatedAtCol "timestamppb.New(now)"}}
+	}
+	{{- end}}
+
+	{{ if .HasUpdatedAt -}}
+	for _, {{toLowerCamel .GetName}} := range toMerge {
+		{{- if not .UsesDatabaseClock}}
+		if {{toLowerCamel .GetName}}.Get{{protoFieldField $.UpdatedAtCol}}() != nil {
+			continue
+		}
+		{{- end}}
+		{{toLowerCamel .GetName}}.{{protoFieldMutatorFn $.UpdatedAtCol "timestamppb.New(now)"}}
+	}
+	{{- end}}
+
+	stmt, err := tx.PrepareContext(
+		ctx,
+		` + "`" + `INSERT INTO {{sqlQuotedIdent .GetName}} (
+		{{- range $i, $col := .QueryableCols -}}
+		{{if $i}},{{end}}{{sqlQuotedIdent $col.GetName}}
+		{{- end -}}
+		) VALUES (
+		{{- range $i, $col := .QueryableCols -}}
+		{{if $i}},{{end}}?
+		{{- end -}}
+		){{template "repository-upsert-conflict" .}}{{template "repository-returning" .}}` + "`" + `,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	for _, {{toLowerCamel .GetName}} := range toMerge {
+		{{- if .HasFieldMask}}
+		if {{toLowerCamel .GetName}}.{{protoFieldAccessor $.FieldMaskCol}} != nil {
+			err = repo.upsertFieldMask(ctx, tx, {{toLowerCamel .GetName}})
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+		{{- end}}
+		rows, err := stmt.QueryContext(ctx, {{ range $i, $col := .QueryableCols -}}
+		{{if $i}},{{end}}{{protoFieldValue (toLowerCamel $.GetName) $col}}
+		{{- end }})
+		if err != nil {
+			return nil, err
+		}
+		{{- if or .HasDeletedAt .VersionCol}}
+		affected, err := sqlite{{.GetName}}Returned(rows, nil, []*{{.GoType .File.GoPkg.Path}}{ {{- toLowerCamel .GetName -}} })
+		if err != nil {
+			return nil, err
 		}
-		var binds []any
-		var setStmts []string
-		for colName, value := range valuesByColName {
-			setStmts = append(setStmts, fmt.Sprintf("\"%s\" = ?", colName))
-			binds = append(binds, value)
+		if err = sqlite{{.GetName}}Upserted(ctx, tx, affected, {{toLowerCamel .GetName}}); err != nil {
+			return nil, err
 		}
-		_, err = tx.ExecContext(
-			ctx,
-			fmt.Sprintf(
-				` + "`" + `UPDATE {{sqlQuotedIdent .GetName}} SET %s WHERE {{ range $i, $col := .PrimaryKeyCols -}}
-				{{if $i}} AND {{end}}{{sqlQuotedIdent $col.GetName}} = ?
-				{{- end }}` + "`" + `,
-				strings.Join(setStmts, ", "),
-			),
-			append(
-				binds,
-				{{ range $i, $field := .PrimaryKeyCols -}}
-				{{if $i}},{{end}}{{toLowerCamel $.GetName}}.Get{{camelIdentifier $field.GetName}}()
-				{{- end }},
-			)...
-		)
-		if err != nil {
+		{{- else}}
+		if _, err = sqlite{{.GetName}}Returned(rows, nil, []*{{.GoType .File.GoPkg.Path}}{ {{- toLowerCamel .GetName -}} }); err != nil {
 			return nil, err
 		}
+		{{- end}}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+	return toUpsert, nil
+}
+{{- if .HasFieldMask}}
+
+// upsertFieldMask creates or updates a single {{.GetName}} with the columns selected by its field mask
+func (repo *SQLite{{.GetName}}Repository) upsertFieldMask(ctx context.Context, tx crudruntime.Querier, {{toLowerCamel .GetName}} *{{.GoType .File.GoPkg.Path}}) error {
+	valuesByColName, err := sqlite{{.GetName}}GetCreateValuesByColumnName({{toLowerCamel .GetName}}, {{toLowerCamel .GetName}}.{{protoFieldAccessor $.FieldMaskCol}})
+	if err != nil {
+		return err
+	}
+	updateValuesByColName, err := sqlite{{.GetName}}GetUpdateValuesByColumnName({{toLowerCamel .GetName}}, {{toLowerCamel .GetName}}.{{protoFieldAccessor $.FieldMaskCol}})
+	if err != nil {
+		return err
+	}
+	{{- if .HasCreatedAt}}
+	delete(updateValuesByColName, "{{sqlIdent .CreatedAtCol.GetName}}")
+	{{- end}}
+	{{- if .VersionCol}}
+	// the version is compared whether masked or not, an existing {{.GetName}} has it incremented rather than set
+	valuesByColName["{{sqlIdent .VersionCol.GetName}}"] = {{toLowerCamel .GetName}}.{{protoFieldAccessor .VersionCol}}
+	delete(updateValuesByColName, "{{sqlIdent .VersionCol.GetName}}")
+	{{- end}}
+	{{- if and .UsesDatabaseClock .HasCreatedAt}}
+	// the created at time of the database clock is written whether masked or not
+	valuesByColName["{{sqlIdent .CreatedAtCol.GetName}}"] = {{toLowerCamel .GetName}}.{{protoFieldAccessor .CreatedAtCol}}
+	{{- end}}
+	{{- if and .UsesDatabaseClock .HasUpdatedAt}}
+	// the updated at time of the database clock is written whether masked or not
+	valuesByColName["{{sqlIdent .UpdatedAtCol.GetName}}"] = {{toLowerCamel .GetName}}.{{protoFieldAccessor .UpdatedAtCol}}
+	updateValuesByColName["{{sqlIdent .UpdatedAtCol.GetName}}"] = {{toLowerCamel .GetName}}.{{protoFieldAccessor .UpdatedAtCol}}
+	{{- end}}
+	var binds []any
+	var cols []string
+	var params []string
+	for colName, value := range valuesByColName {
+		cols = append(cols, "\"" + colName + "\"")
+		params = append(params, "?")
+		binds = append(binds, value)
+	}
+	var setStmts []string
+	{{- if .VersionCol}}
+	setStmts = append(setStmts, "\"{{sqlIdent .VersionCol.GetName}}\" = \"{{sqlIdent .GetName}}\".\"{{sqlIdent .VersionCol.GetName}}\" + 1")
+	{{- end}}
+	for colName := range updateValuesByColName {
+		setStmts = append(setStmts, fmt.Sprintf("\"%s\" = EXCLUDED.\"%s\"", colName, colName))
+	}
+	conflict := ` + "`" + `ON CONFLICT ({{range $i, $col := .PrimaryKeyCols}}{{if $i}},{{end}}{{sqlQuotedIdent $col.GetName}}{{end}}) DO NOTHING` + "`" + `
+	if len(setStmts) > 0 {
+		conflict = fmt.Sprintf(
+			` + "`" + `ON CONFLICT ({{range $i, $col := .PrimaryKeyCols}}{{if $i}},{{end}}{{sqlQuotedIdent $col.GetName}}{{end}}) DO UPDATE SET %s
+			{{- if or .HasDeletedAt .VersionCol}} WHERE {{end}}
+			{{- if .HasDeletedAt}}{{sqlQuotedIdent .GetName}}.{{sqlQuotedIdent .DeletedAtCol.GetName}} IS NULL{{end}}
+			{{- if and .HasDeletedAt .VersionCol}} AND {{end}}
+			{{- if .VersionCol}}{{sqlQuotedIdent .GetName}}.{{sqlQuotedIdent .VersionCol.GetName}} = EXCLUDED.{{sqlQuotedIdent .VersionCol.GetName}}{{end}}` + "`" + `,
+			strings.Join(setStmts, ", "),
+		)
+	}
+	fields := sqlite{{.GetName}}WrittenFields(valuesByColName)
+	rows, err := tx.QueryContext(
+		ctx,
+		fmt.Sprintf(
+			` + "`" + `INSERT INTO {{sqlQuotedIdent .GetName}} (%s) VALUES (%s) %s%s` + "`" + `,
+			strings.Join(cols, ", "),
+			strings.Join(params, ", "),
+			conflict,
+			sqlite{{.GetName}}Returning(fields),
+		),
+		binds...
+	)
+	if err != nil {
+		return err
+	}
+	{{- if or .HasDeletedAt .VersionCol}}
+	affected, err := sqlite{{.GetName}}Returned(rows, fields, []*{{.GoType .File.GoPkg.Path}}{ {{- toLowerCamel .GetName -}} })
+	if err != nil {
+		return err
+	}
+	return sqlite{{.GetName}}Upserted(ctx, tx, affected, {{toLowerCamel .GetName}})
+	{{- else}}
+	_, err = sqlite{{.GetName}}Returned(rows, fields, []*{{.GoType .File.GoPkg.Path}}{ {{- toLowerCamel .GetName -}} })
+	return err
+	{{- end}}
+}
+{{- end}}
+`))
+
+	_ = template.Must(repositoryTemplate.New("repository-returning").Funcs(funcMap).Parse(` RETURNING {{range $i, $col := .QueryableCols -}}
+		{{if $i}},{{end}}{{sqlQuotedIdent $col.GetName}}
+		{{- end -}}
+`))
+
+	_ = template.Must(repositoryTemplate.New("repository-upsert-conflict").Funcs(funcMap).Parse(`
+		ON CONFLICT ({{range $i, $col := .PrimaryKeyCols}}{{if $i}},{{end}}{{sqlQuotedIdent $col.GetName}}{{end}})
+		{{- if or .ConflictUpdateCols .VersionCol}} DO UPDATE SET {{range $i, $col := .ConflictUpdateCols -}}
+		{{if $i}},{{end}}{{sqlQuotedIdent $col.GetName}} = EXCLUDED.{{sqlQuotedIdent $col.GetName}}
+		{{- end}}
+		{{- if .VersionCol}}{{if .ConflictUpdateCols}},{{end}}{{sqlQuotedIdent .VersionCol.GetName}} = {{sqlQuotedIdent .GetName}}.{{sqlQuotedIdent .VersionCol.GetName}} + 1{{end}}
+		{{- if or .HasDeletedAt .VersionCol}} WHERE {{end}}
+		{{- if .HasDeletedAt}}{{sqlQuotedIdent .GetName}}.{{sqlQuotedIdent .DeletedAtCol.GetName}} IS NULL{{end}}
+		{{- if and .HasDeletedAt .VersionCol}} AND {{end}}
+		{{- if .VersionCol}}{{sqlQuotedIdent .GetName}}.{{sqlQuotedIdent .VersionCol.GetName}} = EXCLUDED.{{sqlQuotedIdent .VersionCol.GetName}}{{end}}
+		{{- else}} DO NOTHING
+		{{- end -}}
+`))
+
+	_ = template.Must(repositoryTemplate.New("repository-now").Funcs(funcMap).Parse(`
+	{{- if .UsesDatabaseClock}}
+	now, err := crudsqlite.Now(ctx, tx)
+	if err != nil {
+		return nil, err
 	}
+	{{- else}}
+	now := repo.options.Now()
+	{{- end}}
 `))
 
 	_ = template.Must(repositoryTemplate.New("repository-delete").Funcs(funcMap).Parse(`
-// Delete deletes {{.GetName}}s based on the defined unique identifiers
-func (repo *SQLite{{.GetName}}Repository) Delete(ctx context.Context, expr expressions.Expression) error {
+{{- if .HasDeletedAt}}
+// Delete soft deletes {{.GetName}}s matching the provided criteria, the number soft deleted is returned
+func (repo *SQLite{{.GetName}}Repository) Delete(ctx context.Context, expr expressions.Expression) (_ int64, err error) {
+	defer func() { err = crudsqlite.WrapError(err) }()
+	query := ` + "`" + `UPDATE {{sqlQuotedIdent .GetName}} SET {{sqlQuotedIdent .DeletedAtCol.GetName}} = ? WHERE {{sqlQuotedIdent .GetName}}.{{sqlQuotedIdent .DeletedAtCol.GetName}} IS NULL` + "`" + `
+	clauses, binds, err := whereClauseFromExpressionForSQLite{{.GetName}}(expr)
+	if err != nil {
+		return 0, err
+	}
+	if clauses != "" {
+		query += " AND (" + clauses + ")"
+	}
+	stmt, err := repo.db.PrepareContext(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+	defer stmt.Close()
+	{{- if .UsesDatabaseClock}}
+	now, err := crudsqlite.Now(ctx, repo.db)
+	if err != nil {
+		return 0, err
+	}
+	{{- else}}
+	now := repo.options.Now()
+	{{- end}}
+	res, err := stmt.ExecContext(ctx, append([]any{now.UTC().Format(time.RFC3339)}, binds...)...)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// Restore restores soft deleted {{.GetName}}s matching the provided criteria, the number restored is returned
+func (repo *SQLite{{.GetName}}Repository) Restore(ctx context.Context, expr expressions.Expression) (_ int64, err error) {
+	defer func() { err = crudsqlite.WrapError(err) }()
+	query := ` + "`" + `UPDATE {{sqlQuotedIdent .GetName}} SET {{sqlQuotedIdent .DeletedAtCol.GetName}} = NULL WHERE {{sqlQuotedIdent .GetName}}.{{sqlQuotedIdent .DeletedAtCol.GetName}} IS NOT NULL` + "`" + `
+	clauses, binds, err := whereClauseFromExpressionForSQLite{{.GetName}}(expr)
+	if err != nil {
+		return 0, err
+	}
+	if clauses != "" {
+		query += " AND (" + clauses + ")"
+	}
+	stmt, err := repo.db.PrepareContext(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+	defer stmt.Close()
+	res, err := stmt.ExecContext(ctx, binds...)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// Purge permanently deletes {{.GetName}}s, soft deleted or not, matching the provided criteria, the number deleted is
+// returned
+func (repo *SQLite{{.GetName}}Repository) Purge(ctx context.Context, expr expressions.Expression) (_ int64, err error) {
+	defer func() { err = crudsqlite.WrapError(err) }()
+{{- else}}
+// Delete deletes {{.GetName}}s matching the provided criteria, the number deleted is returned
+func (repo *SQLite{{.GetName}}Repository) Delete(ctx context.Context, expr expressions.Expression) (_ int64, err error) {
+	defer func() { err = crudsqlite.WrapError(err) }()
+{{- end}}
 	query := ` + "`" + `DELETE FROM {{sqlQuotedIdent .GetName}}` + "`" + `
 	clauses, binds, err := whereClauseFromExpressionForSQLite{{.GetName}}(expr)
 	if err != nil {
-		return err
+		return 0, err
 	}
 	if clauses != "" {
 		query += "\nWHERE\n" + clauses
 	}
-	stmt, err := repo.db.Prepare(query)
+	stmt, err := repo.db.PrepareContext(ctx, query)
 	if err != nil {
-		return err
+		return 0, err
 	}
-	_, err = stmt.ExecContext(ctx, binds...)
+	defer stmt.Close()
+	res, err := stmt.ExecContext(ctx, binds...)
 	if err != nil {
-		return err
+		return 0, err
 	}
-	return nil
+	return res.RowsAffected()
 }
 `))
 
 	_ = template.Must(repositoryTemplate.New("repository-misc").Funcs(funcMap).Parse(`
+// sqlite{{.GetName}}Updated returns an error wrapping crudruntime.ErrNotFound when no {{.GetName}} was affected, i.e.
+// {{toLowerCamel .GetName}} does not exist{{if .HasDeletedAt}} or is soft deleted{{end}}
+{{- if .VersionCol}}, or crudruntime.ErrVersionConflict when its version no longer matches{{end}}
+func sqlite{{.GetName}}Updated(ctx context.Context, tx crudruntime.Querier, affected int64, {{toLowerCamel .GetName}} *{{.GoType .File.GoPkg.Path}}) error {
+	if affected > 0 {
+		return nil
+	}
+	{{- if .VersionCol}}
+	exists, err := sqlite{{.GetName}}Exists(ctx, tx, {{toLowerCamel .GetName}})
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("%w: {{.GetName}} %+v version %d", crudruntime.ErrVersionConflict, {{.GetName}}KeyOf({{toLowerCamel .GetName}}), {{toLowerCamel .GetName}}.{{protoFieldAccessor .VersionCol}})
+	}
+	{{- end}}
+	return fmt.Errorf("%w: {{.GetName}} %+v", crudruntime.ErrNotFound, {{.GetName}}KeyOf({{toLowerCamel .GetName}}))
+}
+
+// sqlite{{.GetName}}Exists reports whether a {{.GetName}} with the key of {{toLowerCamel .GetName}} exists
+{{- if .HasDeletedAt}} and is not soft deleted{{end}}
+func sqlite{{.GetName}}Exists(ctx context.Context, tx crudruntime.Querier, {{toLowerCamel .GetName}} *{{.GoType .File.GoPkg.Path}}) (bool, error) {
+	var exists bool
+	err := tx.QueryRowContext(
+		ctx,
+		` + "`" + `SELECT EXISTS (SELECT 1 FROM {{sqlQuotedIdent .GetName}} WHERE {{ range $i, $col := .PrimaryKeyCols -}}
+		{{if $i}} AND {{end}}{{sqlQuotedIdent $col.GetName}} = ?
+		{{- end }}
+		{{- if .HasDeletedAt}} AND {{sqlQuotedIdent .DeletedAtCol.GetName}} IS NULL{{end}})` + "`" + `,
+		{{ range $i, $col := .PrimaryKeyCols -}}
+		{{protoFieldValue (toLowerCamel $.GetName) $col}},
+		{{ end -}}
+	).Scan(&exists)
+	return exists, err
+}
+{{- if or .HasDeletedAt .VersionCol}}
+
+// sqlite{{.GetName}}Upserted returns an error when no {{.GetName}} was written although {{toLowerCamel .GetName}} conflicts
+// with one, wrapping crudruntime.ErrNotFound when that {{.GetName}} is soft deleted
+{{- if .VersionCol}} or crudruntime.ErrVersionConflict when its version no longer matches{{end}}
+func sqlite{{.GetName}}Upserted(ctx context.Context, tx crudruntime.Querier, affected int64, {{toLowerCamel .GetName}} *{{.GoType .File.GoPkg.Path}}) error {
+	{{- if .VersionCol}}
+	return sqlite{{.GetName}}Updated(ctx, tx, affected, {{toLowerCamel .GetName}})
+	{{- else}}
+	if affected > 0 {
+		return nil
+	}
+	exists, err := sqlite{{.GetName}}Exists(ctx, tx, {{toLowerCamel .GetName}})
+	if err != nil {
+		return err
+	}
+	// an existing {{.GetName}} with nothing to update is not written either
+	if exists {
+		return nil
+	}
+	return fmt.Errorf("%w: {{.GetName}} %+v", crudruntime.ErrNotFound, {{.GetName}}KeyOf({{toLowerCamel .GetName}}))
+	{{- end}}
+}
+{{- end}}
+
+// sqlite{{.GetName}}Returned overwrites the {{.GetName}}s written with the columns of fields returned by rows, all
+// queryable columns when fields is nil, and returns the number of rows
+func sqlite{{.GetName}}Returned(rows *sql.Rows, fields []expressions.ID, written []*{{.GoType .File.GoPkg.Path}}) (int64, error) {
+	defer rows.Close()
+	if fields == nil {
+		fields = []expressions.ID{
+		{{- range $col := .QueryableCols}}
+			{{fieldIDConstantName $col.QueryableField}},
+		{{- end}}
+		}
+	}
+	var byKey map[{{.GetName}}Key]*{{.GoType .File.GoPkg.Path}}
+	if len(written) > 1 {
+		byKey = make(map[{{.GetName}}Key]*{{.GoType .File.GoPkg.Path}}, len(written))
+		for _, {{toLowerCamel .GetName}} := range written {
+			byKey[{{.GetName}}KeyOf({{toLowerCamel .GetName}})] = {{toLowerCamel .GetName}}
+		}
+	}
+	var count int64
+	for rows.Next() {
+		returned, err := sqliteScan{{.GetName}}Fields(rows, fields)
+		if err != nil {
+			return 0, err
+		}
+		// a single {{.GetName}} is matched regardless of its key, which may have been generated by the database
+		{{toLowerCamel .GetName}} := written[0]
+		if byKey != nil {
+			var ok bool
+			{{toLowerCamel .GetName}}, ok = byKey[{{.GetName}}KeyOf(returned)]
+			if !ok {
+				return 0, fmt.Errorf("unexpected {{.GetName}} returned: %+v", {{.GetName}}KeyOf(returned))
+			}
+		}
+		for _, field := range fields {
+			sqlite{{.GetName}}CopyField({{toLowerCamel .GetName}}, returned, field)
+		}
+		count++
+	}
+	return count, rows.Err()
+}
+
+// sqlite{{.GetName}}CopyField copies the value of field from src to dst
+func sqlite{{.GetName}}CopyField(dst, src *{{.GoType .File.GoPkg.Path}}, field expressions.ID) {
+	switch field {
+	{{- range $col := .QueryableCols}}
+	case {{fieldIDConstantName $col.QueryableField}}:
+		{{- if $col.IsInlined}}
+		if dst.Get{{camelIdentifier $col.Parent.GetName}}() == nil {
+			dst.Set{{camelIdentifier $col.Parent.GetName}}(&{{$col.Parent.FieldMessage.GoType $.File.GoPkg.Path}}{})
+		}
+		dst.Get{{camelIdentifier $col.Parent.GetName}}().{{camelIdentifier $col.Field.GetName}} = src.{{protoFieldAccessor $col}}
+		{{- else if $col.IsOptional}}
+		if src.Has{{protoFieldField $col}}() {
+			dst.Set{{protoFieldField $col}}(src.Get{{protoFieldField $col}}())
+		} else {
+			dst.Clear{{protoFieldField $col}}()
+		}
+		{{- else}}
+		dst.Set{{protoFieldField $col}}(src.Get{{protoFieldField $col}}())
+		{{- end}}
+	{{- end}}
+	}
+}
+{{- if .HasFieldMask}}
+
+// sqlite{{.GetName}}WrittenFields returns the fields read back after writing the columns of valuesByColName, the primary
+// key{{if .VersionCol}} and version{{end}} are always read
+func sqlite{{.GetName}}WrittenFields(valuesByColName map[string]any) []expressions.ID {
+	fields := []expressions.ID{
+	{{- range $col := .PrimaryKeyCols}}
+		{{fieldIDConstantName $col.QueryableField}},
+	{{- end}}
+	{{- if .VersionCol}}
+		{{fieldIDConstantName .VersionCol.QueryableField}},
+	{{- end}}
+	}
+	{{- range $col := .UpdateCols}}
+	if _, ok := valuesByColName["{{sqlIdent $col.GetName}}"]; ok {
+		fields = append(fields, {{fieldIDConstantName $col.QueryableField}})
+	}
+	{{- end}}
+	return fields
+}
+
+// sqlite{{.GetName}}Returning returns the RETURNING clause reading back the columns of fields
+func sqlite{{.GetName}}Returning(fields []expressions.ID) string {
+	columns := make([]string, 0, len(fields))
+	for _, field := range fields {
+		columns = append(columns, fmt.Sprintf("\"%s\"", sqlite{{.GetName}}ColumnNameByFieldID[field]))
+	}
+	return " RETURNING " + strings.Join(columns, ",")
+}
+{{- end}}
+
 var sqlite{{.GetName}}ColumnNameByFieldID = map[expressions.ID]string{
 {{- range $col := .QueryableCols}}
 	{{fieldIDConstantName $col.QueryableField}}: "{{sqlIdent $col.GetName}}",
 {{- end}}
+{{- if .HasDeletedAt}}
+	{{fieldIDConstantName .DeletedAtCol.QueryableField}}: "{{sqlIdent .DeletedAtCol.GetName}}",
+{{- end}}
+}
+
+func sqlite{{.GetName}}OrderByClause(orderBy []{{.GetName}}OrderBy) (string, error) {
+	clauses := make([]string, 0, len(orderBy))
+	for _, by := range orderBy {
+		colName, ok := sqlite{{.GetName}}ColumnNameByFieldID[by.Field]
+		if !ok {
+			return "", fmt.Errorf("missing meta-data: field id: %s", by.Field)
+		}
+		direction := "ASC"
+		if by.Descending {
+			direction = "DESC"
+		}
+		clauses = append(clauses, fmt.Sprintf(` + "`" + `{{sqlQuotedIdent .GetName}}."%s" %s` + "`" + `, colName, direction))
+	}
+	return strings.Join(clauses, ", "), nil
+}
+
+// sqlite{{.GetName}}KeysetClause matches the {{.GetName}}s ordered after the given values of orderBy, SQLite orders NULL
+// before every value ascending and after every value descending
+func sqlite{{.GetName}}KeysetClause(orderBy []{{.GetName}}OrderBy, values []json.RawMessage) (string, []any, error) {
+	decoded := make([]any, 0, len(values))
+	for i, by := range orderBy {
+		value, err := sqlite{{.GetName}}KeysetValue(by.Field, values[i])
+		if err != nil {
+			return "", nil, err
+		}
+		decoded = append(decoded, value)
+	}
+	var binds []any
+	disjuncts := make([]string, 0, len(orderBy))
+	for i := range orderBy {
+		conjuncts := make([]string, 0, i + 1)
+		var conjunctBinds []any
+		for j, by := range orderBy[:i + 1] {
+			colName, ok := sqlite{{.GetName}}ColumnNameByFieldID[by.Field]
+			if !ok {
+				return "", nil, fmt.Errorf("missing meta-data: field id: %s", by.Field)
+			}
+			column := fmt.Sprintf(` + "`" + `{{sqlQuotedIdent .GetName}}."%s"` + "`" + `, colName)
+			nullsLast := by.Descending
+			switch {
+			case j < i && decoded[j] == nil:
+				conjuncts = append(conjuncts, column + " IS NULL")
+			case j < i:
+				conjuncts = append(conjuncts, column + " = ?")
+				conjunctBinds = append(conjunctBinds, decoded[j])
+			case decoded[j] == nil && nullsLast:
+				// nothing is ordered after NULL
+				conjuncts = nil
+			case decoded[j] == nil:
+				conjuncts = append(conjuncts, column + " IS NOT NULL")
+			default:
+				operator := ">"
+				if by.Descending {
+					operator = "<"
+				}
+				after := column + " " + operator + " ?"
+				conjunctBinds = append(conjunctBinds, decoded[j])
+				if sqlite{{$.GetName}}NullableFieldIDs[by.Field] && nullsLast {
+					after = "(" + after + " OR " + column + " IS NULL)"
+				}
+				conjuncts = append(conjuncts, after)
+			}
+		}
+		if conjuncts == nil {
+			continue
+		}
+		disjuncts = append(disjuncts, "(" + strings.Join(conjuncts, " AND ") + ")")
+		binds = append(binds, conjunctBinds...)
+	}
+	if len(disjuncts) == 0 {
+		return "1 = 0", nil, nil
+	}
+	return "(" + strings.Join(disjuncts, " OR ") + ")", binds, nil
+}
+
+// sqlite{{.GetName}}KeysetValues returns the values of the orderBy fields of a {{.GetName}} encoded for a page token,
+// unset fields which track presence are encoded as null
+func sqlite{{.GetName}}KeysetValues(orderBy []{{.GetName}}OrderBy, def *{{.GoType .File.GoPkg.Path}}) ([]json.RawMessage, error) {
+	values := make([]json.RawMessage, 0, len(orderBy))
+	for _, by := range orderBy {
+		var value any
+		switch by.Field {
+		{{- range $col := .QueryableCols}}
+		case {{fieldIDConstantName $col.QueryableField}}:
+			value = {{protoFieldValue "def" $col}}
+		{{- end}}
+		default:
+			return nil, fmt.Errorf("unsupported order by field id: %s", by.Field)
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, encoded)
+	}
+	return values, nil
+}
+
+// sqlite{{.GetName}}KeysetValue decodes a page token value of the field into its bind type, nil when the field was unset
+func sqlite{{.GetName}}KeysetValue(fieldID expressions.ID, encoded json.RawMessage) (any, error) {
+	switch fieldID {
+	{{- range $col := .QueryableCols}}
+	case {{fieldIDConstantName $col.QueryableField}}:
+		{{- if $col.HasPresence}}
+		if string(encoded) == "null" {
+			return nil, nil
+		}
+		{{- end}}
+		value := (&{{$.GoType $.File.GoPkg.Path}}{}).{{protoFieldAccessor $col}}
+		if err := json.Unmarshal(encoded, &value); err != nil {
+			return nil, fmt.Errorf("invalid page token: %w", err)
+		}
+		return value, nil
+	{{- end}}
+	default:
+		return nil, fmt.Errorf("unsupported order by field id: %s", fieldID)
+	}
+}
+
+// sqlite{{.GetName}}NullableFieldIDs are the fields whose columns are nullable, mapped to whether the field tracks
+// presence, NULL being read as the zero value of fields which do not
+var sqlite{{.GetName}}NullableFieldIDs = map[expressions.ID]bool{
+{{- range $col := .QueryableCols}}
+{{- if or $col.HasPresence $col.Field.Nullable}}
+	{{fieldIDConstantName $col.QueryableField}}: {{$col.HasPresence}},
+{{- end}}
+{{- end}}
 }
 
 func whereClauseFromExpressionForSQLite{{.GetName}}(expr expressions.Expression) (string, []any, error) {
@@ -506,7 +2069,7 @@ func whereClauseFromExpressionForSQLite{{.GetName}}(expr expressions.Expression)
 			if err != nil {
 				return "", nil, err
 			}
-			return fmt.Sprintf("%s AND %s", left, right), append(leftBinds, rightBinds...), nil
+			return fmt.Sprintf("(%s AND %s)", left, right), append(leftBinds, rightBinds...), nil
 
 		case *expressions.Or:
 				left, leftBinds, err := whereClauseFromExpressionForSQLite{{.GetName}}(expr.Left())
@@ -517,13 +2080,13 @@ func whereClauseFromExpressionForSQLite{{.GetName}}(expr expressions.Expression)
 			if err != nil {
 				return "", nil, err
 			}
-			return fmt.Sprintf("%s OR %s", left, right), append(leftBinds, rightBinds...), nil
+			return fmt.Sprintf("(%s OR %s)", left, right), append(leftBinds, rightBinds...), nil
 		case *expressions.Not:
 			operand, binds, err := whereClauseFromExpressionForSQLite{{.GetName}}(expr.Operand())
 			if err != nil {
 				return "", nil, err
 			}
-			return fmt.Sprintf("NOT %s", operand), binds, nil
+			return fmt.Sprintf("NOT (%s)", operand), binds, nil
 
 		case *expressions.Equals:
 				left, leftBinds, err := whereClauseFromExpressionForSQLite{{.GetName}}(expr.Left())
@@ -536,6 +2099,79 @@ func whereClauseFromExpressionForSQLite{{.GetName}}(expr expressions.Expression)
 			}
 			return fmt.Sprintf("%s = %s", left, right), append(leftBinds, rightBinds...), nil
 
+		{{- range $op := binaryOperators}}
+		case {{$op.Type}}:
+			left, leftBinds, err := whereClauseFromExpressionForSQLite{{$.GetName}}(expr.Left())
+			if err != nil {
+				return "", nil, err
+			}
+			right, rightBinds, err := whereClauseFromExpressionForSQLite{{$.GetName}}(expr.Right())
+			if err != nil {
+				return "", nil, err
+			}
+			return fmt.Sprintf("%s {{$op.Operator}} %s", left, right), append(leftBinds, rightBinds...), nil
+		{{end}}
+		case *crudexpressions.ILike:
+			left, leftBinds, err := whereClauseFromExpressionForSQLite{{.GetName}}(expr.Left())
+			if err != nil {
+				return "", nil, err
+			}
+			right, rightBinds, err := whereClauseFromExpressionForSQLite{{.GetName}}(expr.Right())
+			if err != nil {
+				return "", nil, err
+			}
+			return fmt.Sprintf("lower(%s) LIKE lower(%s)", left, right), append(leftBinds, rightBinds...), nil
+
+		case *crudexpressions.Between:
+			operand, binds, err := whereClauseFromExpressionForSQLite{{.GetName}}(expr.Operand())
+			if err != nil {
+				return "", nil, err
+			}
+			low, lowBinds, err := whereClauseFromExpressionForSQLite{{.GetName}}(expr.Low())
+			if err != nil {
+				return "", nil, err
+			}
+			binds = append(binds, lowBinds...)
+			high, highBinds, err := whereClauseFromExpressionForSQLite{{.GetName}}(expr.High())
+			if err != nil {
+				return "", nil, err
+			}
+			return fmt.Sprintf("%s BETWEEN %s AND %s", operand, low, high), append(binds, highBinds...), nil
+
+		case *crudexpressions.In:
+			if len(expr.Values()) == 0 {
+				// nothing is a member of the empty set
+				return "1 = 0", nil, nil
+			}
+			operand, binds, err := whereClauseFromExpressionForSQLite{{.GetName}}(expr.Operand())
+			if err != nil {
+				return "", nil, err
+			}
+			values := make([]string, 0, len(expr.Values()))
+			for _, value := range expr.Values() {
+				valueClause, valueBinds, err := whereClauseFromExpressionForSQLite{{.GetName}}(value)
+				if err != nil {
+					return "", nil, err
+				}
+				values = append(values, valueClause)
+				binds = append(binds, valueBinds...)
+			}
+			return fmt.Sprintf("%s IN (%s)", operand, strings.Join(values, ", ")), binds, nil
+
+		case *crudexpressions.IsNull:
+			operand, binds, err := whereClauseFromExpressionForSQLite{{.GetName}}(expr.Operand())
+			if err != nil {
+				return "", nil, err
+			}
+			return fmt.Sprintf("%s IS NULL", operand), binds, nil
+
+		case *crudexpressions.IsNotNull:
+			operand, binds, err := whereClauseFromExpressionForSQLite{{.GetName}}(expr.Operand())
+			if err != nil {
+				return "", nil, err
+			}
+			return fmt.Sprintf("%s IS NOT NULL", operand), binds, nil
+
 		case *expressions.Identifier:
 			if _, ok := valid{{.GetName}}Fields[expr.ID()]; !ok {
 				return "", nil, fmt.Errorf("invalid field id: %s", expr.ID())
@@ -548,7 +2184,7 @@ func whereClauseFromExpressionForSQLite{{.GetName}}(expr expressions.Expression)
 		case *expressions.Scalar:
 			return "?", []any{expr.Value()}, nil
 		case expressions.Timestamp:
-			return "?", []any{time.Time(expr).Format(time.RFC3339)}, nil
+			return "?", []any{time.Time(expr).UTC().Format(time.RFC3339)}, nil
 		default:
 			return "", nil, fmt.Errorf("unknown expression")
 	}
@@ -563,16 +2199,24 @@ func sqlite{{.GetName}}GetCreateValuesByColumnName(def *{{.GoType .File.GoPkg.Pa
 	valuesByColumnName := make(map[string]any, 0)
 	nestedMask := fmutils.NestedMaskFromPaths(fieldMask.Paths)
 	{{ range $i, $col := .PrimaryKeyCols -}}
+	{{if $col.IsSequential -}}
+	if def.{{protoFieldAccessor $col}} != 0 {
+		valuesByColumnName["{{sqlIdent $col.Field.GetName}}"] = {{protoFieldValue "def" $col}}
+	}
+	{{else -}}
+	{{if not $col.IsAutoGenerated -}}
 	if _, ok := nestedMask["{{$col.Field.GetName}}"]; !ok {
 		return nil, fmt.Errorf("primary key field excluded by field mask: {{$col.Field.GetName}}")
 	}
-	valuesByColumnName["{{sqlIdent $col.Field.GetName}}"] = def.{{protoFieldAccessor $col}}
+	{{end -}}
+	valuesByColumnName["{{sqlIdent $col.Field.GetName}}"] = {{protoFieldValue "def" $col}}
+	{{end -}}
 	{{end -}}
 	{{ range $i, $col := .NonPrimeAttributeCols -}}
 	if _, ok := nestedMask["{{$col.Field.GetName}}"]; ok {
-		valuesByColumnName["{{sqlIdent $col.Field.GetName}}"] = def.{{protoFieldAccessor $col}}
+		valuesByColumnName["{{sqlIdent $col.Field.GetName}}"] = {{protoFieldValue "def" $col}}
 	} else {
-		valuesByColumnName["{{sqlIdent $col.Field.GetName}}"] = {{toLowerCamel $.GetName}}.{{protoFieldAccessor $col}}
+		valuesByColumnName["{{sqlIdent $col.Field.GetName}}"] = {{protoFieldValue (toLowerCamel $.GetName) $col}}
 	}
 	{{end -}}
 	return valuesByColumnName, nil
@@ -590,7 +2234,7 @@ func sqlite{{.GetName}}GetUpdateValuesByColumnName(def *{{.GoType .File.GoPkg.Pa
 	{{end -}}
 	{{ range $i, $col := .NonPrimeAttributeCols -}}
 	if _, ok := nestedMask["{{$col.Field.GetName}}"]; ok {
-		valuesByColumnName["{{sqlIdent $col.GetName}}"] = def.{{protoFieldAccessor $col}}
+		valuesByColumnName["{{sqlIdent $col.GetName}}"] = {{protoFieldValue "def" $col}}
 	}
 	{{end -}}
 	return valuesByColumnName, nil
@@ -5,6 +5,8 @@ import (
 
 	"github.com/samlitowitz/protoc-gen-crud/internal/generator/crud"
 
+	"github.com/samlitowitz/protoc-gen-crud/options"
+
 	"github.com/iancoleman/strcase"
 	"google.golang.org/protobuf/types/descriptorpb"
 )
@@ -41,7 +43,7 @@ func (col *Column) GetComment() string {
 	if col.AsTimestamp {
 		return " /* stored as RFC3339 string */"
 	}
-	switch col.Field.GetType() {
+	switch col.Field.ValueType() {
 	case descriptorpb.FieldDescriptorProto_TYPE_DOUBLE:
 		fallthrough
 	case descriptorpb.FieldDescriptorProto_TYPE_FLOAT:
@@ -56,25 +58,24 @@ func (col *Column) GetComment() string {
 		fallthrough
 	case descriptorpb.FieldDescriptorProto_TYPE_FIXED32:
 		fallthrough
+	case descriptorpb.FieldDescriptorProto_TYPE_SFIXED32:
+		fallthrough
 	case descriptorpb.FieldDescriptorProto_TYPE_SINT32:
 		fallthrough
 	case descriptorpb.FieldDescriptorProto_TYPE_INT64:
 		fallthrough
 	case descriptorpb.FieldDescriptorProto_TYPE_FIXED64:
 		fallthrough
+	case descriptorpb.FieldDescriptorProto_TYPE_SFIXED64:
+		fallthrough
 	case descriptorpb.FieldDescriptorProto_TYPE_SINT64:
 		fallthrough
 	case descriptorpb.FieldDescriptorProto_TYPE_BYTES:
 		fallthrough
 	case descriptorpb.FieldDescriptorProto_TYPE_STRING:
-		return ""
-
+		fallthrough
 	case descriptorpb.FieldDescriptorProto_TYPE_ENUM:
-		return fmt.Sprintf(
-			" /* references %s.%s */",
-			QuotedIdent(col.FieldEnum.GetName()),
-			QuotedIdent("id"),
-		)
+		return ""
 
 	case descriptorpb.FieldDescriptorProto_TYPE_MESSAGE:
 		fallthrough
@@ -85,11 +86,49 @@ func (col *Column) GetComment() string {
 	}
 }
 
+// GetReferences returns the REFERENCES clause of the column, enum columns reference the id of their look-up table
+func (col *Column) GetReferences() string {
+	if col.Field.GetType() != descriptorpb.FieldDescriptorProto_TYPE_ENUM {
+		return ""
+	}
+	return fmt.Sprintf(
+		" REFERENCES %s (%s)%s",
+		QuotedIdent(col.FieldEnum.GetName()),
+		QuotedIdent("id"),
+		ReferentialActions(col.OnDelete, col.OnUpdate),
+	)
+}
+
+// ReferentialActions returns the ON DELETE and ON UPDATE clauses of a reference, unspecified actions are omitted
+func ReferentialActions(onDelete, onUpdate options.ReferentialAction) string {
+	var clauses string
+	if action := referentialAction(onDelete); action != "" {
+		clauses += " ON DELETE " + action
+	}
+	if action := referentialAction(onUpdate); action != "" {
+		clauses += " ON UPDATE " + action
+	}
+	return clauses
+}
+
+func referentialAction(action options.ReferentialAction) string {
+	switch action {
+	case options.ReferentialAction_REFERENTIAL_ACTION_CASCADE:
+		return "CASCADE"
+	case options.ReferentialAction_REFERENTIAL_ACTION_RESTRICT:
+		return "RESTRICT"
+	case options.ReferentialAction_REFERENTIAL_ACTION_SET_NULL:
+		return "SET NULL"
+	default:
+		return ""
+	}
+}
+
 func (col *Column) GetType() string {
 	if col.AsTimestamp {
 		return "TEXT"
 	}
-	switch col.Field.GetType() {
+	switch col.Field.ValueType() {
 	case descriptorpb.FieldDescriptorProto_TYPE_DOUBLE:
 		fallthrough
 	case descriptorpb.FieldDescriptorProto_TYPE_FLOAT:
@@ -106,12 +145,16 @@ func (col *Column) GetType() string {
 		fallthrough
 	case descriptorpb.FieldDescriptorProto_TYPE_FIXED32:
 		fallthrough
+	case descriptorpb.FieldDescriptorProto_TYPE_SFIXED32:
+		fallthrough
 	case descriptorpb.FieldDescriptorProto_TYPE_SINT32:
 		fallthrough
 	case descriptorpb.FieldDescriptorProto_TYPE_INT64:
 		fallthrough
 	case descriptorpb.FieldDescriptorProto_TYPE_FIXED64:
 		fallthrough
+	case descriptorpb.FieldDescriptorProto_TYPE_SFIXED64:
+		fallthrough
 	case descriptorpb.FieldDescriptorProto_TYPE_SINT64:
 		return "INTEGER"
 
@@ -35,10 +35,11 @@ func applyTemplate(p param, reg *descriptor.Registry) (string, error) {
 	completedEnums := make(map[string]struct{})
 
 	w := bytes.NewBuffer(nil)
+	hasReferences := false
 
 	//return "", fmt.Errorf("%v", p.Messages)
 
-	for _, msg := range p.Messages {
+	for _, msg := range p.MessagesInReferenceOrder() {
 		if !msg.GenerateCRUD {
 			continue
 		}
@@ -53,6 +54,7 @@ func applyTemplate(p param, reg *descriptor.Registry) (string, error) {
 			if field.FieldEnum == nil {
 				return "", fmt.Errorf("%s: missing enum definition", field.GetName())
 			}
+			hasReferences = true
 			if _, ok := completedEnums[field.FieldEnum.FQEN()]; ok {
 				continue
 			}
@@ -60,6 +62,11 @@ func applyTemplate(p param, reg *descriptor.Registry) (string, error) {
 			if err := createTableForEnumTemplate.Execute(w, &enum{field.FieldEnum}); err != nil {
 				return "", fmt.Errorf("%s: %s: create enum table: %v", field.GetName(), field.FieldEnum.GetName(), err)
 			}
+			completedEnums[field.FieldEnum.FQEN()] = struct{}{}
+		}
+
+		if len(msg.ForeignKeys) > 0 {
+			hasReferences = true
 		}
 
 		injected := &message{
@@ -71,12 +78,21 @@ func applyTemplate(p param, reg *descriptor.Registry) (string, error) {
 			return "", fmt.Errorf("%s: create message table: %v", msg.GetName(), err)
 		}
 	}
-	return w.String(), nil
+	if !hasReferences {
+		return w.String(), nil
+	}
+
+	header := bytes.NewBuffer(nil)
+	if err := enableForeignKeysTemplate.Execute(header, p); err != nil {
+		return "", fmt.Errorf("enable foreign keys: %v", err)
+	}
+	return header.String() + w.String(), nil
 }
 
 var (
 	funcMap template.FuncMap = map[string]interface{}{
-		"quotedIdent": sqlite.QuotedIdent,
+		"quotedIdent":        sqlite.QuotedIdent,
+		"referentialActions": sqlite.ReferentialActions,
 	}
 
 	// https://www.sqlite.org/lang_createtable.html
@@ -93,7 +109,7 @@ CREATE TABLE IF NOT EXISTS {{quotedIdent .GetName}} (
     {{- if $i}},{{end}}
     {{template "column-definition" $col}}
 {{- end }}
-{{- if gt (len .PrimaryKey) 0 -}}
+{{- if and (gt (len .PrimaryKey) 0) (not .HasSequentialKey) -}}
         ,
 
     PRIMARY KEY (
@@ -103,15 +119,29 @@ CREATE TABLE IF NOT EXISTS {{quotedIdent .GetName}} (
     {{- end}}
     )
     {{- end}}
+    {{- range $fk := .ForeignKeys -}}
+        ,
+
+    FOREIGN KEY (
+    {{- range $i, $field := $fk.Fields -}}
+        {{- if $i}}, {{end}}{{quotedIdent $field.GetName}}
+    {{- end -}}
+    ) REFERENCES {{quotedIdent $fk.References.GetName}} (
+    {{- range $i, $field := $fk.References.PrimaryKey -}}
+        {{- if $i}}, {{end}}{{quotedIdent $field.GetName}}
+    {{- end -}}
+    ){{referentialActions $fk.GetOnDelete $fk.GetOnUpdate}}
+    {{- end}}
 );
 `))
 
 	_ = template.Must(createTableForMessageTemplate.New("column-definition").Funcs(funcMap).Parse(`
-    {{- quotedIdent .GetName}} {{.GetType}}{{.GetComment -}}
+    {{- quotedIdent .GetName}} {{.GetType}}{{if .IsSequential}} PRIMARY KEY AUTOINCREMENT{{end}}{{if .IsVersion}} NOT NULL DEFAULT 0{{end}}{{if and .IsCreatedOrUpdatedAt .Message.UsesDatabaseClock}} NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%SZ', 'now')){{end}}{{if .NotNull}} NOT NULL{{end}}{{with .Default}} DEFAULT ({{.}}){{end}}{{with .Check}} CHECK ({{.}}){{end}}{{.GetReferences}}{{.GetComment -}}
 `))
 
+	// enum look-up tables are referenced by the tables of other messages, possibly in other files, so they are upserted
+	// rather than dropped and recreated
 	createTableForEnumTemplate = template.Must(template.New("create-table-for-enum").Funcs(funcMap).Parse(`
-DROP TABLE IF EXISTS {{quotedIdent .GetName}};
 CREATE TABLE IF NOT EXISTS {{quotedIdent .GetName}} (
     "id" INTEGER PRIMARY KEY,
     "value" TEXT
@@ -122,6 +152,15 @@ INSERT INTO {{quotedIdent .GetName}} ("id", "value") VALUES
     {{- if $i}},{{end}}
     ({{$valDesc.GetNumber}}, "{{$valDesc.GetName}}")
 {{- end}}
+ON CONFLICT ("id") DO UPDATE SET "value" = excluded."value"
 ;
+`))
+
+	// https://www.sqlite.org/foreignkeys.html#fk_enable
+	enableForeignKeysTemplate = template.Must(template.New("enable-foreign-keys").Parse(`
+-- SQLite only enforces foreign keys on connections which enable them, the statement below only enables them on the
+-- connection executing it, e.g. enable them on every connection by opening the database with
+-- "file:app.db?_pragma=foreign_keys(1)" using modernc.org/sqlite or "file:app.db?_foreign_keys=on" using mattn/go-sqlite3
+PRAGMA foreign_keys = ON;
 `))
 )
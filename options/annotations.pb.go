@@ -53,6 +53,14 @@ var file_protoc_gen_crud_options_annotations_proto_extTypes = []protoimpl.Extens
 		Tag:           "bytes,65535,opt,name=crud_service_options",
 		Filename:      "protoc-gen-crud/options/annotations.proto",
 	},
+	{
+		ExtendedType:  (*descriptorpb.EnumOptions)(nil),
+		ExtensionType: (*EnumOptions)(nil),
+		Field:         65535,
+		Name:          "protoc_gen_crud.options.crud_enum_options",
+		Tag:           "bytes,65535,opt,name=crud_enum_options",
+		Filename:      "protoc-gen-crud/options/annotations.proto",
+	},
 	{
 		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
 		ExtensionType: (*FieldOptions)(nil),
@@ -99,13 +107,22 @@ var (
 	E_CrudServiceOptions = &file_protoc_gen_crud_options_annotations_proto_extTypes[3]
 )
 
+// Extension fields to descriptorpb.EnumOptions.
+var (
+	// All IDs are the same, as assigned. It is okay that they are the same, as they extend
+	// different descriptor messages.
+	//
+	// optional protoc_gen_crud.options.EnumOptions crud_enum_options = 65535;
+	E_CrudEnumOptions = &file_protoc_gen_crud_options_annotations_proto_extTypes[4]
+)
+
 // Extension fields to descriptorpb.FieldOptions.
 var (
 	// All IDs are the same, as assigned. It is okay that they are the same, as they extend
 	// different descriptor messages.
 	//
 	// optional protoc_gen_crud.options.FieldOptions crud_field_options = 65535;
-	E_CrudFieldOptions = &file_protoc_gen_crud_options_annotations_proto_extTypes[4]
+	E_CrudFieldOptions = &file_protoc_gen_crud_options_annotations_proto_extTypes[5]
 )
 
 var File_protoc_gen_crud_options_annotations_proto protoreflect.FileDescriptor
@@ -150,18 +167,25 @@ var file_protoc_gen_crud_options_annotations_proto_rawDesc = []byte{
 	0x65, 0x6e, 0x5f, 0x63, 0x72, 0x75, 0x64, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e,
 	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x12,
 	0x63, 0x72, 0x75, 0x64, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f,
-	0x6e, 0x73, 0x3a, 0x74, 0x0a, 0x12, 0x63, 0x72, 0x75, 0x64, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64,
-	0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
-	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64,
-	0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xff, 0xff, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x25, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x5f, 0x67, 0x65, 0x6e, 0x5f, 0x63, 0x72, 0x75,
-	0x64, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f,
-	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x10, 0x63, 0x72, 0x75, 0x64, 0x46, 0x69, 0x65, 0x6c,
-	0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x42, 0x30, 0x5a, 0x2e, 0x67, 0x69, 0x74, 0x68,
-	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x61, 0x6d, 0x6c, 0x69, 0x74, 0x6f, 0x77, 0x69,
-	0x74, 0x7a, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x2d, 0x67, 0x65, 0x6e, 0x2d, 0x63, 0x72,
-	0x75, 0x64, 0x2f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x33,
+	0x6e, 0x73, 0x3a, 0x70, 0x0a, 0x11, 0x63, 0x72, 0x75, 0x64, 0x5f, 0x65, 0x6e, 0x75, 0x6d, 0x5f,
+	0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6e, 0x75, 0x6d, 0x4f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xff, 0xff, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x5f, 0x67, 0x65, 0x6e, 0x5f, 0x63, 0x72, 0x75, 0x64, 0x2e,
+	0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x45, 0x6e, 0x75, 0x6d, 0x4f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x52, 0x0f, 0x63, 0x72, 0x75, 0x64, 0x45, 0x6e, 0x75, 0x6d, 0x4f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x3a, 0x74, 0x0a, 0x12, 0x63, 0x72, 0x75, 0x64, 0x5f, 0x66, 0x69, 0x65,
+	0x6c, 0x64, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1d, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65,
+	0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xff, 0xff, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x25, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x5f, 0x67, 0x65, 0x6e, 0x5f, 0x63,
+	0x72, 0x75, 0x64, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x46, 0x69, 0x65, 0x6c,
+	0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x10, 0x63, 0x72, 0x75, 0x64, 0x46, 0x69,
+	0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x42, 0x30, 0x5a, 0x2e, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x61, 0x6d, 0x6c, 0x69, 0x74, 0x6f,
+	0x77, 0x69, 0x74, 0x7a, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x2d, 0x67, 0x65, 0x6e, 0x2d,
+	0x63, 0x72, 0x75, 0x64, 0x2f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
 }
 
 var file_protoc_gen_crud_options_annotations_proto_goTypes = []any{
@@ -169,28 +193,32 @@ var file_protoc_gen_crud_options_annotations_proto_goTypes = []any{
 	(*descriptorpb.MethodOptions)(nil),  // 1: google.protobuf.MethodOptions
 	(*descriptorpb.MessageOptions)(nil), // 2: google.protobuf.MessageOptions
 	(*descriptorpb.ServiceOptions)(nil), // 3: google.protobuf.ServiceOptions
-	(*descriptorpb.FieldOptions)(nil),   // 4: google.protobuf.FieldOptions
-	(*FileOptions)(nil),                 // 5: protoc_gen_crud.options.FileOptions
-	(*MethodOptions)(nil),               // 6: protoc_gen_crud.options.MethodOptions
-	(*MessageOptions)(nil),              // 7: protoc_gen_crud.options.MessageOptions
-	(*ServiceOptions)(nil),              // 8: protoc_gen_crud.options.ServiceOptions
-	(*FieldOptions)(nil),                // 9: protoc_gen_crud.options.FieldOptions
+	(*descriptorpb.EnumOptions)(nil),    // 4: google.protobuf.EnumOptions
+	(*descriptorpb.FieldOptions)(nil),   // 5: google.protobuf.FieldOptions
+	(*FileOptions)(nil),                 // 6: protoc_gen_crud.options.FileOptions
+	(*MethodOptions)(nil),               // 7: protoc_gen_crud.options.MethodOptions
+	(*MessageOptions)(nil),              // 8: protoc_gen_crud.options.MessageOptions
+	(*ServiceOptions)(nil),              // 9: protoc_gen_crud.options.ServiceOptions
+	(*EnumOptions)(nil),                 // 10: protoc_gen_crud.options.EnumOptions
+	(*FieldOptions)(nil),                // 11: protoc_gen_crud.options.FieldOptions
 }
 var file_protoc_gen_crud_options_annotations_proto_depIdxs = []int32{
 	0,  // 0: protoc_gen_crud.options.crud_file_options:extendee -> google.protobuf.FileOptions
 	1,  // 1: protoc_gen_crud.options.crud_method_options:extendee -> google.protobuf.MethodOptions
 	2,  // 2: protoc_gen_crud.options.crud_message_options:extendee -> google.protobuf.MessageOptions
 	3,  // 3: protoc_gen_crud.options.crud_service_options:extendee -> google.protobuf.ServiceOptions
-	4,  // 4: protoc_gen_crud.options.crud_field_options:extendee -> google.protobuf.FieldOptions
-	5,  // 5: protoc_gen_crud.options.crud_file_options:type_name -> protoc_gen_crud.options.FileOptions
-	6,  // 6: protoc_gen_crud.options.crud_method_options:type_name -> protoc_gen_crud.options.MethodOptions
-	7,  // 7: protoc_gen_crud.options.crud_message_options:type_name -> protoc_gen_crud.options.MessageOptions
-	8,  // 8: protoc_gen_crud.options.crud_service_options:type_name -> protoc_gen_crud.options.ServiceOptions
-	9,  // 9: protoc_gen_crud.options.crud_field_options:type_name -> protoc_gen_crud.options.FieldOptions
-	10, // [10:10] is the sub-list for method output_type
-	10, // [10:10] is the sub-list for method input_type
-	5,  // [5:10] is the sub-list for extension type_name
-	0,  // [0:5] is the sub-list for extension extendee
+	4,  // 4: protoc_gen_crud.options.crud_enum_options:extendee -> google.protobuf.EnumOptions
+	5,  // 5: protoc_gen_crud.options.crud_field_options:extendee -> google.protobuf.FieldOptions
+	6,  // 6: protoc_gen_crud.options.crud_file_options:type_name -> protoc_gen_crud.options.FileOptions
+	7,  // 7: protoc_gen_crud.options.crud_method_options:type_name -> protoc_gen_crud.options.MethodOptions
+	8,  // 8: protoc_gen_crud.options.crud_message_options:type_name -> protoc_gen_crud.options.MessageOptions
+	9,  // 9: protoc_gen_crud.options.crud_service_options:type_name -> protoc_gen_crud.options.ServiceOptions
+	10, // 10: protoc_gen_crud.options.crud_enum_options:type_name -> protoc_gen_crud.options.EnumOptions
+	11, // 11: protoc_gen_crud.options.crud_field_options:type_name -> protoc_gen_crud.options.FieldOptions
+	12, // [12:12] is the sub-list for method output_type
+	12, // [12:12] is the sub-list for method input_type
+	6,  // [6:12] is the sub-list for extension type_name
+	0,  // [0:6] is the sub-list for extension extendee
 	0,  // [0:0] is the sub-list for field type_name
 }
 
@@ -207,7 +235,7 @@ func file_protoc_gen_crud_options_annotations_proto_init() {
 			RawDescriptor: file_protoc_gen_crud_options_annotations_proto_rawDesc,
 			NumEnums:      0,
 			NumMessages:   0,
-			NumExtensions: 5,
+			NumExtensions: 6,
 			NumServices:   0,
 		},
 		GoTypes:           file_protoc_gen_crud_options_annotations_proto_goTypes,
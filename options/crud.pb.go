@@ -64,10 +64,203 @@ func (x Implementation) Number() protoreflect.EnumNumber {
 	return protoreflect.EnumNumber(x)
 }
 
+// Auto-generation strategies supported by `protoc-gen-crud`
+type AutoGenerationStrategy int32
+
+const (
+	AutoGenerationStrategy_AUTO_GENERATION_STRATEGY_UNSPECIFIED AutoGenerationStrategy = 0 // Values are never generated, they must be provided
+	AutoGenerationStrategy_AUTO_GENERATION_STRATEGY_UUID_V4     AutoGenerationStrategy = 1 // Generate a random (version 4) UUID on create, the field must be a string
+	AutoGenerationStrategy_AUTO_GENERATION_STRATEGY_UUID_V7     AutoGenerationStrategy = 2 // Generate a time-ordered (version 7) UUID on create, the field must be a string
+	AutoGenerationStrategy_AUTO_GENERATION_STRATEGY_SEQUENTIAL  AutoGenerationStrategy = 3 // Let the database assign a sequential integer on create, the field must be an integer
+)
+
+// Enum value maps for AutoGenerationStrategy.
+var (
+	AutoGenerationStrategy_name = map[int32]string{
+		0: "AUTO_GENERATION_STRATEGY_UNSPECIFIED",
+		1: "AUTO_GENERATION_STRATEGY_UUID_V4",
+		2: "AUTO_GENERATION_STRATEGY_UUID_V7",
+		3: "AUTO_GENERATION_STRATEGY_SEQUENTIAL",
+	}
+	AutoGenerationStrategy_value = map[string]int32{
+		"AUTO_GENERATION_STRATEGY_UNSPECIFIED": 0,
+		"AUTO_GENERATION_STRATEGY_UUID_V4":     1,
+		"AUTO_GENERATION_STRATEGY_UUID_V7":     2,
+		"AUTO_GENERATION_STRATEGY_SEQUENTIAL":  3,
+	}
+)
+
+func (x AutoGenerationStrategy) Enum() *AutoGenerationStrategy {
+	p := new(AutoGenerationStrategy)
+	*p = x
+	return p
+}
+
+func (x AutoGenerationStrategy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AutoGenerationStrategy) Descriptor() protoreflect.EnumDescriptor {
+	return file_protoc_gen_crud_options_crud_proto_enumTypes[1].Descriptor()
+}
+
+func (AutoGenerationStrategy) Type() protoreflect.EnumType {
+	return &file_protoc_gen_crud_options_crud_proto_enumTypes[1]
+}
+
+func (x AutoGenerationStrategy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Transaction isolation levels supported by `protoc-gen-crud`
+type IsolationLevel int32
+
+const (
+	IsolationLevel_ISOLATION_LEVEL_UNSPECIFIED      IsolationLevel = 0 // Use the default, serializable
+	IsolationLevel_ISOLATION_LEVEL_READ_UNCOMMITTED IsolationLevel = 1 // Use `sql.LevelReadUncommitted`
+	IsolationLevel_ISOLATION_LEVEL_READ_COMMITTED   IsolationLevel = 2 // Use `sql.LevelReadCommitted`
+	IsolationLevel_ISOLATION_LEVEL_REPEATABLE_READ  IsolationLevel = 3 // Use `sql.LevelRepeatableRead`
+	IsolationLevel_ISOLATION_LEVEL_SERIALIZABLE     IsolationLevel = 4 // Use `sql.LevelSerializable`
+)
+
+// Enum value maps for IsolationLevel.
+var (
+	IsolationLevel_name = map[int32]string{
+		0: "ISOLATION_LEVEL_UNSPECIFIED",
+		1: "ISOLATION_LEVEL_READ_UNCOMMITTED",
+		2: "ISOLATION_LEVEL_READ_COMMITTED",
+		3: "ISOLATION_LEVEL_REPEATABLE_READ",
+		4: "ISOLATION_LEVEL_SERIALIZABLE",
+	}
+	IsolationLevel_value = map[string]int32{
+		"ISOLATION_LEVEL_UNSPECIFIED":      0,
+		"ISOLATION_LEVEL_READ_UNCOMMITTED": 1,
+		"ISOLATION_LEVEL_READ_COMMITTED":   2,
+		"ISOLATION_LEVEL_REPEATABLE_READ":  3,
+		"ISOLATION_LEVEL_SERIALIZABLE":     4,
+	}
+)
+
+func (x IsolationLevel) Enum() *IsolationLevel {
+	p := new(IsolationLevel)
+	*p = x
+	return p
+}
+
+func (x IsolationLevel) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (IsolationLevel) Descriptor() protoreflect.EnumDescriptor {
+	return file_protoc_gen_crud_options_crud_proto_enumTypes[2].Descriptor()
+}
+
+func (IsolationLevel) Type() protoreflect.EnumType {
+	return &file_protoc_gen_crud_options_crud_proto_enumTypes[2]
+}
+
+func (x IsolationLevel) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Clocks which the created, updated and deleted at times of a message can be taken from
+type TimestampSource int32
+
+const (
+	TimestampSource_TIMESTAMP_SOURCE_UNSPECIFIED TimestampSource = 0 // Use the default, the client clock
+	TimestampSource_TIMESTAMP_SOURCE_CLIENT      TimestampSource = 1 // Use the clock of the repository, `time.Now` unless replaced when constructing it
+	TimestampSource_TIMESTAMP_SOURCE_DATABASE    TimestampSource = 2 // Use the clock of the database, `now()` or `CURRENT_TIMESTAMP`
+)
+
+// Enum value maps for TimestampSource.
+var (
+	TimestampSource_name = map[int32]string{
+		0: "TIMESTAMP_SOURCE_UNSPECIFIED",
+		1: "TIMESTAMP_SOURCE_CLIENT",
+		2: "TIMESTAMP_SOURCE_DATABASE",
+	}
+	TimestampSource_value = map[string]int32{
+		"TIMESTAMP_SOURCE_UNSPECIFIED": 0,
+		"TIMESTAMP_SOURCE_CLIENT":      1,
+		"TIMESTAMP_SOURCE_DATABASE":    2,
+	}
+)
+
+func (x TimestampSource) Enum() *TimestampSource {
+	p := new(TimestampSource)
+	*p = x
+	return p
+}
+
+func (x TimestampSource) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TimestampSource) Descriptor() protoreflect.EnumDescriptor {
+	return file_protoc_gen_crud_options_crud_proto_enumTypes[3].Descriptor()
+}
+
+func (TimestampSource) Type() protoreflect.EnumType {
+	return &file_protoc_gen_crud_options_crud_proto_enumTypes[3]
+}
+
+func (x TimestampSource) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Representations the values of an enum can be stored as
+type EnumStorage int32
+
+const (
+	EnumStorage_ENUM_STORAGE_UNSPECIFIED  EnumStorage = 0 // Use the default, an integer referencing a look-up table
+	EnumStorage_ENUM_STORAGE_LOOKUP_TABLE EnumStorage = 1 // Store the number of the value, referencing the id of a look-up table of the values
+	EnumStorage_ENUM_STORAGE_NATIVE       EnumStorage = 2 // Store the name of the value as a native enum type, PgSQL only
+	EnumStorage_ENUM_STORAGE_TEXT         EnumStorage = 3 // Store the name of the value as text, PgSQL only
+)
+
+// Enum value maps for EnumStorage.
+var (
+	EnumStorage_name = map[int32]string{
+		0: "ENUM_STORAGE_UNSPECIFIED",
+		1: "ENUM_STORAGE_LOOKUP_TABLE",
+		2: "ENUM_STORAGE_NATIVE",
+		3: "ENUM_STORAGE_TEXT",
+	}
+	EnumStorage_value = map[string]int32{
+		"ENUM_STORAGE_UNSPECIFIED":  0,
+		"ENUM_STORAGE_LOOKUP_TABLE": 1,
+		"ENUM_STORAGE_NATIVE":       2,
+		"ENUM_STORAGE_TEXT":         3,
+	}
+)
+
+func (x EnumStorage) Enum() *EnumStorage {
+	p := new(EnumStorage)
+	*p = x
+	return p
+}
+
+func (x EnumStorage) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EnumStorage) Descriptor() protoreflect.EnumDescriptor {
+	return file_protoc_gen_crud_options_crud_proto_enumTypes[4].Descriptor()
+}
+
+func (EnumStorage) Type() protoreflect.EnumType {
+	return &file_protoc_gen_crud_options_crud_proto_enumTypes[4]
+}
+
+func (x EnumStorage) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
 type FileOptions struct {
-	state         protoimpl.MessageState `protogen:"opaque.v1"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_EnumStorage EnumStorage            `protobuf:"varint,1,opt,name=enumStorage,proto3,enum=protoc_gen_crud.options.EnumStorage" json:"enumStorage,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
 }
 
 func (x *FileOptions) Reset() {
@@ -95,15 +288,90 @@ func (x *FileOptions) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
+func (x *FileOptions) GetEnumStorage() EnumStorage {
+	if x != nil {
+		return x.xxx_hidden_EnumStorage
+	}
+	return EnumStorage_ENUM_STORAGE_UNSPECIFIED
+}
+
+func (x *FileOptions) SetEnumStorage(v EnumStorage) {
+	x.xxx_hidden_EnumStorage = v
+}
+
 type FileOptions_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
+	// Sets how the enums defined in this file are stored, unless overridden by the enum.
+	// If not set enums are stored as integers referencing a look-up table.
+	// SQLite always stores enums as integers referencing a look-up table.
+	EnumStorage EnumStorage
 }
 
 func (b0 FileOptions_builder) Build() *FileOptions {
 	m0 := &FileOptions{}
 	b, x := &b0, m0
 	_, _ = b, x
+	x.xxx_hidden_EnumStorage = b.EnumStorage
+	return m0
+}
+
+type EnumOptions struct {
+	state              protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Storage EnumStorage            `protobuf:"varint,1,opt,name=storage,proto3,enum=protoc_gen_crud.options.EnumStorage" json:"storage,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *EnumOptions) Reset() {
+	*x = EnumOptions{}
+	mi := &file_protoc_gen_crud_options_crud_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnumOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnumOptions) ProtoMessage() {}
+
+func (x *EnumOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_protoc_gen_crud_options_crud_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *EnumOptions) GetStorage() EnumStorage {
+	if x != nil {
+		return x.xxx_hidden_Storage
+	}
+	return EnumStorage_ENUM_STORAGE_UNSPECIFIED
+}
+
+func (x *EnumOptions) SetStorage(v EnumStorage) {
+	x.xxx_hidden_Storage = v
+}
+
+type EnumOptions_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Sets how this enum is stored, overriding the `enumStorage` of its file.
+	// SQLite always stores enums as integers referencing a look-up table.
+	Storage EnumStorage
+}
+
+func (b0 EnumOptions_builder) Build() *EnumOptions {
+	m0 := &EnumOptions{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Storage = b.Storage
 	return m0
 }
 
@@ -115,7 +383,7 @@ type MethodOptions struct {
 
 func (x *MethodOptions) Reset() {
 	*x = MethodOptions{}
-	mi := &file_protoc_gen_crud_options_crud_proto_msgTypes[1]
+	mi := &file_protoc_gen_crud_options_crud_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -127,7 +395,7 @@ func (x *MethodOptions) String() string {
 func (*MethodOptions) ProtoMessage() {}
 
 func (x *MethodOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_protoc_gen_crud_options_crud_proto_msgTypes[1]
+	mi := &file_protoc_gen_crud_options_crud_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -157,13 +425,18 @@ type MessageOptions struct {
 	xxx_hidden_PrimaryKey      []string               `protobuf:"bytes,3,rep,name=primaryKey,proto3" json:"primaryKey,omitempty"`
 	xxx_hidden_CreatedAt       string                 `protobuf:"bytes,4,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
 	xxx_hidden_UpdatedAt       string                 `protobuf:"bytes,5,opt,name=updatedAt,proto3" json:"updatedAt,omitempty"`
+	xxx_hidden_DeletedAt       string                 `protobuf:"bytes,6,opt,name=deletedAt,proto3" json:"deletedAt,omitempty"`
+	xxx_hidden_IsolationLevel  IsolationLevel         `protobuf:"varint,7,opt,name=isolationLevel,proto3,enum=protoc_gen_crud.options.IsolationLevel" json:"isolationLevel,omitempty"`
+	xxx_hidden_Version         string                 `protobuf:"bytes,8,opt,name=version,proto3" json:"version,omitempty"`
+	xxx_hidden_TimestampSource TimestampSource        `protobuf:"varint,9,opt,name=timestampSource,proto3,enum=protoc_gen_crud.options.TimestampSource" json:"timestampSource,omitempty"`
+	xxx_hidden_ForeignKeys     *[]*ForeignKey         `protobuf:"bytes,10,rep,name=foreignKeys,proto3" json:"foreignKeys,omitempty"`
 	unknownFields              protoimpl.UnknownFields
 	sizeCache                  protoimpl.SizeCache
 }
 
 func (x *MessageOptions) Reset() {
 	*x = MessageOptions{}
-	mi := &file_protoc_gen_crud_options_crud_proto_msgTypes[2]
+	mi := &file_protoc_gen_crud_options_crud_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -175,7 +448,7 @@ func (x *MessageOptions) String() string {
 func (*MessageOptions) ProtoMessage() {}
 
 func (x *MessageOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_protoc_gen_crud_options_crud_proto_msgTypes[2]
+	mi := &file_protoc_gen_crud_options_crud_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -221,6 +494,43 @@ func (x *MessageOptions) GetUpdatedAt() string {
 	return ""
 }
 
+func (x *MessageOptions) GetDeletedAt() string {
+	if x != nil {
+		return x.xxx_hidden_DeletedAt
+	}
+	return ""
+}
+
+func (x *MessageOptions) GetIsolationLevel() IsolationLevel {
+	if x != nil {
+		return x.xxx_hidden_IsolationLevel
+	}
+	return IsolationLevel_ISOLATION_LEVEL_UNSPECIFIED
+}
+
+func (x *MessageOptions) GetVersion() string {
+	if x != nil {
+		return x.xxx_hidden_Version
+	}
+	return ""
+}
+
+func (x *MessageOptions) GetTimestampSource() TimestampSource {
+	if x != nil {
+		return x.xxx_hidden_TimestampSource
+	}
+	return TimestampSource_TIMESTAMP_SOURCE_UNSPECIFIED
+}
+
+func (x *MessageOptions) GetForeignKeys() []*ForeignKey {
+	if x != nil {
+		if x.xxx_hidden_ForeignKeys != nil {
+			return *x.xxx_hidden_ForeignKeys
+		}
+	}
+	return nil
+}
+
 func (x *MessageOptions) SetImplementations(v []Implementation) {
 	x.xxx_hidden_Implementations = v
 }
@@ -241,6 +551,26 @@ func (x *MessageOptions) SetUpdatedAt(v string) {
 	x.xxx_hidden_UpdatedAt = v
 }
 
+func (x *MessageOptions) SetDeletedAt(v string) {
+	x.xxx_hidden_DeletedAt = v
+}
+
+func (x *MessageOptions) SetIsolationLevel(v IsolationLevel) {
+	x.xxx_hidden_IsolationLevel = v
+}
+
+func (x *MessageOptions) SetVersion(v string) {
+	x.xxx_hidden_Version = v
+}
+
+func (x *MessageOptions) SetTimestampSource(v TimestampSource) {
+	x.xxx_hidden_TimestampSource = v
+}
+
+func (x *MessageOptions) SetForeignKeys(v []*ForeignKey) {
+	x.xxx_hidden_ForeignKeys = &v
+}
+
 type MessageOptions_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
@@ -260,6 +590,28 @@ type MessageOptions_builder struct {
 	// Sets the property of this message to be used to track when this message was created.
 	// If set, the property must exist on the message and must have `asTimestamp` set to true.
 	UpdatedAt string
+	// Sets the property of this message to be used to track when this message was deleted.
+	// If set, deletes are soft, i.e. the property is set instead of the row being removed,
+	// and soft deleted messages are excluded from reads and updates.
+	// If set, the property must exist on the message and must have `asTimestamp` set to true.
+	DeletedAt string
+	// Sets the isolation level of the transactions begun by creates and updates.
+	// If not set transactions are serializable.
+	// The isolation level can be overridden when constructing a repository.
+	IsolationLevel IsolationLevel
+	// Sets the property of this message to be used as its version for optimistic locking.
+	// If set, updates only succeed when the version matches the stored version, which they increment,
+	// and otherwise fail with a version conflict.
+	// If set, the property must exist on the message, must be an integer and cannot be part of a primary key.
+	Version string
+	// Sets the clock the created, updated and deleted at times of this message are taken from.
+	// If not set the clock of the repository is used, which can be replaced when constructing a repository.
+	// If set to the database clock, unset created at times are filled by the database on create
+	// and updated at times are always set by the database on update.
+	TimestampSource TimestampSource
+	// Sets the foreign keys of this message, each referencing the primary key of another message.
+	// The referenced message must be stored by the same implementations as this message.
+	ForeignKeys []*ForeignKey
 }
 
 func (b0 MessageOptions_builder) Build() *MessageOptions {
@@ -271,6 +623,11 @@ func (b0 MessageOptions_builder) Build() *MessageOptions {
 	x.xxx_hidden_PrimaryKey = b.PrimaryKey
 	x.xxx_hidden_CreatedAt = b.CreatedAt
 	x.xxx_hidden_UpdatedAt = b.UpdatedAt
+	x.xxx_hidden_DeletedAt = b.DeletedAt
+	x.xxx_hidden_IsolationLevel = b.IsolationLevel
+	x.xxx_hidden_Version = b.Version
+	x.xxx_hidden_TimestampSource = b.TimestampSource
+	x.xxx_hidden_ForeignKeys = &b.ForeignKeys
 	return m0
 }
 
@@ -282,7 +639,7 @@ type ServiceOptions struct {
 
 func (x *ServiceOptions) Reset() {
 	*x = ServiceOptions{}
-	mi := &file_protoc_gen_crud_options_crud_proto_msgTypes[3]
+	mi := &file_protoc_gen_crud_options_crud_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -294,7 +651,7 @@ func (x *ServiceOptions) String() string {
 func (*ServiceOptions) ProtoMessage() {}
 
 func (x *ServiceOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_protoc_gen_crud_options_crud_proto_msgTypes[3]
+	mi := &file_protoc_gen_crud_options_crud_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -324,13 +681,21 @@ type FieldOptions struct {
 	xxx_hidden_Ignore       bool                   `protobuf:"varint,2,opt,name=ignore,proto3" json:"ignore,omitempty"`
 	xxx_hidden_Inline       bool                   `protobuf:"varint,3,opt,name=inline,proto3" json:"inline,omitempty"`
 	xxx_hidden_AsTimestamp  bool                   `protobuf:"varint,4,opt,name=asTimestamp,proto3" json:"asTimestamp,omitempty"`
+	xxx_hidden_AutoGenerate AutoGenerationStrategy `protobuf:"varint,5,opt,name=autoGenerate,proto3,enum=protoc_gen_crud.options.AutoGenerationStrategy" json:"autoGenerate,omitempty"`
+	xxx_hidden_Nullable     bool                   `protobuf:"varint,6,opt,name=nullable,proto3,oneof" json:"nullable,omitempty"`
+	xxx_hidden_Default      string                 `protobuf:"bytes,7,opt,name=default,proto3" json:"default,omitempty"`
+	xxx_hidden_Check        string                 `protobuf:"bytes,8,opt,name=check,proto3" json:"check,omitempty"`
+	xxx_hidden_OnDelete     ReferentialAction      `protobuf:"varint,9,opt,name=onDelete,proto3,enum=protoc_gen_crud.options.ReferentialAction" json:"onDelete,omitempty"`
+	xxx_hidden_OnUpdate     ReferentialAction      `protobuf:"varint,10,opt,name=onUpdate,proto3,enum=protoc_gen_crud.options.ReferentialAction" json:"onUpdate,omitempty"`
+	XXX_raceDetectHookData  protoimpl.RaceDetectHookData
+	XXX_presence            [1]uint32
 	unknownFields           protoimpl.UnknownFields
 	sizeCache               protoimpl.SizeCache
 }
 
 func (x *FieldOptions) Reset() {
 	*x = FieldOptions{}
-	mi := &file_protoc_gen_crud_options_crud_proto_msgTypes[4]
+	mi := &file_protoc_gen_crud_options_crud_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -342,7 +707,7 @@ func (x *FieldOptions) String() string {
 func (*FieldOptions) ProtoMessage() {}
 
 func (x *FieldOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_protoc_gen_crud_options_crud_proto_msgTypes[4]
+	mi := &file_protoc_gen_crud_options_crud_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -381,6 +746,48 @@ func (x *FieldOptions) GetAsTimestamp() bool {
 	return false
 }
 
+func (x *FieldOptions) GetAutoGenerate() AutoGenerationStrategy {
+	if x != nil {
+		return x.xxx_hidden_AutoGenerate
+	}
+	return AutoGenerationStrategy_AUTO_GENERATION_STRATEGY_UNSPECIFIED
+}
+
+func (x *FieldOptions) GetNullable() bool {
+	if x != nil {
+		return x.xxx_hidden_Nullable
+	}
+	return false
+}
+
+func (x *FieldOptions) GetDefault() string {
+	if x != nil {
+		return x.xxx_hidden_Default
+	}
+	return ""
+}
+
+func (x *FieldOptions) GetCheck() string {
+	if x != nil {
+		return x.xxx_hidden_Check
+	}
+	return ""
+}
+
+func (x *FieldOptions) GetOnDelete() ReferentialAction {
+	if x != nil {
+		return x.xxx_hidden_OnDelete
+	}
+	return ReferentialAction_REFERENTIAL_ACTION_UNSPECIFIED
+}
+
+func (x *FieldOptions) GetOnUpdate() ReferentialAction {
+	if x != nil {
+		return x.xxx_hidden_OnUpdate
+	}
+	return ReferentialAction_REFERENTIAL_ACTION_UNSPECIFIED
+}
+
 func (x *FieldOptions) SetRelationship(v *Relationship) {
 	x.xxx_hidden_Relationship = v
 }
@@ -397,6 +804,31 @@ func (x *FieldOptions) SetAsTimestamp(v bool) {
 	x.xxx_hidden_AsTimestamp = v
 }
 
+func (x *FieldOptions) SetAutoGenerate(v AutoGenerationStrategy) {
+	x.xxx_hidden_AutoGenerate = v
+}
+
+func (x *FieldOptions) SetNullable(v bool) {
+	x.xxx_hidden_Nullable = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 10)
+}
+
+func (x *FieldOptions) SetDefault(v string) {
+	x.xxx_hidden_Default = v
+}
+
+func (x *FieldOptions) SetCheck(v string) {
+	x.xxx_hidden_Check = v
+}
+
+func (x *FieldOptions) SetOnDelete(v ReferentialAction) {
+	x.xxx_hidden_OnDelete = v
+}
+
+func (x *FieldOptions) SetOnUpdate(v ReferentialAction) {
+	x.xxx_hidden_OnUpdate = v
+}
+
 func (x *FieldOptions) HasRelationship() bool {
 	if x == nil {
 		return false
@@ -404,10 +836,22 @@ func (x *FieldOptions) HasRelationship() bool {
 	return x.xxx_hidden_Relationship != nil
 }
 
+func (x *FieldOptions) HasNullable() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
 func (x *FieldOptions) ClearRelationship() {
 	x.xxx_hidden_Relationship = nil
 }
 
+func (x *FieldOptions) ClearNullable() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_Nullable = false
+}
+
 type FieldOptions_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
@@ -415,6 +859,28 @@ type FieldOptions_builder struct {
 	Ignore       bool
 	Inline       bool
 	AsTimestamp  bool
+	// Sets the strategy used to generate the value of this field on create.
+	// Values are only generated when the field is unset, i.e. has its zero value, set values are used as is.
+	// If set, the field must be part of the primary key.
+	// If set to `AUTO_GENERATION_STRATEGY_SEQUENTIAL`, the field must be the only primary key field.
+	AutoGenerate AutoGenerationStrategy
+	// Sets whether the column of this field accepts NULL.
+	// If unset, the column is nullable as are the columns of proto3 `optional` fields.
+	// If set to false, the column is declared NOT NULL, this cannot be combined with a proto3 `optional` field.
+	// If set to true on a field without presence, NULL is read as the zero value of the field.
+	// Primary key and version fields cannot be nullable.
+	Nullable *bool
+	// Sets the SQL expression used as the DEFAULT of the column of this field.
+	// The default only applies to inserts which omit the column, e.g. those made outside the generated repositories.
+	Default string
+	// Sets the SQL expression the column of this field is CHECKed against, e.g. `price >= 0`.
+	Check string
+	// Sets the action taken on the rows referencing a value of the enum look-up table when it is deleted.
+	// If set, the field must be an enum.
+	OnDelete ReferentialAction
+	// Sets the action taken on the rows referencing a value of the enum look-up table when its id is updated.
+	// If set, the field must be an enum.
+	OnUpdate ReferentialAction
 }
 
 func (b0 FieldOptions_builder) Build() *FieldOptions {
@@ -425,6 +891,15 @@ func (b0 FieldOptions_builder) Build() *FieldOptions {
 	x.xxx_hidden_Ignore = b.Ignore
 	x.xxx_hidden_Inline = b.Inline
 	x.xxx_hidden_AsTimestamp = b.AsTimestamp
+	x.xxx_hidden_AutoGenerate = b.AutoGenerate
+	if b.Nullable != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 10)
+		x.xxx_hidden_Nullable = *b.Nullable
+	}
+	x.xxx_hidden_Default = b.Default
+	x.xxx_hidden_Check = b.Check
+	x.xxx_hidden_OnDelete = b.OnDelete
+	x.xxx_hidden_OnUpdate = b.OnUpdate
 	return m0
 }
 
@@ -437,66 +912,168 @@ var file_protoc_gen_crud_options_crud_proto_rawDesc = []byte{
 	0x5f, 0x63, 0x72, 0x75, 0x64, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x1a, 0x2a, 0x70,
 	0x72, 0x6f, 0x74, 0x6f, 0x63, 0x2d, 0x67, 0x65, 0x6e, 0x2d, 0x63, 0x72, 0x75, 0x64, 0x2f, 0x6f,
 	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2f, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
-	0x68, 0x69, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x0d, 0x0a, 0x0b, 0x46, 0x69, 0x6c,
-	0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x0f, 0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x68,
-	0x6f, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xdd, 0x01, 0x0a, 0x0e, 0x4d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x51, 0x0a, 0x0f,
-	0x69, 0x6d, 0x70, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18,
-	0x01, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x27, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x5f, 0x67,
-	0x65, 0x6e, 0x5f, 0x63, 0x72, 0x75, 0x64, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e,
-	0x49, 0x6d, 0x70, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0f,
-	0x69, 0x6d, 0x70, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12,
-	0x1c, 0x0a, 0x09, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x4d, 0x61, 0x73, 0x6b, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x09, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x4d, 0x61, 0x73, 0x6b, 0x12, 0x1e, 0x0a,
-	0x0a, 0x70, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x4b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x03, 0x28,
-	0x09, 0x52, 0x0a, 0x70, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x4b, 0x65, 0x79, 0x12, 0x1c, 0x0a,
-	0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x75,
-	0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
-	0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x10, 0x0a, 0x0e, 0x53, 0x65, 0x72,
-	0x76, 0x69, 0x63, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xab, 0x01, 0x0a, 0x0c,
-	0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x49, 0x0a, 0x0c,
-	0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x68, 0x69, 0x70, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x25, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x5f, 0x67, 0x65, 0x6e, 0x5f,
-	0x63, 0x72, 0x75, 0x64, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x52, 0x65, 0x6c,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x68, 0x69, 0x70, 0x52, 0x0c, 0x72, 0x65, 0x6c, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x73, 0x68, 0x69, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x69, 0x67, 0x6e, 0x6f, 0x72,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x12,
-	0x16, 0x0a, 0x06, 0x69, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x06, 0x69, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x61, 0x73, 0x54, 0x69, 0x6d,
-	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x61, 0x73,
-	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2a, 0x65, 0x0a, 0x0e, 0x49, 0x6d, 0x70,
-	0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1e, 0x0a, 0x1a, 0x49,
-	0x4d, 0x50, 0x4c, 0x45, 0x4d, 0x45, 0x4e, 0x54, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x55, 0x4e,
-	0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x19, 0x0a, 0x15, 0x49,
-	0x4d, 0x50, 0x4c, 0x45, 0x4d, 0x45, 0x4e, 0x54, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x51,
-	0x4c, 0x49, 0x54, 0x45, 0x10, 0x01, 0x12, 0x18, 0x0a, 0x14, 0x49, 0x4d, 0x50, 0x4c, 0x45, 0x4d,
-	0x45, 0x4e, 0x54, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x50, 0x47, 0x53, 0x51, 0x4c, 0x10, 0x02,
-	0x42, 0x30, 0x5a, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73,
-	0x61, 0x6d, 0x6c, 0x69, 0x74, 0x6f, 0x77, 0x69, 0x74, 0x7a, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x63, 0x2d, 0x67, 0x65, 0x6e, 0x2d, 0x63, 0x72, 0x75, 0x64, 0x2f, 0x6f, 0x70, 0x74, 0x69, 0x6f,
-	0x6e, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
-}
-
-var file_protoc_gen_crud_options_crud_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_protoc_gen_crud_options_crud_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+	0x68, 0x69, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x55, 0x0a, 0x0b, 0x46, 0x69, 0x6c,
+	0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x46, 0x0a, 0x0b, 0x65, 0x6e, 0x75, 0x6d,
+	0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x24, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x5f, 0x67, 0x65, 0x6e, 0x5f, 0x63, 0x72, 0x75, 0x64, 0x2e,
+	0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x45, 0x6e, 0x75, 0x6d, 0x53, 0x74, 0x6f, 0x72,
+	0x61, 0x67, 0x65, 0x52, 0x0b, 0x65, 0x6e, 0x75, 0x6d, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65,
+	0x22, 0x4d, 0x0a, 0x0b, 0x45, 0x6e, 0x75, 0x6d, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12,
+	0x3e, 0x0a, 0x07, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x24, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x5f, 0x67, 0x65, 0x6e, 0x5f, 0x63, 0x72,
+	0x75, 0x64, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x45, 0x6e, 0x75, 0x6d, 0x53,
+	0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x52, 0x07, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x22,
+	0x0f, 0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x22, 0x81, 0x04, 0x0a, 0x0e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x12, 0x51, 0x0a, 0x0f, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x27, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x63, 0x5f, 0x67, 0x65, 0x6e, 0x5f, 0x63, 0x72, 0x75, 0x64, 0x2e, 0x6f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x49, 0x6d, 0x70, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0f, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x4d,
+	0x61, 0x73, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x66, 0x69, 0x65, 0x6c, 0x64,
+	0x4d, 0x61, 0x73, 0x6b, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x4b,
+	0x65, 0x79, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x72, 0x69, 0x6d, 0x61, 0x72,
+	0x79, 0x4b, 0x65, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41,
+	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64,
+	0x41, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74,
+	0x12, 0x1c, 0x0a, 0x09, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x4f,
+	0x0a, 0x0e, 0x69, 0x73, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x65, 0x76, 0x65, 0x6c,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x27, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x5f,
+	0x67, 0x65, 0x6e, 0x5f, 0x63, 0x72, 0x75, 0x64, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x2e, 0x49, 0x73, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x52,
+	0x0e, 0x69, 0x73, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12,
+	0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x52, 0x0a, 0x0f, 0x74, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x28, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x5f, 0x67, 0x65, 0x6e, 0x5f,
+	0x63, 0x72, 0x75, 0x64, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x0f, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x45, 0x0a,
+	0x0b, 0x66, 0x6f, 0x72, 0x65, 0x69, 0x67, 0x6e, 0x4b, 0x65, 0x79, 0x73, 0x18, 0x0a, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x23, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x5f, 0x67, 0x65, 0x6e, 0x5f,
+	0x63, 0x72, 0x75, 0x64, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x46, 0x6f, 0x72,
+	0x65, 0x69, 0x67, 0x6e, 0x4b, 0x65, 0x79, 0x52, 0x0b, 0x66, 0x6f, 0x72, 0x65, 0x69, 0x67, 0x6e,
+	0x4b, 0x65, 0x79, 0x73, 0x22, 0x10, 0x0a, 0x0e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xee, 0x03, 0x0a, 0x0c, 0x46, 0x69, 0x65, 0x6c, 0x64,
+	0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x49, 0x0a, 0x0c, 0x72, 0x65, 0x6c, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x68, 0x69, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x5f, 0x67, 0x65, 0x6e, 0x5f, 0x63, 0x72, 0x75, 0x64, 0x2e,
+	0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x68, 0x69, 0x70, 0x52, 0x0c, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x68,
+	0x69, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x06, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x69, 0x6e,
+	0x6c, 0x69, 0x6e, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x69, 0x6e, 0x6c, 0x69,
+	0x6e, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x61, 0x73, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x61, 0x73, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x12, 0x53, 0x0a, 0x0c, 0x61, 0x75, 0x74, 0x6f, 0x47, 0x65, 0x6e, 0x65,
+	0x72, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2f, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x63, 0x5f, 0x67, 0x65, 0x6e, 0x5f, 0x63, 0x72, 0x75, 0x64, 0x2e, 0x6f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x41, 0x75, 0x74, 0x6f, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x53, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x52, 0x0c, 0x61, 0x75, 0x74,
+	0x6f, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x12, 0x1f, 0x0a, 0x08, 0x6e, 0x75, 0x6c,
+	0x6c, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x08, 0x6e,
+	0x75, 0x6c, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x88, 0x01, 0x01, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x65,
+	0x66, 0x61, 0x75, 0x6c, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x64, 0x65, 0x66,
+	0x61, 0x75, 0x6c, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x12, 0x46, 0x0a, 0x08, 0x6f, 0x6e,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2a, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x63, 0x5f, 0x67, 0x65, 0x6e, 0x5f, 0x63, 0x72, 0x75, 0x64, 0x2e, 0x6f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x74, 0x69,
+	0x61, 0x6c, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x6f, 0x6e, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x12, 0x46, 0x0a, 0x08, 0x6f, 0x6e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x2a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x5f, 0x67, 0x65,
+	0x6e, 0x5f, 0x63, 0x72, 0x75, 0x64, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x52,
+	0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x08, 0x6f, 0x6e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x6e,
+	0x75, 0x6c, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x2a, 0x65, 0x0a, 0x0e, 0x49, 0x6d, 0x70, 0x6c, 0x65,
+	0x6d, 0x65, 0x6e, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1e, 0x0a, 0x1a, 0x49, 0x4d, 0x50,
+	0x4c, 0x45, 0x4d, 0x45, 0x4e, 0x54, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x53, 0x50,
+	0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x19, 0x0a, 0x15, 0x49, 0x4d, 0x50,
+	0x4c, 0x45, 0x4d, 0x45, 0x4e, 0x54, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x51, 0x4c, 0x49,
+	0x54, 0x45, 0x10, 0x01, 0x12, 0x18, 0x0a, 0x14, 0x49, 0x4d, 0x50, 0x4c, 0x45, 0x4d, 0x45, 0x4e,
+	0x54, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x50, 0x47, 0x53, 0x51, 0x4c, 0x10, 0x02, 0x2a, 0xb7,
+	0x01, 0x0a, 0x16, 0x41, 0x75, 0x74, 0x6f, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x53, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x12, 0x28, 0x0a, 0x24, 0x41, 0x55, 0x54,
+	0x4f, 0x5f, 0x47, 0x45, 0x4e, 0x45, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x54, 0x52,
+	0x41, 0x54, 0x45, 0x47, 0x59, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45,
+	0x44, 0x10, 0x00, 0x12, 0x24, 0x0a, 0x20, 0x41, 0x55, 0x54, 0x4f, 0x5f, 0x47, 0x45, 0x4e, 0x45,
+	0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x54, 0x52, 0x41, 0x54, 0x45, 0x47, 0x59, 0x5f,
+	0x55, 0x55, 0x49, 0x44, 0x5f, 0x56, 0x34, 0x10, 0x01, 0x12, 0x24, 0x0a, 0x20, 0x41, 0x55, 0x54,
+	0x4f, 0x5f, 0x47, 0x45, 0x4e, 0x45, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x54, 0x52,
+	0x41, 0x54, 0x45, 0x47, 0x59, 0x5f, 0x55, 0x55, 0x49, 0x44, 0x5f, 0x56, 0x37, 0x10, 0x02, 0x12,
+	0x27, 0x0a, 0x23, 0x41, 0x55, 0x54, 0x4f, 0x5f, 0x47, 0x45, 0x4e, 0x45, 0x52, 0x41, 0x54, 0x49,
+	0x4f, 0x4e, 0x5f, 0x53, 0x54, 0x52, 0x41, 0x54, 0x45, 0x47, 0x59, 0x5f, 0x53, 0x45, 0x51, 0x55,
+	0x45, 0x4e, 0x54, 0x49, 0x41, 0x4c, 0x10, 0x03, 0x2a, 0xc2, 0x01, 0x0a, 0x0e, 0x49, 0x73, 0x6f,
+	0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x1f, 0x0a, 0x1b, 0x49,
+	0x53, 0x4f, 0x4c, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x4c, 0x45, 0x56, 0x45, 0x4c, 0x5f, 0x55,
+	0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x24, 0x0a, 0x20,
+	0x49, 0x53, 0x4f, 0x4c, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x4c, 0x45, 0x56, 0x45, 0x4c, 0x5f,
+	0x52, 0x45, 0x41, 0x44, 0x5f, 0x55, 0x4e, 0x43, 0x4f, 0x4d, 0x4d, 0x49, 0x54, 0x54, 0x45, 0x44,
+	0x10, 0x01, 0x12, 0x22, 0x0a, 0x1e, 0x49, 0x53, 0x4f, 0x4c, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f,
+	0x4c, 0x45, 0x56, 0x45, 0x4c, 0x5f, 0x52, 0x45, 0x41, 0x44, 0x5f, 0x43, 0x4f, 0x4d, 0x4d, 0x49,
+	0x54, 0x54, 0x45, 0x44, 0x10, 0x02, 0x12, 0x23, 0x0a, 0x1f, 0x49, 0x53, 0x4f, 0x4c, 0x41, 0x54,
+	0x49, 0x4f, 0x4e, 0x5f, 0x4c, 0x45, 0x56, 0x45, 0x4c, 0x5f, 0x52, 0x45, 0x50, 0x45, 0x41, 0x54,
+	0x41, 0x42, 0x4c, 0x45, 0x5f, 0x52, 0x45, 0x41, 0x44, 0x10, 0x03, 0x12, 0x20, 0x0a, 0x1c, 0x49,
+	0x53, 0x4f, 0x4c, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x4c, 0x45, 0x56, 0x45, 0x4c, 0x5f, 0x53,
+	0x45, 0x52, 0x49, 0x41, 0x4c, 0x49, 0x5a, 0x41, 0x42, 0x4c, 0x45, 0x10, 0x04, 0x2a, 0x6f, 0x0a,
+	0x0f, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x12, 0x20, 0x0a, 0x1c, 0x54, 0x49, 0x4d, 0x45, 0x53, 0x54, 0x41, 0x4d, 0x50, 0x5f, 0x53, 0x4f,
+	0x55, 0x52, 0x43, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44,
+	0x10, 0x00, 0x12, 0x1b, 0x0a, 0x17, 0x54, 0x49, 0x4d, 0x45, 0x53, 0x54, 0x41, 0x4d, 0x50, 0x5f,
+	0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x43, 0x4c, 0x49, 0x45, 0x4e, 0x54, 0x10, 0x01, 0x12,
+	0x1d, 0x0a, 0x19, 0x54, 0x49, 0x4d, 0x45, 0x53, 0x54, 0x41, 0x4d, 0x50, 0x5f, 0x53, 0x4f, 0x55,
+	0x52, 0x43, 0x45, 0x5f, 0x44, 0x41, 0x54, 0x41, 0x42, 0x41, 0x53, 0x45, 0x10, 0x02, 0x2a, 0x7a,
+	0x0a, 0x0b, 0x45, 0x6e, 0x75, 0x6d, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x12, 0x1c, 0x0a,
+	0x18, 0x45, 0x4e, 0x55, 0x4d, 0x5f, 0x53, 0x54, 0x4f, 0x52, 0x41, 0x47, 0x45, 0x5f, 0x55, 0x4e,
+	0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1d, 0x0a, 0x19, 0x45,
+	0x4e, 0x55, 0x4d, 0x5f, 0x53, 0x54, 0x4f, 0x52, 0x41, 0x47, 0x45, 0x5f, 0x4c, 0x4f, 0x4f, 0x4b,
+	0x55, 0x50, 0x5f, 0x54, 0x41, 0x42, 0x4c, 0x45, 0x10, 0x01, 0x12, 0x17, 0x0a, 0x13, 0x45, 0x4e,
+	0x55, 0x4d, 0x5f, 0x53, 0x54, 0x4f, 0x52, 0x41, 0x47, 0x45, 0x5f, 0x4e, 0x41, 0x54, 0x49, 0x56,
+	0x45, 0x10, 0x02, 0x12, 0x15, 0x0a, 0x11, 0x45, 0x4e, 0x55, 0x4d, 0x5f, 0x53, 0x54, 0x4f, 0x52,
+	0x41, 0x47, 0x45, 0x5f, 0x54, 0x45, 0x58, 0x54, 0x10, 0x03, 0x42, 0x30, 0x5a, 0x2e, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x61, 0x6d, 0x6c, 0x69, 0x74, 0x6f,
+	0x77, 0x69, 0x74, 0x7a, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x2d, 0x67, 0x65, 0x6e, 0x2d,
+	0x63, 0x72, 0x75, 0x64, 0x2f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
+}
+
+var file_protoc_gen_crud_options_crud_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_protoc_gen_crud_options_crud_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
 var file_protoc_gen_crud_options_crud_proto_goTypes = []any{
-	(Implementation)(0),    // 0: protoc_gen_crud.options.Implementation
-	(*FileOptions)(nil),    // 1: protoc_gen_crud.options.FileOptions
-	(*MethodOptions)(nil),  // 2: protoc_gen_crud.options.MethodOptions
-	(*MessageOptions)(nil), // 3: protoc_gen_crud.options.MessageOptions
-	(*ServiceOptions)(nil), // 4: protoc_gen_crud.options.ServiceOptions
-	(*FieldOptions)(nil),   // 5: protoc_gen_crud.options.FieldOptions
-	(*Relationship)(nil),   // 6: protoc_gen_crud.options.Relationship
+	(Implementation)(0),         // 0: protoc_gen_crud.options.Implementation
+	(AutoGenerationStrategy)(0), // 1: protoc_gen_crud.options.AutoGenerationStrategy
+	(IsolationLevel)(0),         // 2: protoc_gen_crud.options.IsolationLevel
+	(TimestampSource)(0),        // 3: protoc_gen_crud.options.TimestampSource
+	(EnumStorage)(0),            // 4: protoc_gen_crud.options.EnumStorage
+	(*FileOptions)(nil),         // 5: protoc_gen_crud.options.FileOptions
+	(*EnumOptions)(nil),         // 6: protoc_gen_crud.options.EnumOptions
+	(*MethodOptions)(nil),       // 7: protoc_gen_crud.options.MethodOptions
+	(*MessageOptions)(nil),      // 8: protoc_gen_crud.options.MessageOptions
+	(*ServiceOptions)(nil),      // 9: protoc_gen_crud.options.ServiceOptions
+	(*FieldOptions)(nil),        // 10: protoc_gen_crud.options.FieldOptions
+	(*ForeignKey)(nil),          // 11: protoc_gen_crud.options.ForeignKey
+	(*Relationship)(nil),        // 12: protoc_gen_crud.options.Relationship
+	(ReferentialAction)(0),      // 13: protoc_gen_crud.options.ReferentialAction
 }
 var file_protoc_gen_crud_options_crud_proto_depIdxs = []int32{
-	0, // 0: protoc_gen_crud.options.MessageOptions.implementations:type_name -> protoc_gen_crud.options.Implementation
-	6, // 1: protoc_gen_crud.options.FieldOptions.relationship:type_name -> protoc_gen_crud.options.Relationship
-	2, // [2:2] is the sub-list for method output_type
-	2, // [2:2] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	4,  // 0: protoc_gen_crud.options.FileOptions.enumStorage:type_name -> protoc_gen_crud.options.EnumStorage
+	4,  // 1: protoc_gen_crud.options.EnumOptions.storage:type_name -> protoc_gen_crud.options.EnumStorage
+	0,  // 2: protoc_gen_crud.options.MessageOptions.implementations:type_name -> protoc_gen_crud.options.Implementation
+	2,  // 3: protoc_gen_crud.options.MessageOptions.isolationLevel:type_name -> protoc_gen_crud.options.IsolationLevel
+	3,  // 4: protoc_gen_crud.options.MessageOptions.timestampSource:type_name -> protoc_gen_crud.options.TimestampSource
+	11, // 5: protoc_gen_crud.options.MessageOptions.foreignKeys:type_name -> protoc_gen_crud.options.ForeignKey
+	12, // 6: protoc_gen_crud.options.FieldOptions.relationship:type_name -> protoc_gen_crud.options.Relationship
+	1,  // 7: protoc_gen_crud.options.FieldOptions.autoGenerate:type_name -> protoc_gen_crud.options.AutoGenerationStrategy
+	13, // 8: protoc_gen_crud.options.FieldOptions.onDelete:type_name -> protoc_gen_crud.options.ReferentialAction
+	13, // 9: protoc_gen_crud.options.FieldOptions.onUpdate:type_name -> protoc_gen_crud.options.ReferentialAction
+	10, // [10:10] is the sub-list for method output_type
+	10, // [10:10] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
 }
 
 func init() { file_protoc_gen_crud_options_crud_proto_init() }
@@ -505,13 +1082,14 @@ func file_protoc_gen_crud_options_crud_proto_init() {
 		return
 	}
 	file_protoc_gen_crud_options_relationship_proto_init()
+	file_protoc_gen_crud_options_crud_proto_msgTypes[5].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_protoc_gen_crud_options_crud_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   5,
+			NumEnums:      5,
+			NumMessages:   6,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
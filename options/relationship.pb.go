@@ -20,11 +20,61 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Actions taken on the referencing rows when the row they reference is deleted or its key is updated
+type ReferentialAction int32
+
+const (
+	ReferentialAction_REFERENTIAL_ACTION_UNSPECIFIED ReferentialAction = 0 // Use the default, the change fails while the row is referenced (NO ACTION)
+	ReferentialAction_REFERENTIAL_ACTION_CASCADE     ReferentialAction = 1 // Delete or update the referencing rows along with the referenced row
+	ReferentialAction_REFERENTIAL_ACTION_RESTRICT    ReferentialAction = 2 // Fail the change immediately while the row is referenced
+	ReferentialAction_REFERENTIAL_ACTION_SET_NULL    ReferentialAction = 3 // Set the referencing columns to NULL, they must be nullable
+)
+
+// Enum value maps for ReferentialAction.
+var (
+	ReferentialAction_name = map[int32]string{
+		0: "REFERENTIAL_ACTION_UNSPECIFIED",
+		1: "REFERENTIAL_ACTION_CASCADE",
+		2: "REFERENTIAL_ACTION_RESTRICT",
+		3: "REFERENTIAL_ACTION_SET_NULL",
+	}
+	ReferentialAction_value = map[string]int32{
+		"REFERENTIAL_ACTION_UNSPECIFIED": 0,
+		"REFERENTIAL_ACTION_CASCADE":     1,
+		"REFERENTIAL_ACTION_RESTRICT":    2,
+		"REFERENTIAL_ACTION_SET_NULL":    3,
+	}
+)
+
+func (x ReferentialAction) Enum() *ReferentialAction {
+	p := new(ReferentialAction)
+	*p = x
+	return p
+}
+
+func (x ReferentialAction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReferentialAction) Descriptor() protoreflect.EnumDescriptor {
+	return file_protoc_gen_crud_options_relationship_proto_enumTypes[0].Descriptor()
+}
+
+func (ReferentialAction) Type() protoreflect.EnumType {
+	return &file_protoc_gen_crud_options_relationship_proto_enumTypes[0]
+}
+
+func (x ReferentialAction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
 type Relationship struct {
-	state           protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_Type relationships.Type     `protobuf:"varint,1,opt,name=type,proto3,enum=protoc_gen_crud.options.relationships.Type" json:"type,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	state               protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Type     relationships.Type     `protobuf:"varint,1,opt,name=type,proto3,enum=protoc_gen_crud.options.relationships.Type" json:"type,omitempty"`
+	xxx_hidden_OnDelete ReferentialAction      `protobuf:"varint,2,opt,name=onDelete,proto3,enum=protoc_gen_crud.options.ReferentialAction" json:"onDelete,omitempty"`
+	xxx_hidden_OnUpdate ReferentialAction      `protobuf:"varint,3,opt,name=onUpdate,proto3,enum=protoc_gen_crud.options.ReferentialAction" json:"onUpdate,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
 }
 
 func (x *Relationship) Reset() {
@@ -59,14 +109,42 @@ func (x *Relationship) GetType() relationships.Type {
 	return relationships.Type(0)
 }
 
+func (x *Relationship) GetOnDelete() ReferentialAction {
+	if x != nil {
+		return x.xxx_hidden_OnDelete
+	}
+	return ReferentialAction_REFERENTIAL_ACTION_UNSPECIFIED
+}
+
+func (x *Relationship) GetOnUpdate() ReferentialAction {
+	if x != nil {
+		return x.xxx_hidden_OnUpdate
+	}
+	return ReferentialAction_REFERENTIAL_ACTION_UNSPECIFIED
+}
+
 func (x *Relationship) SetType(v relationships.Type) {
 	x.xxx_hidden_Type = v
 }
 
+func (x *Relationship) SetOnDelete(v ReferentialAction) {
+	x.xxx_hidden_OnDelete = v
+}
+
+func (x *Relationship) SetOnUpdate(v ReferentialAction) {
+	x.xxx_hidden_OnUpdate = v
+}
+
 type Relationship_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
 	Type relationships.Type
+	// Sets the action taken on the rows of the relationship table when a related row is deleted.
+	// SET NULL is not supported, the columns of the relationship table are its primary key.
+	OnDelete ReferentialAction
+	// Sets the action taken on the rows of the relationship table when the key of a related row is updated.
+	// SET NULL is not supported, the columns of the relationship table are its primary key.
+	OnUpdate ReferentialAction
 }
 
 func (b0 Relationship_builder) Build() *Relationship {
@@ -74,6 +152,113 @@ func (b0 Relationship_builder) Build() *Relationship {
 	b, x := &b0, m0
 	_, _ = b, x
 	x.xxx_hidden_Type = b.Type
+	x.xxx_hidden_OnDelete = b.OnDelete
+	x.xxx_hidden_OnUpdate = b.OnUpdate
+	return m0
+}
+
+// A foreign key referencing the primary key of another message.
+type ForeignKey struct {
+	state                 protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Fields     []string               `protobuf:"bytes,1,rep,name=fields,proto3" json:"fields,omitempty"`
+	xxx_hidden_References string                 `protobuf:"bytes,2,opt,name=references,proto3" json:"references,omitempty"`
+	xxx_hidden_OnDelete   ReferentialAction      `protobuf:"varint,3,opt,name=onDelete,proto3,enum=protoc_gen_crud.options.ReferentialAction" json:"onDelete,omitempty"`
+	xxx_hidden_OnUpdate   ReferentialAction      `protobuf:"varint,4,opt,name=onUpdate,proto3,enum=protoc_gen_crud.options.ReferentialAction" json:"onUpdate,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *ForeignKey) Reset() {
+	*x = ForeignKey{}
+	mi := &file_protoc_gen_crud_options_relationship_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ForeignKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForeignKey) ProtoMessage() {}
+
+func (x *ForeignKey) ProtoReflect() protoreflect.Message {
+	mi := &file_protoc_gen_crud_options_relationship_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ForeignKey) GetFields() []string {
+	if x != nil {
+		return x.xxx_hidden_Fields
+	}
+	return nil
+}
+
+func (x *ForeignKey) GetReferences() string {
+	if x != nil {
+		return x.xxx_hidden_References
+	}
+	return ""
+}
+
+func (x *ForeignKey) GetOnDelete() ReferentialAction {
+	if x != nil {
+		return x.xxx_hidden_OnDelete
+	}
+	return ReferentialAction_REFERENTIAL_ACTION_UNSPECIFIED
+}
+
+func (x *ForeignKey) GetOnUpdate() ReferentialAction {
+	if x != nil {
+		return x.xxx_hidden_OnUpdate
+	}
+	return ReferentialAction_REFERENTIAL_ACTION_UNSPECIFIED
+}
+
+func (x *ForeignKey) SetFields(v []string) {
+	x.xxx_hidden_Fields = v
+}
+
+func (x *ForeignKey) SetReferences(v string) {
+	x.xxx_hidden_References = v
+}
+
+func (x *ForeignKey) SetOnDelete(v ReferentialAction) {
+	x.xxx_hidden_OnDelete = v
+}
+
+func (x *ForeignKey) SetOnUpdate(v ReferentialAction) {
+	x.xxx_hidden_OnUpdate = v
+}
+
+type ForeignKey_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Sets the properties of this message making up the foreign key.
+	// They must match the primary key of the referenced message in number and order.
+	Fields []string
+	// Sets the name of the referenced message, it may be fully qualified by a leading dot.
+	References string
+	// Sets the action taken on the referencing rows when the referenced row is deleted.
+	OnDelete ReferentialAction
+	// Sets the action taken on the referencing rows when the key of the referenced row is updated.
+	OnUpdate ReferentialAction
+}
+
+func (b0 ForeignKey_builder) Build() *ForeignKey {
+	m0 := &ForeignKey{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Fields = b.Fields
+	x.xxx_hidden_References = b.References
+	x.xxx_hidden_OnDelete = b.OnDelete
+	x.xxx_hidden_OnUpdate = b.OnUpdate
 	return m0
 }
 
@@ -87,30 +272,69 @@ var file_protoc_gen_crud_options_relationship_proto_rawDesc = []byte{
 	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x1a, 0x30, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x2d, 0x67, 0x65,
 	0x6e, 0x2d, 0x63, 0x72, 0x75, 0x64, 0x2f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2f, 0x72,
 	0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x68, 0x69, 0x70, 0x73, 0x2f, 0x74, 0x79, 0x70,
-	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x4f, 0x0a, 0x0c, 0x52, 0x65, 0x6c, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x73, 0x68, 0x69, 0x70, 0x12, 0x3f, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x5f, 0x67,
-	0x65, 0x6e, 0x5f, 0x63, 0x72, 0x75, 0x64, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e,
-	0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x68, 0x69, 0x70, 0x73, 0x2e, 0x54, 0x79,
-	0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x42, 0x30, 0x5a, 0x2e, 0x67, 0x69, 0x74, 0x68,
-	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x61, 0x6d, 0x6c, 0x69, 0x74, 0x6f, 0x77, 0x69,
-	0x74, 0x7a, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x2d, 0x67, 0x65, 0x6e, 0x2d, 0x63, 0x72,
-	0x75, 0x64, 0x2f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x33,
-}
-
-var file_protoc_gen_crud_options_relationship_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xdf, 0x01, 0x0a, 0x0c, 0x52, 0x65, 0x6c, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x68, 0x69, 0x70, 0x12, 0x3f, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x5f,
+	0x67, 0x65, 0x6e, 0x5f, 0x63, 0x72, 0x75, 0x64, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x2e, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x68, 0x69, 0x70, 0x73, 0x2e, 0x54,
+	0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x46, 0x0a, 0x08, 0x6f, 0x6e, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2a, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x63, 0x5f, 0x67, 0x65, 0x6e, 0x5f, 0x63, 0x72, 0x75, 0x64, 0x2e, 0x6f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x74, 0x69, 0x61,
+	0x6c, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x6f, 0x6e, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x12, 0x46, 0x0a, 0x08, 0x6f, 0x6e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x2a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x5f, 0x67, 0x65, 0x6e,
+	0x5f, 0x63, 0x72, 0x75, 0x64, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x52, 0x65,
+	0x66, 0x65, 0x72, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x08, 0x6f, 0x6e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x22, 0xd4, 0x01, 0x0a, 0x0a, 0x46, 0x6f,
+	0x72, 0x65, 0x69, 0x67, 0x6e, 0x4b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x65, 0x6c,
+	0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73,
+	0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73,
+	0x12, 0x46, 0x0a, 0x08, 0x6f, 0x6e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x2a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x5f, 0x67, 0x65, 0x6e, 0x5f,
+	0x63, 0x72, 0x75, 0x64, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x52, 0x65, 0x66,
+	0x65, 0x72, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08,
+	0x6f, 0x6e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x46, 0x0a, 0x08, 0x6f, 0x6e, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2a, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x63, 0x5f, 0x67, 0x65, 0x6e, 0x5f, 0x63, 0x72, 0x75, 0x64, 0x2e, 0x6f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c,
+	0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x6f, 0x6e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x2a, 0x99, 0x01, 0x0a, 0x11, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c,
+	0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x22, 0x0a, 0x1e, 0x52, 0x45, 0x46, 0x45, 0x52, 0x45,
+	0x4e, 0x54, 0x49, 0x41, 0x4c, 0x5f, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x53,
+	0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1e, 0x0a, 0x1a, 0x52, 0x45,
+	0x46, 0x45, 0x52, 0x45, 0x4e, 0x54, 0x49, 0x41, 0x4c, 0x5f, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e,
+	0x5f, 0x43, 0x41, 0x53, 0x43, 0x41, 0x44, 0x45, 0x10, 0x01, 0x12, 0x1f, 0x0a, 0x1b, 0x52, 0x45,
+	0x46, 0x45, 0x52, 0x45, 0x4e, 0x54, 0x49, 0x41, 0x4c, 0x5f, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e,
+	0x5f, 0x52, 0x45, 0x53, 0x54, 0x52, 0x49, 0x43, 0x54, 0x10, 0x02, 0x12, 0x1f, 0x0a, 0x1b, 0x52,
+	0x45, 0x46, 0x45, 0x52, 0x45, 0x4e, 0x54, 0x49, 0x41, 0x4c, 0x5f, 0x41, 0x43, 0x54, 0x49, 0x4f,
+	0x4e, 0x5f, 0x53, 0x45, 0x54, 0x5f, 0x4e, 0x55, 0x4c, 0x4c, 0x10, 0x03, 0x42, 0x30, 0x5a, 0x2e,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x61, 0x6d, 0x6c, 0x69,
+	0x74, 0x6f, 0x77, 0x69, 0x74, 0x7a, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x2d, 0x67, 0x65,
+	0x6e, 0x2d, 0x63, 0x72, 0x75, 0x64, 0x2f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var file_protoc_gen_crud_options_relationship_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_protoc_gen_crud_options_relationship_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
 var file_protoc_gen_crud_options_relationship_proto_goTypes = []any{
-	(*Relationship)(nil),    // 0: protoc_gen_crud.options.Relationship
-	(relationships.Type)(0), // 1: protoc_gen_crud.options.relationships.Type
+	(ReferentialAction)(0),  // 0: protoc_gen_crud.options.ReferentialAction
+	(*Relationship)(nil),    // 1: protoc_gen_crud.options.Relationship
+	(*ForeignKey)(nil),      // 2: protoc_gen_crud.options.ForeignKey
+	(relationships.Type)(0), // 3: protoc_gen_crud.options.relationships.Type
 }
 var file_protoc_gen_crud_options_relationship_proto_depIdxs = []int32{
-	1, // 0: protoc_gen_crud.options.Relationship.type:type_name -> protoc_gen_crud.options.relationships.Type
-	1, // [1:1] is the sub-list for method output_type
-	1, // [1:1] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	3, // 0: protoc_gen_crud.options.Relationship.type:type_name -> protoc_gen_crud.options.relationships.Type
+	0, // 1: protoc_gen_crud.options.Relationship.onDelete:type_name -> protoc_gen_crud.options.ReferentialAction
+	0, // 2: protoc_gen_crud.options.Relationship.onUpdate:type_name -> protoc_gen_crud.options.ReferentialAction
+	0, // 3: protoc_gen_crud.options.ForeignKey.onDelete:type_name -> protoc_gen_crud.options.ReferentialAction
+	0, // 4: protoc_gen_crud.options.ForeignKey.onUpdate:type_name -> protoc_gen_crud.options.ReferentialAction
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_protoc_gen_crud_options_relationship_proto_init() }
@@ -123,13 +347,14 @@ func file_protoc_gen_crud_options_relationship_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_protoc_gen_crud_options_relationship_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   1,
+			NumEnums:      1,
+			NumMessages:   2,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_protoc_gen_crud_options_relationship_proto_goTypes,
 		DependencyIndexes: file_protoc_gen_crud_options_relationship_proto_depIdxs,
+		EnumInfos:         file_protoc_gen_crud_options_relationship_proto_enumTypes,
 		MessageInfos:      file_protoc_gen_crud_options_relationship_proto_msgTypes,
 	}.Build()
 	File_protoc_gen_crud_options_relationship_proto = out.File
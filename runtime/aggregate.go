@@ -0,0 +1,46 @@
+package runtime
+
+import (
+	"fmt"
+)
+
+// AggregateFunction is an aggregate function computed over a numeric field of the entities matching some criteria
+type AggregateFunction int
+
+const (
+	// AggregateSum is the sum of the field
+	AggregateSum AggregateFunction = iota + 1
+	// AggregateMin is the least value of the field
+	AggregateMin
+	// AggregateMax is the greatest value of the field
+	AggregateMax
+	// AggregateAvg is the arithmetic mean of the field
+	AggregateAvg
+)
+
+// SQL returns the name of the SQL function computing f
+func (f AggregateFunction) SQL() (string, error) {
+	switch f {
+	case AggregateSum:
+		return "SUM", nil
+	case AggregateMin:
+		return "MIN", nil
+	case AggregateMax:
+		return "MAX", nil
+	case AggregateAvg:
+		return "AVG", nil
+	default:
+		return "", fmt.Errorf("invalid aggregate function: %d", f)
+	}
+}
+
+// AggregateValue is the result of an aggregate function.
+// Averages, and aggregates of floating point fields, are held by Float while all others are held by Int.
+type AggregateValue struct {
+	Int   int64
+	Float float64
+	// IsFloat reports whether the value is held by Float
+	IsFloat bool
+	// Valid is false when there were no values to aggregate
+	Valid bool
+}
@@ -0,0 +1,61 @@
+package runtime
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BatchMode determines how a create or update of several entities handles the failure of some of them.
+type BatchMode int
+
+const (
+	// BatchAllOrNothing rolls back every entity when any fails.
+	BatchAllOrNothing BatchMode = iota
+	// BatchPartialSuccess rolls back only the entities which fail, each within its own savepoint, the others are
+	// committed and the failures are returned as a *BatchError.
+	BatchPartialSuccess
+)
+
+// ItemError is the failure of the entity at Index of a batch.
+type ItemError struct {
+	Index int
+	Err   error
+}
+
+func (e *ItemError) Error() string {
+	return fmt.Sprintf("item %d: %v", e.Index, e.Err)
+}
+
+func (e *ItemError) Unwrap() error {
+	return e.Err
+}
+
+// BatchError is returned when some entities of a batch failed in BatchPartialSuccess mode.
+//
+// It deliberately does not unwrap to the errors of its items: the entities which succeeded were committed, so the
+// batch as a whole must not be mistaken for, and retried as, a failure such as ErrSerializationFailure.
+type BatchError struct {
+	// Errors are the failures ordered by index
+	Errors []*ItemError
+}
+
+func (e *BatchError) Error() string {
+	msgs := make([]string, 0, len(e.Errors))
+	for _, itemErr := range e.Errors {
+		msgs = append(msgs, itemErr.Error())
+	}
+	return fmt.Sprintf("%d items failed: %s", len(e.Errors), strings.Join(msgs, "; "))
+}
+
+// Add records the failure of the entity at index.
+func (e *BatchError) Add(index int, err error) {
+	e.Errors = append(e.Errors, &ItemError{Index: index, Err: err})
+}
+
+// ErrorOrNil returns e when any entity failed, otherwise nil.
+func (e *BatchError) ErrorOrNil() error {
+	if len(e.Errors) == 0 {
+		return nil
+	}
+	return e
+}
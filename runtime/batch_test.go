@@ -0,0 +1,34 @@
+package runtime_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/samlitowitz/protoc-gen-crud/runtime"
+)
+
+func TestBatchError(t *testing.T) {
+	batchErr := &runtime.BatchError{}
+	if err := batchErr.ErrorOrNil(); err != nil {
+		t.Fatalf("ErrorOrNil(): expected nil, got %v", err)
+	}
+
+	batchErr.Add(1, fmt.Errorf("%w: duplicate", runtime.ErrAlreadyExists))
+	batchErr.Add(3, fmt.Errorf("%w: conflict", runtime.ErrSerializationFailure))
+	err := batchErr.ErrorOrNil()
+	if err == nil {
+		t.Fatal("ErrorOrNil(): expected error")
+	}
+	expected := "2 items failed: item 1: already exists: duplicate; item 3: serialization failure: conflict"
+	if err.Error() != expected {
+		t.Fatalf("Error(): expected %q, got %q", expected, err.Error())
+	}
+	if !errors.Is(batchErr.Errors[0], runtime.ErrAlreadyExists) {
+		t.Fatalf("Errors[0]: expected %v", runtime.ErrAlreadyExists)
+	}
+	// a partially successful batch must not be retried
+	if errors.Is(err, runtime.ErrSerializationFailure) {
+		t.Fatalf("Is(): unexpected %v", runtime.ErrSerializationFailure)
+	}
+}
@@ -0,0 +1,18 @@
+package runtime
+
+import "time"
+
+// Clock tells the time at which entities are created, updated and deleted.
+type Clock interface {
+	Now() time.Time
+}
+
+// ClockFunc adapts a function to a Clock.
+type ClockFunc func() time.Time
+
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+// SystemClock is the Clock of the local system, it is used unless another is provided with WithClock.
+var SystemClock Clock = ClockFunc(time.Now)
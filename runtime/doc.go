@@ -0,0 +1,19 @@
+/*
+Package runtime contains the types shared by the generated repositories.
+
+Generated repositories accept a [Querier], either a *sql.DB or a *sql.Tx, so several repositories and hand written SQL
+can take part in a single transaction, e.g.
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = repo.WithTx(tx).Create(ctx, toCreate)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+*/
+package runtime
@@ -0,0 +1,74 @@
+package runtime
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// EnumName returns the name of value when it is an enum, binding an enum stored by its name, and value otherwise.
+// Numbers without a name are returned as their decimal representation, which is not a valid name.
+func EnumName(value any) any {
+	enum, ok := value.(protoreflect.Enum)
+	if !ok {
+		return value
+	}
+	if desc := enum.Descriptor().Values().ByNumber(enum.Number()); desc != nil {
+		return string(desc.Name())
+	}
+	return strconv.Itoa(int(enum.Number()))
+}
+
+// EnumByName returns a sql.Scanner scanning the name of an enum value into dest, values maps the names of the enum to
+// their numbers, e.g. the generated <Enum>_value map. NULL is scanned as the zero value of E.
+func EnumByName[E ~int32](dest *E, values map[string]int32) sql.Scanner {
+	return &enumByName[E]{dest: dest, values: values}
+}
+
+type enumByName[E ~int32] struct {
+	dest   *E
+	values map[string]int32
+}
+
+func (e *enumByName[E]) Scan(src any) error {
+	var name sql.Null[string]
+	if err := name.Scan(src); err != nil {
+		return err
+	}
+	if !name.Valid {
+		*e.dest = 0
+		return nil
+	}
+	number, ok := e.values[name.V]
+	if !ok {
+		return fmt.Errorf("unknown enum value name %q", name.V)
+	}
+	*e.dest = E(number)
+	return nil
+}
+
+// NullableEnumByName returns a sql.Scanner scanning the name of an enum value into dest, as EnumByName does, NULL being
+// scanned as nil, i.e. an unset optional field.
+func NullableEnumByName[E ~int32](dest **E, values map[string]int32) sql.Scanner {
+	return &nullableEnumByName[E]{dest: dest, values: values}
+}
+
+type nullableEnumByName[E ~int32] struct {
+	dest   **E
+	values map[string]int32
+}
+
+func (e *nullableEnumByName[E]) Scan(src any) error {
+	if src == nil {
+		*e.dest = nil
+		return nil
+	}
+	value := new(E)
+	if err := EnumByName(value, e.values).Scan(src); err != nil {
+		return err
+	}
+	*e.dest = value
+	return nil
+}
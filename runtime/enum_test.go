@@ -0,0 +1,63 @@
+package runtime_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	_ "modernc.org/sqlite"
+
+	"github.com/samlitowitz/protoc-gen-crud/runtime"
+)
+
+func TestEnumName(t *testing.T) {
+	if name := runtime.EnumName(descriptorpb.FieldDescriptorProto_TYPE_STRING); name != "TYPE_STRING" {
+		t.Fatalf("EnumName(): expected %q, got %v", "TYPE_STRING", name)
+	}
+	if name := runtime.EnumName(descriptorpb.FieldDescriptorProto_Type(99)); name != "99" {
+		t.Fatalf("EnumName(): unnamed: expected %q, got %v", "99", name)
+	}
+	if value := runtime.EnumName(int32(1)); value != int32(1) {
+		t.Fatalf("EnumName(): not an enum: expected %d, got %v", 1, value)
+	}
+}
+
+func TestEnumByName(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	typ := descriptorpb.FieldDescriptorProto_TYPE_BOOL
+	optionalTyp := &typ
+	err = db.QueryRow(`SELECT 'TYPE_STRING', 'TYPE_BYTES'`).Scan(
+		runtime.EnumByName(&typ, descriptorpb.FieldDescriptorProto_Type_value),
+		runtime.NullableEnumByName(&optionalTyp, descriptorpb.FieldDescriptorProto_Type_value),
+	)
+	if err != nil {
+		t.Fatalf("Scan(): %s", err)
+	}
+	if typ != descriptorpb.FieldDescriptorProto_TYPE_STRING || optionalTyp == nil || *optionalTyp != descriptorpb.FieldDescriptorProto_TYPE_BYTES {
+		t.Fatalf("Scan(): expected TYPE_STRING and TYPE_BYTES, got %v and %v", typ, optionalTyp)
+	}
+
+	err = db.QueryRow(`SELECT NULL, NULL`).Scan(
+		runtime.EnumByName(&typ, descriptorpb.FieldDescriptorProto_Type_value),
+		runtime.NullableEnumByName(&optionalTyp, descriptorpb.FieldDescriptorProto_Type_value),
+	)
+	if err != nil {
+		t.Fatalf("Scan(): NULL: %s", err)
+	}
+	if typ != 0 || optionalTyp != nil {
+		t.Fatalf("Scan(): NULL: expected zero value and nil, got %v and %v", typ, optionalTyp)
+	}
+
+	err = db.QueryRow(`SELECT 'TYPE_UNKNOWN'`).Scan(runtime.EnumByName(&typ, descriptorpb.FieldDescriptorProto_Type_value))
+	if err == nil {
+		t.Fatalf("Scan(): unknown name: expected error")
+	}
+}
@@ -0,0 +1,35 @@
+package runtime
+
+import (
+	"errors"
+)
+
+// These errors classify failures independently of the database driver.
+// Errors returned by generated repositories wrap both the classifying error and the original driver error, so
+// errors.Is matches these errors while errors.As still finds the driver error.
+var (
+	// ErrNotFound is returned when an entity does not exist
+	ErrNotFound = errors.New("not found")
+	// ErrAlreadyExists is returned when a primary key or unique constraint is violated
+	ErrAlreadyExists = errors.New("already exists")
+	// ErrForeignKeyViolation is returned when a foreign key constraint is violated
+	ErrForeignKeyViolation = errors.New("foreign key violation")
+	// ErrConstraintViolation is returned when a NOT NULL or CHECK constraint is violated
+	ErrConstraintViolation = errors.New("constraint violation")
+	// ErrSerializationFailure is returned when a transaction conflicts with a concurrent one, either failing to
+	// serialize or deadlocking, and may succeed if retried
+	ErrSerializationFailure = errors.New("serialization failure")
+	// ErrVersionConflict is returned when an entity was modified concurrently, i.e. its version no longer matches the
+	// stored version
+	ErrVersionConflict = errors.New("version conflict")
+)
+
+// Classified reports whether err is already classified by one of the errors above.
+func Classified(err error) bool {
+	return errors.Is(err, ErrNotFound) ||
+		errors.Is(err, ErrAlreadyExists) ||
+		errors.Is(err, ErrForeignKeyViolation) ||
+		errors.Is(err, ErrConstraintViolation) ||
+		errors.Is(err, ErrSerializationFailure) ||
+		errors.Is(err, ErrVersionConflict)
+}
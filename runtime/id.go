@@ -0,0 +1,50 @@
+package runtime
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// UUIDVersion is the version of the UUIDs generated for a field.
+type UUIDVersion int
+
+const (
+	// UUIDv4 is a random UUID.
+	UUIDv4 UUIDVersion = 4
+	// UUIDv7 is a time-ordered UUID.
+	UUIDv7 UUIDVersion = 7
+)
+
+// IDGenerator generates the values of fields auto-generated on create.
+type IDGenerator interface {
+	NewUUID(version UUIDVersion) (string, error)
+}
+
+// IDGeneratorFunc adapts a function to an IDGenerator.
+type IDGeneratorFunc func(version UUIDVersion) (string, error)
+
+func (f IDGeneratorFunc) NewUUID(version UUIDVersion) (string, error) {
+	return f(version)
+}
+
+// UUIDGenerator generates UUIDs with github.com/google/uuid, it is used unless another is provided with
+// WithIDGenerator.
+var UUIDGenerator IDGenerator = IDGeneratorFunc(func(version UUIDVersion) (string, error) {
+	var (
+		id  uuid.UUID
+		err error
+	)
+	switch version {
+	case UUIDv4:
+		id, err = uuid.NewRandom()
+	case UUIDv7:
+		id, err = uuid.NewV7()
+	default:
+		return "", fmt.Errorf("unsupported UUID version %d", version)
+	}
+	if err != nil {
+		return "", err
+	}
+	return id.String(), nil
+})
@@ -0,0 +1,61 @@
+package runtime
+
+import (
+	"database/sql"
+)
+
+// Nullable returns value when valid and nil, i.e. NULL, otherwise. It binds a field which tracks presence, valid
+// being whether the field is set.
+func Nullable[T any](valid bool, value T) any {
+	if !valid {
+		return nil
+	}
+	// drivers bind nil bytes as NULL, set bytes are bound as empty instead
+	if b, ok := any(value).([]byte); ok && b == nil {
+		return []byte{}
+	}
+	return value
+}
+
+// NullAsZero returns a sql.Scanner scanning into dest, NULL being scanned as the zero value of T.
+func NullAsZero[T any](dest *T) sql.Scanner {
+	return &nullAsZero[T]{dest: dest}
+}
+
+type nullAsZero[T any] struct {
+	dest *T
+}
+
+func (n *nullAsZero[T]) Scan(src any) error {
+	var value sql.Null[T]
+	if err := value.Scan(src); err != nil {
+		return err
+	}
+	*n.dest = value.V
+	return nil
+}
+
+// NullAsNil returns a sql.Scanner scanning into dest the value wrap builds from the scanned T, NULL being scanned as
+// the zero value of M, e.g. a nil wrapper type.
+func NullAsNil[T any, M any](dest *M, wrap func(T) M) sql.Scanner {
+	return &nullAsNil[T, M]{dest: dest, wrap: wrap}
+}
+
+type nullAsNil[T any, M any] struct {
+	dest *M
+	wrap func(T) M
+}
+
+func (n *nullAsNil[T, M]) Scan(src any) error {
+	var value sql.Null[T]
+	if err := value.Scan(src); err != nil {
+		return err
+	}
+	if !value.Valid {
+		var zero M
+		*n.dest = zero
+		return nil
+	}
+	*n.dest = n.wrap(value.V)
+	return nil
+}
@@ -0,0 +1,77 @@
+package runtime_test
+
+import (
+	"database/sql"
+	"testing"
+
+	_ "modernc.org/sqlite"
+
+	"github.com/samlitowitz/protoc-gen-crud/runtime"
+)
+
+func TestNullable(t *testing.T) {
+	if value := runtime.Nullable(false, "set"); value != nil {
+		t.Fatalf("Nullable(): unset: expected nil, got %v", value)
+	}
+	if value := runtime.Nullable(true, ""); value != "" {
+		t.Fatalf("Nullable(): set: expected %q, got %v", "", value)
+	}
+	if value, ok := runtime.Nullable(true, []byte(nil)).([]byte); !ok || value == nil {
+		t.Fatalf("Nullable(): set bytes: expected empty bytes, got %v", value)
+	}
+}
+
+func TestNullAsZero(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	name, count := "stale", int32(-1)
+	err = db.QueryRow(`SELECT NULL, NULL`).Scan(runtime.NullAsZero(&name), runtime.NullAsZero(&count))
+	if err != nil {
+		t.Fatalf("Scan(): NULL: %s", err)
+	}
+	if name != "" || count != 0 {
+		t.Fatalf("Scan(): NULL: expected zero values, got %q and %d", name, count)
+	}
+
+	err = db.QueryRow(`SELECT 'one', 1`).Scan(runtime.NullAsZero(&name), runtime.NullAsZero(&count))
+	if err != nil {
+		t.Fatalf("Scan(): %s", err)
+	}
+	if name != "one" || count != 1 {
+		t.Fatalf("Scan(): expected %q and %d, got %q and %d", "one", 1, name, count)
+	}
+}
+
+func TestNullAsNil(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	wrap := func(v string) *string { return &v }
+	name := wrap("stale")
+	err = db.QueryRow(`SELECT NULL`).Scan(runtime.NullAsNil(&name, wrap))
+	if err != nil {
+		t.Fatalf("Scan(): NULL: %s", err)
+	}
+	if name != nil {
+		t.Fatalf("Scan(): NULL: expected nil, got %q", *name)
+	}
+
+	err = db.QueryRow(`SELECT 'one'`).Scan(runtime.NullAsNil(&name, wrap))
+	if err != nil {
+		t.Fatalf("Scan(): %s", err)
+	}
+	if name == nil || *name != "one" {
+		t.Fatalf("Scan(): expected %q, got %v", "one", name)
+	}
+}
@@ -0,0 +1,81 @@
+package runtime
+
+import (
+	"database/sql"
+	"time"
+)
+
+// Options are the settings of a generated repository.
+type Options struct {
+	// IsolationLevel is the isolation level of the transactions begun by creates and updates
+	IsolationLevel sql.IsolationLevel
+	// RetryPolicy retries operations failing with ErrSerializationFailure, nil disables retries
+	RetryPolicy *RetryPolicy
+	// BatchMode determines whether creates and updates of several entities may partially succeed
+	BatchMode BatchMode
+	// Clock tells the created, updated and deleted at times of messages using the client clock, nil is SystemClock
+	Clock Clock
+	// IDGenerator generates the values of fields auto-generated on create, nil is UUIDGenerator
+	IDGenerator IDGenerator
+}
+
+// Now is the time told by the clock of the settings.
+func (o *Options) Now() time.Time {
+	if o.Clock == nil {
+		return SystemClock.Now()
+	}
+	return o.Clock.Now()
+}
+
+// NewUUID generates a UUID of version with the ID generator of the settings.
+func (o *Options) NewUUID(version UUIDVersion) (string, error) {
+	if o.IDGenerator == nil {
+		return UUIDGenerator.NewUUID(version)
+	}
+	return o.IDGenerator.NewUUID(version)
+}
+
+// Option configures a generated repository.
+type Option func(*Options)
+
+// Apply applies opts to the settings, generated repositories start with their message's defaults.
+func (o *Options) Apply(opts ...Option) {
+	for _, opt := range opts {
+		opt(o)
+	}
+}
+
+// WithIsolationLevel overrides the isolation level of the transactions begun by creates and updates.
+func WithIsolationLevel(level sql.IsolationLevel) Option {
+	return func(o *Options) {
+		o.IsolationLevel = level
+	}
+}
+
+// WithRetryPolicy retries operations failing with ErrSerializationFailure according to policy.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(o *Options) {
+		o.RetryPolicy = &policy
+	}
+}
+
+// WithBatchMode sets how creates and updates of several entities handle the failure of some of them.
+func WithBatchMode(mode BatchMode) Option {
+	return func(o *Options) {
+		o.BatchMode = mode
+	}
+}
+
+// WithClock replaces the clock telling the created, updated and deleted at times of messages using the client clock.
+func WithClock(clock Clock) Option {
+	return func(o *Options) {
+		o.Clock = clock
+	}
+}
+
+// WithIDGenerator replaces the generator of the values of fields auto-generated on create.
+func WithIDGenerator(generator IDGenerator) Option {
+	return func(o *Options) {
+		o.IDGenerator = generator
+	}
+}
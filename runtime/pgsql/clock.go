@@ -0,0 +1,20 @@
+package pgsql
+
+import (
+	"context"
+	"time"
+
+	"github.com/samlitowitz/protoc-gen-crud/runtime"
+)
+
+// Now tells the time of the database clock, within a transaction it is the time the transaction began.
+//
+// Messages taking their created, updated and deleted at times from the database clock use it instead of the clock of
+// the repository.
+func Now(ctx context.Context, q runtime.Querier) (time.Time, error) {
+	var now time.Time
+	if err := q.QueryRowContext(ctx, `SELECT now()`).Scan(&now); err != nil {
+		return time.Time{}, err
+	}
+	return now.UTC(), nil
+}
@@ -0,0 +1,48 @@
+// Package pgsql classifies PostgreSQL driver errors for the generated PgSQL repositories.
+package pgsql
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/samlitowitz/protoc-gen-crud/runtime"
+)
+
+// SQLSTATE codes which are classified
+const (
+	notNullViolation     = "23502"
+	foreignKeyViolation  = "23503"
+	uniqueViolation      = "23505"
+	checkViolation       = "23514"
+	serializationFailure = "40001"
+	deadlockDetected     = "40P01"
+)
+
+// WrapError wraps err with the [runtime] error classifying it, if any, keeping the original error in the chain.
+func WrapError(err error) error {
+	if err == nil || runtime.Classified(err) {
+		return err
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: %w", runtime.ErrNotFound, err)
+	}
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+	switch pgErr.Code {
+	case uniqueViolation:
+		return fmt.Errorf("%w: %w", runtime.ErrAlreadyExists, err)
+	case foreignKeyViolation:
+		return fmt.Errorf("%w: %w", runtime.ErrForeignKeyViolation, err)
+	case notNullViolation, checkViolation:
+		return fmt.Errorf("%w: %w", runtime.ErrConstraintViolation, err)
+	case serializationFailure, deadlockDetected:
+		return fmt.Errorf("%w: %w", runtime.ErrSerializationFailure, err)
+	default:
+		return err
+	}
+}
@@ -0,0 +1,64 @@
+package pgsql_test
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/samlitowitz/protoc-gen-crud/runtime"
+	"github.com/samlitowitz/protoc-gen-crud/runtime/pgsql"
+)
+
+func TestWrapError_ClassifiesDriverErrors(t *testing.T) {
+	testCases := map[string]struct {
+		err      error
+		expected error
+	}{
+		"no rows":               {err: sql.ErrNoRows, expected: runtime.ErrNotFound},
+		"unique violation":      {err: &pgconn.PgError{Code: "23505"}, expected: runtime.ErrAlreadyExists},
+		"foreign key violation": {err: &pgconn.PgError{Code: "23503"}, expected: runtime.ErrForeignKeyViolation},
+		"not null violation":    {err: &pgconn.PgError{Code: "23502"}, expected: runtime.ErrConstraintViolation},
+		"check violation":       {err: &pgconn.PgError{Code: "23514"}, expected: runtime.ErrConstraintViolation},
+		"serialization failure": {err: &pgconn.PgError{Code: "40001"}, expected: runtime.ErrSerializationFailure},
+		"deadlock detected":     {err: &pgconn.PgError{Code: "40P01"}, expected: runtime.ErrSerializationFailure},
+		"wrapped unique violation": {
+			err:      fmt.Errorf("insert: %w", &pgconn.PgError{Code: "23505"}),
+			expected: runtime.ErrAlreadyExists,
+		},
+	}
+
+	for testDesc, testCase := range testCases {
+		err := pgsql.WrapError(testCase.err)
+		if !errors.Is(err, testCase.expected) {
+			t.Fatalf("%s: WrapError(): expected %s, got %v", testDesc, testCase.expected, err)
+		}
+		if !errors.Is(err, testCase.err) {
+			t.Fatalf("%s: WrapError(): original error not wrapped: %v", testDesc, err)
+		}
+		if wrapped := pgsql.WrapError(err); wrapped != err {
+			t.Fatalf("%s: WrapError(): classified error wrapped again: %v", testDesc, wrapped)
+		}
+	}
+}
+
+func TestWrapError_KeepsDriverErrorInChain(t *testing.T) {
+	err := pgsql.WrapError(&pgconn.PgError{Code: "23505", Detail: "duplicate"})
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		t.Fatalf("WrapError(): expected *pgconn.PgError in chain, got %v", err)
+	}
+	if pgErr.Detail != "duplicate" {
+		t.Fatalf("WrapError(): expected detail %q, got %q", "duplicate", pgErr.Detail)
+	}
+}
+
+func TestWrapError_LeavesUnclassifiedErrors(t *testing.T) {
+	for _, err := range []error{nil, errors.New("other"), &pgconn.PgError{Code: "42P01"}} {
+		if wrapped := pgsql.WrapError(err); wrapped != err {
+			t.Fatalf("WrapError(%v): expected error unchanged, got %v", err, wrapped)
+		}
+	}
+}
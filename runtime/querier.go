@@ -0,0 +1,87 @@
+package runtime
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync/atomic"
+)
+
+var _ Querier = (*sql.DB)(nil)
+var _ Querier = (*sql.Tx)(nil)
+var _ Querier = (*sql.Conn)(nil)
+
+// Querier is implemented by *sql.DB, *sql.Conn and *sql.Tx.
+type Querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// Tx is a transaction begun by [BeginTx].
+type Tx interface {
+	Querier
+	Commit() error
+	Rollback() error
+}
+
+// txBeginner is implemented by *sql.DB and *sql.Conn.
+type txBeginner interface {
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+}
+
+var savepointSeq atomic.Uint64
+
+// BeginTx begins a transaction on q.
+//
+// When q cannot begin a transaction, e.g. it is a *sql.Tx, a savepoint is created within it instead and opts are
+// ignored. Committing the savepoint releases it and rolling it back undoes only the work done since it was created,
+// the enclosing transaction is left to its owner.
+func BeginTx(ctx context.Context, q Querier, opts *sql.TxOptions) (Tx, error) {
+	if beginner, ok := q.(txBeginner); ok {
+		tx, err := beginner.BeginTx(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		return tx, nil
+	}
+
+	name := fmt.Sprintf("protoc_gen_crud_%d", savepointSeq.Add(1))
+	if _, err := q.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+		return nil, err
+	}
+	return &savepoint{Querier: q, ctx: ctx, name: name}, nil
+}
+
+// savepoint is a nested transaction within an enclosing transaction.
+type savepoint struct {
+	Querier
+
+	ctx  context.Context
+	name string
+	done bool
+}
+
+func (sp *savepoint) Commit() error {
+	if sp.done {
+		return sql.ErrTxDone
+	}
+	sp.done = true
+	_, err := sp.ExecContext(sp.ctx, "RELEASE SAVEPOINT "+sp.name)
+	return err
+}
+
+func (sp *savepoint) Rollback() error {
+	if sp.done {
+		return sql.ErrTxDone
+	}
+	sp.done = true
+	// rolling back must succeed even when the context which began the savepoint is done
+	ctx := context.WithoutCancel(sp.ctx)
+	if _, err := sp.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+sp.name); err != nil {
+		return err
+	}
+	_, err := sp.ExecContext(ctx, "RELEASE SAVEPOINT "+sp.name)
+	return err
+}
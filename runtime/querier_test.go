@@ -0,0 +1,83 @@
+package runtime_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	_ "modernc.org/sqlite"
+
+	"github.com/samlitowitz/protoc-gen-crud/runtime"
+)
+
+func TestBeginTx_WithinTransactionUsesSavepoint(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+	// every connection to :memory: is a distinct database
+	db.SetMaxOpenConns(1)
+	if _, err := db.Exec(`CREATE TABLE "example" ("id" INTEGER PRIMARY KEY)`); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	tx, err := runtime.BeginTx(ctx, db, nil)
+	if err != nil {
+		t.Fatalf("BeginTx(): %s", err)
+	}
+	defer tx.Rollback()
+
+	committed, err := runtime.BeginTx(ctx, tx, nil)
+	if err != nil {
+		t.Fatalf("BeginTx(): nested: %s", err)
+	}
+	if _, err := committed.ExecContext(ctx, `INSERT INTO "example" ("id") VALUES (1)`); err != nil {
+		t.Fatal(err)
+	}
+	if err := committed.Commit(); err != nil {
+		t.Fatalf("Commit(): nested: %s", err)
+	}
+	if err := committed.Rollback(); !errors.Is(err, sql.ErrTxDone) {
+		t.Fatalf("Rollback(): nested: expected %s, got %v", sql.ErrTxDone, err)
+	}
+
+	rolledBack, err := runtime.BeginTx(ctx, tx, nil)
+	if err != nil {
+		t.Fatalf("BeginTx(): nested: %s", err)
+	}
+	if _, err := rolledBack.ExecContext(ctx, `INSERT INTO "example" ("id") VALUES (2)`); err != nil {
+		t.Fatal(err)
+	}
+	if err := rolledBack.Rollback(); err != nil {
+		t.Fatalf("Rollback(): nested: %s", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("Commit(): %s", err)
+	}
+
+	var ids []int
+	rows, err := db.Query(`SELECT "id" FROM "example" ORDER BY "id"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 1 || ids[0] != 1 {
+		t.Fatalf("expected only the committed savepoint's row [1], got %v", ids)
+	}
+}
@@ -0,0 +1,70 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+)
+
+// RetryPolicy retries transactions which fail with ErrSerializationFailure.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts including the first, values below one allow a single attempt
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry, it doubles for every subsequent retry
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay before a retry, zero is uncapped
+	MaxBackoff time.Duration
+	// Jitter is the fraction, between zero and one, of each delay which is randomized
+	Jitter float64
+}
+
+// DefaultRetryPolicy is a reasonable policy for transactions contending over a few rows.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    5,
+		InitialBackoff: 10 * time.Millisecond,
+		MaxBackoff:     time.Second,
+		Jitter:         0.5,
+	}
+}
+
+// Retry runs fn until it succeeds, fails with an error other than ErrSerializationFailure, the attempts are exhausted
+// or ctx is done. The error of the last attempt is returned.
+//
+// Only transactions begun on q are retried, when q is itself a transaction the failure aborted the enclosing
+// transaction and only its owner can retry it.
+func (p *RetryPolicy) Retry(ctx context.Context, q Querier, fn func() error) error {
+	if p == nil {
+		return fn()
+	}
+	if _, ok := q.(txBeginner); !ok {
+		return fn()
+	}
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || !errors.Is(err, ErrSerializationFailure) || attempt >= p.MaxAttempts {
+			return err
+		}
+		timer := time.NewTimer(p.backoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// backoff is the delay before the retry following attempt
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.InitialBackoff
+	for i := 1; i < attempt && (p.MaxBackoff == 0 || delay < p.MaxBackoff); i++ {
+		delay *= 2
+	}
+	if p.MaxBackoff > 0 && delay > p.MaxBackoff {
+		delay = p.MaxBackoff
+	}
+	jitter := min(max(p.Jitter, 0), 1)
+	return delay - time.Duration(jitter*rand.Float64()*float64(delay))
+}
@@ -0,0 +1,110 @@
+package runtime_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	_ "modernc.org/sqlite"
+
+	"github.com/samlitowitz/protoc-gen-crud/runtime"
+)
+
+func TestRetryPolicy_Retry(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+
+	serializationFailure := fmt.Errorf("%w: conflict", runtime.ErrSerializationFailure)
+	otherFailure := errors.New("other")
+	policy := &runtime.RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		Jitter:         0.5,
+	}
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	testCases := map[string]struct {
+		policy           *runtime.RetryPolicy
+		ctx              context.Context
+		querier          runtime.Querier
+		errs             []error
+		expectedAttempts int
+		expectedErr      error
+	}{
+		"nil policy": {
+			ctx:              context.Background(),
+			querier:          db,
+			errs:             []error{serializationFailure, nil},
+			expectedAttempts: 1,
+			expectedErr:      serializationFailure,
+		},
+		"success after serialization failures": {
+			policy:           policy,
+			ctx:              context.Background(),
+			querier:          db,
+			errs:             []error{serializationFailure, serializationFailure, nil},
+			expectedAttempts: 3,
+		},
+		"attempts exhausted": {
+			policy:           policy,
+			ctx:              context.Background(),
+			querier:          db,
+			errs:             []error{serializationFailure, serializationFailure, serializationFailure, nil},
+			expectedAttempts: 3,
+			expectedErr:      serializationFailure,
+		},
+		"other errors are not retried": {
+			policy:           policy,
+			ctx:              context.Background(),
+			querier:          db,
+			errs:             []error{otherFailure, nil},
+			expectedAttempts: 1,
+			expectedErr:      otherFailure,
+		},
+		"context done": {
+			policy:           policy,
+			ctx:              cancelled,
+			querier:          db,
+			errs:             []error{serializationFailure, nil},
+			expectedAttempts: 1,
+			expectedErr:      serializationFailure,
+		},
+		"within a transaction": {
+			policy:           policy,
+			ctx:              context.Background(),
+			querier:          tx,
+			errs:             []error{serializationFailure, nil},
+			expectedAttempts: 1,
+			expectedErr:      serializationFailure,
+		},
+	}
+
+	for testDesc, testCase := range testCases {
+		attempts := 0
+		err := testCase.policy.Retry(testCase.ctx, testCase.querier, func() error {
+			err := testCase.errs[attempts]
+			attempts++
+			return err
+		})
+		if !errors.Is(err, testCase.expectedErr) {
+			t.Fatalf("%s: Retry(): expected %v, got %v", testDesc, testCase.expectedErr, err)
+		}
+		if attempts != testCase.expectedAttempts {
+			t.Fatalf("%s: Retry(): expected %d attempts, got %d", testDesc, testCase.expectedAttempts, attempts)
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package sqlite
+
+import (
+	"context"
+	"time"
+
+	"github.com/samlitowitz/protoc-gen-crud/runtime"
+)
+
+// Now tells the time of the database clock, `CURRENT_TIMESTAMP`, to the second as timestamps are stored.
+//
+// Messages taking their created, updated and deleted at times from the database clock use it instead of the clock of
+// the repository.
+func Now(ctx context.Context, q runtime.Querier) (time.Time, error) {
+	var now string
+	if err := q.QueryRowContext(ctx, `SELECT strftime('%Y-%m-%dT%H:%M:%SZ', 'now')`).Scan(&now); err != nil {
+		return time.Time{}, err
+	}
+	return time.Parse(time.RFC3339, now)
+}
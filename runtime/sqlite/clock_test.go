@@ -0,0 +1,30 @@
+package sqlite_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	crudsqlite "github.com/samlitowitz/protoc-gen-crud/runtime/sqlite"
+)
+
+func TestNow(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	before := time.Now().UTC().Truncate(time.Second)
+	now, err := crudsqlite.Now(context.Background(), db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	after := time.Now().UTC()
+	if now.Before(before) || now.After(after) || now.Location() != time.UTC {
+		t.Fatalf("expected a UTC time between %s and %s, got %s", before, after, now)
+	}
+}
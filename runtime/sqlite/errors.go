@@ -0,0 +1,42 @@
+// Package sqlite classifies SQLite driver errors and begins transactions for the generated SQLite repositories.
+package sqlite
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"modernc.org/sqlite"
+	sqliteLib "modernc.org/sqlite/lib"
+
+	"github.com/samlitowitz/protoc-gen-crud/runtime"
+)
+
+// WrapError wraps err with the [runtime] error classifying it, if any, keeping the original error in the chain.
+func WrapError(err error) error {
+	if err == nil || runtime.Classified(err) {
+		return err
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: %w", runtime.ErrNotFound, err)
+	}
+	var sqliteErr *sqlite.Error
+	if !errors.As(err, &sqliteErr) {
+		return err
+	}
+	switch sqliteErr.Code() {
+	case sqliteLib.SQLITE_CONSTRAINT_PRIMARYKEY, sqliteLib.SQLITE_CONSTRAINT_UNIQUE:
+		return fmt.Errorf("%w: %w", runtime.ErrAlreadyExists, err)
+	case sqliteLib.SQLITE_CONSTRAINT_FOREIGNKEY:
+		return fmt.Errorf("%w: %w", runtime.ErrForeignKeyViolation, err)
+	case sqliteLib.SQLITE_CONSTRAINT_NOTNULL, sqliteLib.SQLITE_CONSTRAINT_CHECK:
+		return fmt.Errorf("%w: %w", runtime.ErrConstraintViolation, err)
+	}
+	// extended result codes share the low byte of their primary result code
+	switch sqliteErr.Code() & 0xff {
+	case sqliteLib.SQLITE_BUSY, sqliteLib.SQLITE_LOCKED:
+		return fmt.Errorf("%w: %w", runtime.ErrSerializationFailure, err)
+	default:
+		return err
+	}
+}
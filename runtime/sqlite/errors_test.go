@@ -0,0 +1,89 @@
+package sqlite_test
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"modernc.org/sqlite"
+
+	"github.com/samlitowitz/protoc-gen-crud/runtime"
+	crudsqlite "github.com/samlitowitz/protoc-gen-crud/runtime/sqlite"
+)
+
+func TestWrapError_ClassifiesDriverErrors(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+	// every connection to :memory: is a distinct database
+	db.SetMaxOpenConns(1)
+	for _, stmt := range []string{
+		`PRAGMA foreign_keys = ON`,
+		`CREATE TABLE "parent" ("id" INTEGER PRIMARY KEY, "name" TEXT UNIQUE)`,
+		`CREATE TABLE "child" ("id" INTEGER PRIMARY KEY, "parent_id" INTEGER REFERENCES "parent" ("id"))`,
+		`CREATE TABLE "item" ("id" INTEGER PRIMARY KEY, "name" TEXT NOT NULL, "price" REAL CHECK ("price" >= 0))`,
+		`INSERT INTO "parent" ("id", "name") VALUES (1, 'one')`,
+	} {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	testCases := map[string]struct {
+		stmt     string
+		expected error
+	}{
+		"primary key violation": {
+			stmt:     `INSERT INTO "parent" ("id", "name") VALUES (1, 'other')`,
+			expected: runtime.ErrAlreadyExists,
+		},
+		"unique violation": {
+			stmt:     `INSERT INTO "parent" ("id", "name") VALUES (2, 'one')`,
+			expected: runtime.ErrAlreadyExists,
+		},
+		"foreign key violation": {
+			stmt:     `INSERT INTO "child" ("id", "parent_id") VALUES (1, 2)`,
+			expected: runtime.ErrForeignKeyViolation,
+		},
+		"not null violation": {
+			stmt:     `INSERT INTO "item" ("id", "name") VALUES (1, NULL)`,
+			expected: runtime.ErrConstraintViolation,
+		},
+		"check violation": {
+			stmt:     `INSERT INTO "item" ("id", "name", "price") VALUES (1, 'one', -1)`,
+			expected: runtime.ErrConstraintViolation,
+		},
+	}
+
+	for testDesc, testCase := range testCases {
+		_, driverErr := db.Exec(testCase.stmt)
+		if driverErr == nil {
+			t.Fatalf("%s: expected error", testDesc)
+		}
+		err := crudsqlite.WrapError(driverErr)
+		if !errors.Is(err, testCase.expected) {
+			t.Fatalf("%s: WrapError(): expected %s, got %v", testDesc, testCase.expected, err)
+		}
+		var sqliteErr *sqlite.Error
+		if !errors.As(err, &sqliteErr) {
+			t.Fatalf("%s: WrapError(): expected *sqlite.Error in chain, got %v", testDesc, err)
+		}
+	}
+
+	err = crudsqlite.WrapError(db.QueryRow(`SELECT "id" FROM "parent" WHERE "id" = 2`).Scan(new(int)))
+	if !errors.Is(err, runtime.ErrNotFound) {
+		t.Fatalf("no rows: WrapError(): expected %s, got %v", runtime.ErrNotFound, err)
+	}
+}
+
+func TestWrapError_LeavesUnclassifiedErrors(t *testing.T) {
+	for _, err := range []error{nil, errors.New("other")} {
+		if wrapped := crudsqlite.WrapError(err); wrapped != err {
+			t.Fatalf("WrapError(%v): expected error unchanged, got %v", err, wrapped)
+		}
+	}
+}
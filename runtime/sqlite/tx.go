@@ -0,0 +1,119 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+
+	"github.com/samlitowitz/protoc-gen-crud/runtime"
+)
+
+// BeginTx begins a transaction on q, like [runtime.BeginTx], locking the database as the isolation level of opts
+// requires.
+//
+// SQLite transactions are always serializable and the driver ignores the requested isolation level. Instead,
+// serializable and linearizable read-write transactions begin IMMEDIATE, acquiring the write lock up front so they
+// cannot fail to upgrade a read lock once started, all others begin DEFERRED.
+func BeginTx(ctx context.Context, q runtime.Querier, opts *sql.TxOptions) (runtime.Tx, error) {
+	var conn *sql.Conn
+	owned := false
+	switch q := q.(type) {
+	case *sql.DB:
+		var err error
+		conn, err = q.Conn(ctx)
+		if err != nil {
+			return nil, err
+		}
+		owned = true
+	case *sql.Conn:
+		conn = q
+	default:
+		return runtime.BeginTx(ctx, q, opts)
+	}
+
+	if _, err := conn.ExecContext(ctx, "BEGIN "+beginMode(opts)); err != nil {
+		if owned {
+			_ = conn.Close()
+		}
+		return nil, err
+	}
+	return &tx{conn: conn, owned: owned, ctx: ctx}, nil
+}
+
+// beginMode is the SQLite transaction mode, DEFERRED or IMMEDIATE, used to begin a transaction with opts.
+func beginMode(opts *sql.TxOptions) string {
+	if opts == nil || opts.ReadOnly {
+		return "DEFERRED"
+	}
+	switch opts.Isolation {
+	case sql.LevelSerializable, sql.LevelLinearizable:
+		return "IMMEDIATE"
+	default:
+		return "DEFERRED"
+	}
+}
+
+// tx is a transaction begun on a dedicated connection.
+//
+// It deliberately does not expose the BeginTx method of the connection, further transactions begun on it are
+// savepoints within it.
+type tx struct {
+	conn  *sql.Conn
+	owned bool
+	ctx   context.Context
+	done  bool
+}
+
+func (t *tx) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return t.conn.ExecContext(ctx, query, args...)
+}
+
+func (t *tx) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return t.conn.PrepareContext(ctx, query)
+}
+
+func (t *tx) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return t.conn.QueryContext(ctx, query, args...)
+}
+
+func (t *tx) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	return t.conn.QueryRowContext(ctx, query, args...)
+}
+
+func (t *tx) Commit() error {
+	if t.done {
+		return sql.ErrTxDone
+	}
+	if _, err := t.conn.ExecContext(t.ctx, "COMMIT"); err != nil {
+		// a failed commit, e.g. SQLITE_BUSY, leaves the transaction open
+		_ = t.Rollback()
+		return err
+	}
+	t.done = true
+	return t.release(nil)
+}
+
+func (t *tx) Rollback() error {
+	if t.done {
+		return sql.ErrTxDone
+	}
+	t.done = true
+	// rolling back must succeed even when the context which began the transaction is done
+	_, err := t.conn.ExecContext(context.WithoutCancel(t.ctx), "ROLLBACK")
+	if releaseErr := t.release(err); releaseErr != nil {
+		return releaseErr
+	}
+	return err
+}
+
+// release returns an owned connection to the pool, discarding it when the transaction could not be ended
+func (t *tx) release(err error) error {
+	if !t.owned {
+		return nil
+	}
+	if err != nil {
+		_ = t.conn.Raw(func(any) error { return driver.ErrBadConn })
+		return nil
+	}
+	return t.conn.Close()
+}
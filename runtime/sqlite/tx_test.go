@@ -0,0 +1,119 @@
+package sqlite_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/samlitowitz/protoc-gen-crud/runtime"
+	crudsqlite "github.com/samlitowitz/protoc-gen-crud/runtime/sqlite"
+)
+
+func TestBeginTx_LocksAsIsolationLevelRequires(t *testing.T) {
+	// the lock held by a transaction is only observable from another connection to the same database
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "tx.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+	if _, err := db.Exec(`CREATE TABLE "example" ("id" INTEGER PRIMARY KEY)`); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	testCases := map[string]struct {
+		opts     *sql.TxOptions
+		expected error
+	}{
+		"default":        {},
+		"read committed": {opts: &sql.TxOptions{Isolation: sql.LevelReadCommitted}},
+		"read only":      {opts: &sql.TxOptions{Isolation: sql.LevelSerializable, ReadOnly: true}},
+		"serializable":   {opts: &sql.TxOptions{Isolation: sql.LevelSerializable}, expected: runtime.ErrSerializationFailure},
+		"linearizable":   {opts: &sql.TxOptions{Isolation: sql.LevelLinearizable}, expected: runtime.ErrSerializationFailure},
+	}
+
+	for testDesc, testCase := range testCases {
+		first, err := crudsqlite.BeginTx(ctx, db, testCase.opts)
+		if err != nil {
+			t.Fatalf("%s: BeginTx(): %s", testDesc, err)
+		}
+		second, err := crudsqlite.BeginTx(ctx, db, testCase.opts)
+		if !errors.Is(crudsqlite.WrapError(err), testCase.expected) {
+			t.Fatalf("%s: BeginTx(): concurrent: expected %v, got %v", testDesc, testCase.expected, err)
+		}
+		if err == nil {
+			if err := second.Rollback(); err != nil {
+				t.Fatalf("%s: Rollback(): concurrent: %s", testDesc, err)
+			}
+		}
+		if err := first.Rollback(); err != nil {
+			t.Fatalf("%s: Rollback(): %s", testDesc, err)
+		}
+	}
+}
+
+func TestBeginTx_ReleasesConnection(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+	// every connection to :memory: is a distinct database, a connection not returned to the pool blocks
+	db.SetMaxOpenConns(1)
+	if _, err := db.Exec(`CREATE TABLE "example" ("id" INTEGER PRIMARY KEY)`); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	opts := &sql.TxOptions{Isolation: sql.LevelSerializable}
+
+	committed, err := crudsqlite.BeginTx(ctx, db, opts)
+	if err != nil {
+		t.Fatalf("BeginTx(): %s", err)
+	}
+	if _, err := committed.ExecContext(ctx, `INSERT INTO "example" ("id") VALUES (1)`); err != nil {
+		t.Fatal(err)
+	}
+	// transactions begun within the transaction are savepoints
+	nested, err := crudsqlite.BeginTx(ctx, committed, opts)
+	if err != nil {
+		t.Fatalf("BeginTx(): nested: %s", err)
+	}
+	if _, err := nested.ExecContext(ctx, `INSERT INTO "example" ("id") VALUES (2)`); err != nil {
+		t.Fatal(err)
+	}
+	if err := nested.Rollback(); err != nil {
+		t.Fatalf("Rollback(): nested: %s", err)
+	}
+	if err := committed.Commit(); err != nil {
+		t.Fatalf("Commit(): %s", err)
+	}
+	if err := committed.Rollback(); !errors.Is(err, sql.ErrTxDone) {
+		t.Fatalf("Rollback(): expected %s, got %v", sql.ErrTxDone, err)
+	}
+
+	rolledBack, err := crudsqlite.BeginTx(ctx, db, opts)
+	if err != nil {
+		t.Fatalf("BeginTx(): %s", err)
+	}
+	if _, err := rolledBack.ExecContext(ctx, `INSERT INTO "example" ("id") VALUES (3)`); err != nil {
+		t.Fatal(err)
+	}
+	if err := rolledBack.Rollback(); err != nil {
+		t.Fatalf("Rollback(): %s", err)
+	}
+
+	var count int
+	if err := db.QueryRowContext(ctx, `SELECT COUNT(*) FROM "example"`).Scan(&count); err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Fatalf("expected 1 row, got %d", count)
+	}
+}
@@ -0,0 +1,314 @@
+package aggregate_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/samlitowitz/protoc-gen-crud/test-cases/aggregate"
+
+	"github.com/samlitowitz/protoc-gen-crud/options"
+	"github.com/samlitowitz/protoc-gen-crud/runtime"
+
+	"github.com/samlitowitz/expressions"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func seedSales(t *testing.T, repoDesc string, repo aggregate.SaleRepository) {
+	_, err := repo.Create(context.Background(), []*aggregate.Sale{
+		aggregate.Sale_builder{Id: 1, Region: "east", Quantity: 2, Price: 1.5}.Build(),
+		aggregate.Sale_builder{Id: 2, Region: "east", Quantity: 4, Price: 2.5}.Build(),
+		aggregate.Sale_builder{Id: 3, Region: "west", Quantity: 10, Price: 4}.Build(),
+		aggregate.Sale_builder{Id: 4, Region: "west", Quantity: 100, Price: 100}.Build(),
+	})
+	if err != nil {
+		t.Fatalf(
+			"%s: Create(): %s",
+			repoDesc,
+			err,
+		)
+	}
+	// soft deleted sales are neither counted nor aggregated
+	byID := expressions.NewEquals(
+		expressions.NewIdentifier(aggregate.Sale_Id_Field),
+		expressions.NewScalar(int32(4)),
+	)
+	if _, err := repo.Delete(context.Background(), byID); err != nil {
+		t.Fatalf(
+			"%s: Delete(): %s",
+			repoDesc,
+			err,
+		)
+	}
+}
+
+func TestSaleRepository_CountAndExists(t *testing.T) {
+	byRegion := func(region string) expressions.Expression {
+		return expressions.NewEquals(
+			expressions.NewIdentifier(aggregate.Sale_Region_Field),
+			expressions.NewScalar(region),
+		)
+	}
+	tests := map[string]struct {
+		expr          expressions.Expression
+		expectedCount int64
+	}{
+		"all": {
+			expr:          nil,
+			expectedCount: 3,
+		},
+		"matching": {
+			expr:          byRegion("west"),
+			expectedCount: 1,
+		},
+		"none matching": {
+			expr:          byRegion("north"),
+			expectedCount: 0,
+		},
+	}
+
+	for repoType, componentUnderTest := range aggregateImplementationsToTest() {
+		repoDesc := repoType.String()
+		// Call setup function, inject t *testing.T, and use t.Cleanup
+		repo := componentUnderTest(t)
+		if repo == nil {
+			t.Fatalf(
+				"%s: no implementation provided",
+				repoDesc,
+			)
+		}
+		seedSales(t, repoDesc, repo)
+
+		for testDesc, tc := range tests {
+			count, err := repo.Count(context.Background(), tc.expr)
+			if err != nil {
+				t.Fatalf(
+					"%s: %s: Count(): %s",
+					repoDesc,
+					testDesc,
+					err,
+				)
+			}
+			if count != tc.expectedCount {
+				t.Fatalf(
+					"%s: %s: Count(): expected %d, got %d",
+					repoDesc,
+					testDesc,
+					tc.expectedCount,
+					count,
+				)
+			}
+
+			exists, err := repo.Exists(context.Background(), tc.expr)
+			if err != nil {
+				t.Fatalf(
+					"%s: %s: Exists(): %s",
+					repoDesc,
+					testDesc,
+					err,
+				)
+			}
+			if exists != (tc.expectedCount > 0) {
+				t.Fatalf(
+					"%s: %s: Exists(): expected %t, got %t",
+					repoDesc,
+					testDesc,
+					tc.expectedCount > 0,
+					exists,
+				)
+			}
+		}
+	}
+}
+
+type aggregateResult struct {
+	Region string
+	Count  int64
+	Values []runtime.AggregateValue
+}
+
+func TestSaleRepository_Aggregate(t *testing.T) {
+	aggregates := []aggregate.SaleAggregate{
+		{Function: runtime.AggregateSum, Field: aggregate.Sale_Quantity_Field},
+		{Function: runtime.AggregateMax, Field: aggregate.Sale_Quantity_Field},
+		{Function: runtime.AggregateMin, Field: aggregate.Sale_Price_Field},
+		{Function: runtime.AggregateAvg, Field: aggregate.Sale_Quantity_Field},
+	}
+	tests := map[string]struct {
+		expr     expressions.Expression
+		opts     *aggregate.SaleAggregateOptions
+		expected []aggregateResult
+	}{
+		"single group": {
+			opts: &aggregate.SaleAggregateOptions{Aggregates: aggregates},
+			expected: []aggregateResult{
+				{
+					Count: 3,
+					Values: []runtime.AggregateValue{
+						{Int: 16, Valid: true},
+						{Int: 10, Valid: true},
+						{Float: 1.5, IsFloat: true, Valid: true},
+						{Float: 16.0 / 3.0, IsFloat: true, Valid: true},
+					},
+				},
+			},
+		},
+		"group by": {
+			opts: &aggregate.SaleAggregateOptions{
+				Aggregates: aggregates,
+				GroupBy:    []expressions.ID{aggregate.Sale_Region_Field},
+			},
+			expected: []aggregateResult{
+				{
+					Region: "east",
+					Count:  2,
+					Values: []runtime.AggregateValue{
+						{Int: 6, Valid: true},
+						{Int: 4, Valid: true},
+						{Float: 1.5, IsFloat: true, Valid: true},
+						{Float: 3, IsFloat: true, Valid: true},
+					},
+				},
+				{
+					Region: "west",
+					Count:  1,
+					Values: []runtime.AggregateValue{
+						{Int: 10, Valid: true},
+						{Int: 10, Valid: true},
+						{Float: 4, IsFloat: true, Valid: true},
+						{Float: 10, IsFloat: true, Valid: true},
+					},
+				},
+			},
+		},
+		"none matching": {
+			expr: expressions.NewEquals(
+				expressions.NewIdentifier(aggregate.Sale_Region_Field),
+				expressions.NewScalar("north"),
+			),
+			opts: &aggregate.SaleAggregateOptions{Aggregates: aggregates},
+			expected: []aggregateResult{
+				{
+					Count: 0,
+					Values: []runtime.AggregateValue{
+						{},
+						{},
+						{IsFloat: true},
+						{IsFloat: true},
+					},
+				},
+			},
+		},
+		"count only": {
+			expected: []aggregateResult{
+				{Count: 3, Values: []runtime.AggregateValue{}},
+			},
+		},
+	}
+
+	for repoType, componentUnderTest := range aggregateImplementationsToTest() {
+		repoDesc := repoType.String()
+		// Call setup function, inject t *testing.T, and use t.Cleanup
+		repo := componentUnderTest(t)
+		if repo == nil {
+			t.Fatalf(
+				"%s: no implementation provided",
+				repoDesc,
+			)
+		}
+		seedSales(t, repoDesc, repo)
+
+		for testDesc, tc := range tests {
+			res, err := repo.Aggregate(context.Background(), tc.expr, tc.opts)
+			if err != nil {
+				t.Fatalf(
+					"%s: %s: Aggregate(): %s",
+					repoDesc,
+					testDesc,
+					err,
+				)
+			}
+			var got []aggregateResult
+			for _, result := range res {
+				got = append(got, aggregateResult{
+					Region: result.Group.GetRegion(),
+					Count:  result.Count,
+					Values: result.Values,
+				})
+			}
+			if diff := cmp.Diff(tc.expected, got); diff != "" {
+				t.Fatal(
+					mismatch(
+						fmt.Sprintf(
+							"%s: %s: Aggregate():",
+							repoDesc,
+							testDesc,
+						),
+						diff,
+					),
+				)
+			}
+		}
+	}
+}
+
+func TestSaleRepository_Aggregate_NonNumericField(t *testing.T) {
+	for repoType, componentUnderTest := range aggregateImplementationsToTest() {
+		repoDesc := repoType.String()
+		// Call setup function, inject t *testing.T, and use t.Cleanup
+		repo := componentUnderTest(t)
+		if repo == nil {
+			t.Fatalf(
+				"%s: no implementation provided",
+				repoDesc,
+			)
+		}
+
+		_, err := repo.Aggregate(context.Background(), nil, &aggregate.SaleAggregateOptions{
+			Aggregates: []aggregate.SaleAggregate{
+				{Function: runtime.AggregateSum, Field: aggregate.Sale_Region_Field},
+			},
+		})
+		if err == nil {
+			t.Fatalf(
+				"%s: Aggregate(): expected error aggregating a non-numeric field",
+				repoDesc,
+			)
+		}
+	}
+}
+
+func TestSaleRepository_Aggregate_Unsigned64BitField(t *testing.T) {
+	for repoType, componentUnderTest := range aggregateImplementationsToTest() {
+		repoDesc := repoType.String()
+		// Call setup function, inject t *testing.T, and use t.Cleanup
+		repo := componentUnderTest(t)
+		if repo == nil {
+			t.Fatalf(
+				"%s: no implementation provided",
+				repoDesc,
+			)
+		}
+
+		// the aggregates of an unsigned 64 bit field may not fit an int64
+		_, err := repo.Aggregate(context.Background(), nil, &aggregate.SaleAggregateOptions{
+			Aggregates: []aggregate.SaleAggregate{
+				{Function: runtime.AggregateMax, Field: aggregate.Sale_Serial_Field},
+			},
+		})
+		if err == nil {
+			t.Fatalf(
+				"%s: Aggregate(): expected error aggregating an unsigned 64 bit field",
+				repoDesc,
+			)
+		}
+	}
+}
+
+func aggregateImplementationsToTest() map[options.Implementation]aggregateComponentUnderTest {
+	return map[options.Implementation]aggregateComponentUnderTest{
+		options.Implementation_IMPLEMENTATION_SQLITE: sqliteAggregateComponentUnderTest,
+		options.Implementation_IMPLEMENTATION_PGSQL:  pgsqlAggregateComponentUnderTest,
+	}
+}
@@ -0,0 +1,10 @@
+package aggregate_test
+
+import (
+	"testing"
+
+	"github.com/samlitowitz/protoc-gen-crud/test-cases/aggregate"
+)
+
+// aggregateComponentUnderTest is to be implemented to do setup and tear down for each implementation
+type aggregateComponentUnderTest func(t *testing.T) aggregate.SaleRepository
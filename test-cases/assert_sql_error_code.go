@@ -1,6 +1,7 @@
 package test_cases
 
 import (
+	"errors"
 	"testing"
 
 	"modernc.org/sqlite"
@@ -9,6 +10,7 @@ import (
 	"github.com/jackc/pgx/v5/pgconn"
 
 	"github.com/samlitowitz/protoc-gen-crud/options"
+	"github.com/samlitowitz/protoc-gen-crud/runtime"
 )
 
 func AssertSQLErrorCode(t *testing.T, typ options.Implementation, lut map[options.Implementation]any, err error, prefix string) {
@@ -17,8 +19,8 @@ func AssertSQLErrorCode(t *testing.T, typ options.Implementation, lut map[option
 	}
 	switch typ {
 	case options.Implementation_IMPLEMENTATION_PGSQL:
-		sqlErr, ok := err.(*pgconn.PgError)
-		if !ok {
+		var sqlErr *pgconn.PgError
+		if !errors.As(err, &sqlErr) {
 			t.Fatalf("%sexpected *pgconn.PgError, got %T", prefix, err)
 		}
 		expectedCode, ok := lut[typ].(string)
@@ -34,8 +36,8 @@ func AssertSQLErrorCode(t *testing.T, typ options.Implementation, lut map[option
 			)
 		}
 	case options.Implementation_IMPLEMENTATION_SQLITE:
-		sqlErr, ok := err.(*sqlite.Error)
-		if !ok {
+		var sqlErr *sqlite.Error
+		if !errors.As(err, &sqlErr) {
 			t.Fatalf("%sexpected *sqlite.Error, got %T", prefix, err)
 		}
 		if sqlErr.Code() != sqliteLib.SQLITE_CONSTRAINT_PRIMARYKEY {
@@ -44,4 +46,7 @@ func AssertSQLErrorCode(t *testing.T, typ options.Implementation, lut map[option
 	default:
 		t.Fatal(prefix, "unhandled implementation: ", typ.String())
 	}
+	if !errors.Is(err, runtime.ErrAlreadyExists) {
+		t.Fatalf("%sexpected %s, got %s", prefix, runtime.ErrAlreadyExists, err)
+	}
 }
@@ -0,0 +1,21 @@
+package auto_generate_test
+
+import (
+	"testing"
+
+	auto_generate "github.com/samlitowitz/protoc-gen-crud/test-cases/auto-generate"
+
+	"github.com/samlitowitz/protoc-gen-crud/runtime"
+)
+
+// uuidV4ComponentUnderTest is to be implemented to do setup and tear down for each implementation
+type uuidV4ComponentUnderTest func(t *testing.T, opts ...runtime.Option) auto_generate.UuidV4Repository
+
+// uuidV7ComponentUnderTest is to be implemented to do setup and tear down for each implementation
+type uuidV7ComponentUnderTest func(t *testing.T, opts ...runtime.Option) auto_generate.UuidV7Repository
+
+// sequentialComponentUnderTest is to be implemented to do setup and tear down for each implementation
+type sequentialComponentUnderTest func(t *testing.T) auto_generate.SequentialRepository
+
+// sequentialFieldMaskComponentUnderTest is to be implemented to do setup and tear down for each implementation
+type sequentialFieldMaskComponentUnderTest func(t *testing.T) auto_generate.SequentialFieldMaskRepository
@@ -0,0 +1,145 @@
+package auto_generate_test
+
+import (
+	"database/sql"
+	"os"
+	"testing"
+
+	auto_generate "github.com/samlitowitz/protoc-gen-crud/test-cases/auto-generate"
+
+	"github.com/samlitowitz/protoc-gen-crud/runtime"
+
+	test_cases "github.com/samlitowitz/protoc-gen-crud/test-cases"
+)
+
+func pgsqlUuidV4ComponentUnderTest(t *testing.T, opts ...runtime.Option) auto_generate.UuidV4Repository {
+	dburl, err := test_cases.PgSQLDBURLFromEnv()
+	if err != nil {
+		t.Fatal("pgsql: dburl: ", err)
+	}
+	db, err := sql.Open("pgx", dburl)
+	if err != nil {
+		t.Fatal("pgsql: ", err)
+	}
+	t.Cleanup(func() {
+		err := db.Close()
+		if err != nil {
+			t.Fatal("pgsql: ", err)
+		}
+	})
+
+	origDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal("pgsql: finding working dir:", err)
+	}
+
+	err = test_cases.PgSQLExecSQLFile(db, origDir+string(os.PathSeparator)+"test.pgsql.sql")
+	if err != nil {
+		t.Fatal("pgsql: executing setup SQL: ", err)
+	}
+
+	repo, err := auto_generate.NewPgSQLUuidV4Repository(db, opts...)
+	if err != nil {
+		t.Fatal("pgsql: creating repository: ", err)
+	}
+	return repo
+}
+
+func pgsqlUuidV7ComponentUnderTest(t *testing.T, opts ...runtime.Option) auto_generate.UuidV7Repository {
+	dburl, err := test_cases.PgSQLDBURLFromEnv()
+	if err != nil {
+		t.Fatal("pgsql: dburl: ", err)
+	}
+	db, err := sql.Open("pgx", dburl)
+	if err != nil {
+		t.Fatal("pgsql: ", err)
+	}
+	t.Cleanup(func() {
+		err := db.Close()
+		if err != nil {
+			t.Fatal("pgsql: ", err)
+		}
+	})
+
+	origDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal("pgsql: finding working dir:", err)
+	}
+
+	err = test_cases.PgSQLExecSQLFile(db, origDir+string(os.PathSeparator)+"test.pgsql.sql")
+	if err != nil {
+		t.Fatal("pgsql: executing setup SQL: ", err)
+	}
+
+	repo, err := auto_generate.NewPgSQLUuidV7Repository(db, opts...)
+	if err != nil {
+		t.Fatal("pgsql: creating repository: ", err)
+	}
+	return repo
+}
+
+func pgsqlSequentialComponentUnderTest(t *testing.T) auto_generate.SequentialRepository {
+	dburl, err := test_cases.PgSQLDBURLFromEnv()
+	if err != nil {
+		t.Fatal("pgsql: dburl: ", err)
+	}
+	db, err := sql.Open("pgx", dburl)
+	if err != nil {
+		t.Fatal("pgsql: ", err)
+	}
+	t.Cleanup(func() {
+		err := db.Close()
+		if err != nil {
+			t.Fatal("pgsql: ", err)
+		}
+	})
+
+	origDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal("pgsql: finding working dir:", err)
+	}
+
+	err = test_cases.PgSQLExecSQLFile(db, origDir+string(os.PathSeparator)+"test.pgsql.sql")
+	if err != nil {
+		t.Fatal("pgsql: executing setup SQL: ", err)
+	}
+
+	repo, err := auto_generate.NewPgSQLSequentialRepository(db)
+	if err != nil {
+		t.Fatal("pgsql: creating repository: ", err)
+	}
+	return repo
+}
+
+func pgsqlSequentialFieldMaskComponentUnderTest(t *testing.T) auto_generate.SequentialFieldMaskRepository {
+	dburl, err := test_cases.PgSQLDBURLFromEnv()
+	if err != nil {
+		t.Fatal("pgsql: dburl: ", err)
+	}
+	db, err := sql.Open("pgx", dburl)
+	if err != nil {
+		t.Fatal("pgsql: ", err)
+	}
+	t.Cleanup(func() {
+		err := db.Close()
+		if err != nil {
+			t.Fatal("pgsql: ", err)
+		}
+	})
+
+	origDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal("pgsql: finding working dir:", err)
+	}
+
+	err = test_cases.PgSQLExecSQLFile(db, origDir+string(os.PathSeparator)+"test.pgsql.sql")
+	if err != nil {
+		t.Fatal("pgsql: executing setup SQL: ", err)
+	}
+
+	repo, err := auto_generate.NewPgSQLSequentialFieldMaskRepository(db)
+	if err != nil {
+		t.Fatal("pgsql: creating repository: ", err)
+	}
+	return repo
+}
@@ -0,0 +1,203 @@
+package auto_generate_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	auto_generate "github.com/samlitowitz/protoc-gen-crud/test-cases/auto-generate"
+
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	"github.com/samlitowitz/protoc-gen-crud/options"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestSequentialRepository_Create_AssignsUnsetIdentifiers(t *testing.T) {
+	opts := sequentialDefaultCmpOpts()
+	for repoType, componentUnderTest := range sequentialImplementationsToTest() {
+		repoDesc := repoType.String()
+		// Call setup function, inject t *testing.T, and use t.Cleanup
+		repoImpl := componentUnderTest(t)
+		if repoImpl == nil {
+			t.Fatalf(
+				"%s: no implementation provided",
+				repoDesc,
+			)
+		}
+
+		toCreate := []*auto_generate.Sequential{
+			auto_generate.Sequential_builder{Data: "zero"}.Build(),
+			auto_generate.Sequential_builder{Data: "one"}.Build(),
+			auto_generate.Sequential_builder{Data: "two"}.Build(),
+		}
+		created, err := repoImpl.Create(context.Background(), toCreate)
+		if err != nil {
+			t.Fatalf(
+				"%s: Create(): %s",
+				repoDesc,
+				err,
+			)
+		}
+		if len(created) != len(toCreate) {
+			t.Fatalf(
+				"%s: Create(): expected %d items, got %d items",
+				repoDesc,
+				len(toCreate),
+				len(created),
+			)
+		}
+		for i, sequential := range created {
+			if sequential.GetId() == 0 {
+				t.Fatalf(
+					"%s: Create(): item %d: identifier not assigned",
+					repoDesc,
+					i,
+				)
+			}
+			if i > 0 && sequential.GetId() <= created[i-1].GetId() {
+				t.Fatalf(
+					"%s: Create(): item %d: expected identifier greater than %d, got %d",
+					repoDesc,
+					i,
+					created[i-1].GetId(),
+					sequential.GetId(),
+				)
+			}
+		}
+
+		res, err := repoImpl.Read(context.Background(), nil)
+		if err != nil {
+			t.Fatalf(
+				"%s: Read(): %s",
+				repoDesc,
+				err,
+			)
+		}
+		if diff := cmp.Diff(created, res, opts); diff != "" {
+			t.Fatal(
+				mismatch(
+					fmt.Sprintf(
+						"%s: Read():",
+						repoDesc,
+					),
+					diff,
+				),
+			)
+		}
+		dataByID := make(map[int64]string, len(res))
+		for _, sequential := range res {
+			dataByID[sequential.GetId()] = sequential.GetData()
+		}
+		for _, sequential := range created {
+			data, ok := dataByID[sequential.GetId()]
+			if !ok || data != sequential.GetData() {
+				t.Fatalf(
+					"%s: Read(): %d: expected %q, got %q",
+					repoDesc,
+					sequential.GetId(),
+					sequential.GetData(),
+					data,
+				)
+			}
+		}
+	}
+}
+
+func TestSequentialFieldMaskRepository_Create_AssignsUnsetIdentifiers(t *testing.T) {
+	for repoType, componentUnderTest := range sequentialFieldMaskImplementationsToTest() {
+		repoDesc := repoType.String()
+		// Call setup function, inject t *testing.T, and use t.Cleanup
+		repoImpl := componentUnderTest(t)
+		if repoImpl == nil {
+			t.Fatalf(
+				"%s: no implementation provided",
+				repoDesc,
+			)
+		}
+
+		toCreate := []*auto_generate.SequentialFieldMask{
+			auto_generate.SequentialFieldMask_builder{
+				FieldMask: &fieldmaskpb.FieldMask{Paths: []string{"data"}},
+				Data:      "masked",
+			}.Build(),
+			auto_generate.SequentialFieldMask_builder{
+				Data: "unmasked",
+			}.Build(),
+		}
+		created, err := repoImpl.Create(context.Background(), toCreate)
+		if err != nil {
+			t.Fatalf(
+				"%s: Create(): %s",
+				repoDesc,
+				err,
+			)
+		}
+		if len(created) != len(toCreate) {
+			t.Fatalf(
+				"%s: Create(): expected %d items, got %d items",
+				repoDesc,
+				len(toCreate),
+				len(created),
+			)
+		}
+		if created[0].GetId() == 0 || created[1].GetId() == 0 || created[0].GetId() == created[1].GetId() {
+			t.Fatalf(
+				"%s: Create(): expected distinct assigned identifiers, got %d and %d",
+				repoDesc,
+				created[0].GetId(),
+				created[1].GetId(),
+			)
+		}
+
+		res, err := repoImpl.Read(context.Background(), nil)
+		if err != nil {
+			t.Fatalf(
+				"%s: Read(): %s",
+				repoDesc,
+				err,
+			)
+		}
+		dataByID := make(map[int64]string, len(res))
+		for _, sequentialFieldMask := range res {
+			dataByID[sequentialFieldMask.GetId()] = sequentialFieldMask.GetData()
+		}
+		for _, sequentialFieldMask := range created {
+			data, ok := dataByID[sequentialFieldMask.GetId()]
+			if !ok || data != sequentialFieldMask.GetData() {
+				t.Fatalf(
+					"%s: Read(): %d: expected %q, got %q",
+					repoDesc,
+					sequentialFieldMask.GetId(),
+					sequentialFieldMask.GetData(),
+					data,
+				)
+			}
+		}
+	}
+}
+
+func sequentialImplementationsToTest() map[options.Implementation]sequentialComponentUnderTest {
+	return map[options.Implementation]sequentialComponentUnderTest{
+		options.Implementation_IMPLEMENTATION_SQLITE: sqliteSequentialComponentUnderTest,
+		options.Implementation_IMPLEMENTATION_PGSQL:  pgsqlSequentialComponentUnderTest,
+	}
+}
+
+func sequentialDefaultCmpOpts() cmp.Options {
+	return cmp.Options{
+		cmpopts.IgnoreUnexported(auto_generate.Sequential{}),
+		cmpopts.SortSlices(func(x, y *auto_generate.Sequential) bool {
+			return x.GetId() < y.GetId()
+		}),
+	}
+}
+
+func sequentialFieldMaskImplementationsToTest() map[options.Implementation]sequentialFieldMaskComponentUnderTest {
+	return map[options.Implementation]sequentialFieldMaskComponentUnderTest{
+		options.Implementation_IMPLEMENTATION_SQLITE: sqliteSequentialFieldMaskComponentUnderTest,
+		options.Implementation_IMPLEMENTATION_PGSQL:  pgsqlSequentialFieldMaskComponentUnderTest,
+	}
+}
@@ -0,0 +1,143 @@
+package auto_generate_test
+
+import (
+	"database/sql"
+	"os"
+	"testing"
+
+	auto_generate "github.com/samlitowitz/protoc-gen-crud/test-cases/auto-generate"
+
+	"github.com/samlitowitz/protoc-gen-crud/runtime"
+)
+
+func sqliteExecSQLFile(db *sql.DB, file string) error {
+	code, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	stmt, err := db.Prepare(string(code))
+	if err != nil {
+		return err
+	}
+	_, err = stmt.Exec()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func sqliteUuidV4ComponentUnderTest(t *testing.T, opts ...runtime.Option) auto_generate.UuidV4Repository {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal("sqlite: ", err)
+	}
+	t.Cleanup(func() {
+		err := db.Close()
+		if err != nil {
+			t.Fatal("sqlite: ", err)
+		}
+	})
+
+	origDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal("sqlite: finding working dir:", err)
+	}
+
+	err = sqliteExecSQLFile(db, origDir+string(os.PathSeparator)+"test.sqlite.sql")
+	if err != nil {
+		t.Fatal("sqlite: executing setup SQL: ", err)
+	}
+
+	repo, err := auto_generate.NewSQLiteUuidV4Repository(db, opts...)
+	if err != nil {
+		t.Fatal("sqlite: creating repository: ", err)
+	}
+	return repo
+}
+
+func sqliteUuidV7ComponentUnderTest(t *testing.T, opts ...runtime.Option) auto_generate.UuidV7Repository {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal("sqlite: ", err)
+	}
+	t.Cleanup(func() {
+		err := db.Close()
+		if err != nil {
+			t.Fatal("sqlite: ", err)
+		}
+	})
+
+	origDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal("sqlite: finding working dir:", err)
+	}
+
+	err = sqliteExecSQLFile(db, origDir+string(os.PathSeparator)+"test.sqlite.sql")
+	if err != nil {
+		t.Fatal("sqlite: executing setup SQL: ", err)
+	}
+
+	repo, err := auto_generate.NewSQLiteUuidV7Repository(db, opts...)
+	if err != nil {
+		t.Fatal("sqlite: creating repository: ", err)
+	}
+	return repo
+}
+
+func sqliteSequentialComponentUnderTest(t *testing.T) auto_generate.SequentialRepository {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal("sqlite: ", err)
+	}
+	t.Cleanup(func() {
+		err := db.Close()
+		if err != nil {
+			t.Fatal("sqlite: ", err)
+		}
+	})
+
+	origDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal("sqlite: finding working dir:", err)
+	}
+
+	err = sqliteExecSQLFile(db, origDir+string(os.PathSeparator)+"test.sqlite.sql")
+	if err != nil {
+		t.Fatal("sqlite: executing setup SQL: ", err)
+	}
+
+	repo, err := auto_generate.NewSQLiteSequentialRepository(db)
+	if err != nil {
+		t.Fatal("sqlite: creating repository: ", err)
+	}
+	return repo
+}
+
+func sqliteSequentialFieldMaskComponentUnderTest(t *testing.T) auto_generate.SequentialFieldMaskRepository {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal("sqlite: ", err)
+	}
+	t.Cleanup(func() {
+		err := db.Close()
+		if err != nil {
+			t.Fatal("sqlite: ", err)
+		}
+	})
+
+	origDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal("sqlite: finding working dir:", err)
+	}
+
+	err = sqliteExecSQLFile(db, origDir+string(os.PathSeparator)+"test.sqlite.sql")
+	if err != nil {
+		t.Fatal("sqlite: executing setup SQL: ", err)
+	}
+
+	repo, err := auto_generate.NewSQLiteSequentialFieldMaskRepository(db)
+	if err != nil {
+		t.Fatal("sqlite: creating repository: ", err)
+	}
+	return repo
+}
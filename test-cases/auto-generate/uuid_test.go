@@ -0,0 +1,264 @@
+package auto_generate_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	auto_generate "github.com/samlitowitz/protoc-gen-crud/test-cases/auto-generate"
+
+	"github.com/samlitowitz/protoc-gen-crud/options"
+	"github.com/samlitowitz/protoc-gen-crud/runtime"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/uuid"
+)
+
+func TestUuidV4Repository_Create_GeneratesUnsetIdentifiers(t *testing.T) {
+	opts := uuidV4DefaultCmpOpts()
+	for repoType, componentUnderTest := range uuidV4ImplementationsToTest() {
+		repoDesc := repoType.String()
+		// Call setup function, inject t *testing.T, and use t.Cleanup
+		repoImpl := componentUnderTest(t)
+		if repoImpl == nil {
+			t.Fatalf(
+				"%s: no implementation provided",
+				repoDesc,
+			)
+		}
+
+		provided := uuid.NewString()
+		toCreate := []*auto_generate.UuidV4{
+			auto_generate.UuidV4_builder{Data: "zero"}.Build(),
+			auto_generate.UuidV4_builder{Data: "one"}.Build(),
+			auto_generate.UuidV4_builder{Id: provided, Data: "two"}.Build(),
+		}
+		created, err := repoImpl.Create(context.Background(), toCreate)
+		if err != nil {
+			t.Fatalf(
+				"%s: Create(): %s",
+				repoDesc,
+				err,
+			)
+		}
+		if len(created) != len(toCreate) {
+			t.Fatalf(
+				"%s: Create(): expected %d items, got %d items",
+				repoDesc,
+				len(toCreate),
+				len(created),
+			)
+		}
+		if created[2].GetId() != provided {
+			t.Fatalf(
+				"%s: Create(): provided identifier replaced: expected %s, got %s",
+				repoDesc,
+				provided,
+				created[2].GetId(),
+			)
+		}
+		for _, uuidV4 := range created[:2] {
+			id, err := uuid.Parse(uuidV4.GetId())
+			if err != nil {
+				t.Fatalf(
+					"%s: Create(): invalid identifier %q: %s",
+					repoDesc,
+					uuidV4.GetId(),
+					err,
+				)
+			}
+			if id.Version() != 4 {
+				t.Fatalf(
+					"%s: Create(): expected version 4 UUID, got version %d",
+					repoDesc,
+					id.Version(),
+				)
+			}
+		}
+		if created[0].GetId() == created[1].GetId() {
+			t.Fatalf(
+				"%s: Create(): duplicate identifier generated: %s",
+				repoDesc,
+				created[0].GetId(),
+			)
+		}
+
+		res, err := repoImpl.Read(context.Background(), nil)
+		if err != nil {
+			t.Fatalf(
+				"%s: Read(): %s",
+				repoDesc,
+				err,
+			)
+		}
+		if diff := cmp.Diff(created, res, opts); diff != "" {
+			t.Fatal(
+				mismatch(
+					fmt.Sprintf(
+						"%s: Read():",
+						repoDesc,
+					),
+					diff,
+				),
+			)
+		}
+	}
+}
+
+func TestUuidV7Repository_Create_GeneratesUnsetIdentifiers(t *testing.T) {
+	opts := uuidV7DefaultCmpOpts()
+	for repoType, componentUnderTest := range uuidV7ImplementationsToTest() {
+		repoDesc := repoType.String()
+		// Call setup function, inject t *testing.T, and use t.Cleanup
+		repoImpl := componentUnderTest(t)
+		if repoImpl == nil {
+			t.Fatalf(
+				"%s: no implementation provided",
+				repoDesc,
+			)
+		}
+
+		toCreate := []*auto_generate.UuidV7{
+			auto_generate.UuidV7_builder{Data: "zero"}.Build(),
+			auto_generate.UuidV7_builder{Data: "one"}.Build(),
+		}
+		created, err := repoImpl.Create(context.Background(), toCreate)
+		if err != nil {
+			t.Fatalf(
+				"%s: Create(): %s",
+				repoDesc,
+				err,
+			)
+		}
+		if len(created) != len(toCreate) {
+			t.Fatalf(
+				"%s: Create(): expected %d items, got %d items",
+				repoDesc,
+				len(toCreate),
+				len(created),
+			)
+		}
+		for _, uuidV7 := range created {
+			id, err := uuid.Parse(uuidV7.GetId())
+			if err != nil {
+				t.Fatalf(
+					"%s: Create(): invalid identifier %q: %s",
+					repoDesc,
+					uuidV7.GetId(),
+					err,
+				)
+			}
+			if id.Version() != 7 {
+				t.Fatalf(
+					"%s: Create(): expected version 7 UUID, got version %d",
+					repoDesc,
+					id.Version(),
+				)
+			}
+		}
+		// version 7 UUIDs are time-ordered
+		if created[0].GetId() >= created[1].GetId() {
+			t.Fatalf(
+				"%s: Create(): expected %s to sort before %s",
+				repoDesc,
+				created[0].GetId(),
+				created[1].GetId(),
+			)
+		}
+
+		res, err := repoImpl.Read(context.Background(), nil)
+		if err != nil {
+			t.Fatalf(
+				"%s: Read(): %s",
+				repoDesc,
+				err,
+			)
+		}
+		if diff := cmp.Diff(created, res, opts); diff != "" {
+			t.Fatal(
+				mismatch(
+					fmt.Sprintf(
+						"%s: Read():",
+						repoDesc,
+					),
+					diff,
+				),
+			)
+		}
+	}
+}
+
+func TestUuidV7Repository_Create_UsesIDGenerator(t *testing.T) {
+	for repoType, componentUnderTest := range uuidV7ImplementationsToTest() {
+		repoDesc := repoType.String()
+		var versions []runtime.UUIDVersion
+		generator := runtime.IDGeneratorFunc(func(version runtime.UUIDVersion) (string, error) {
+			versions = append(versions, version)
+			return fmt.Sprintf("00000000-0000-7000-8000-%012d", len(versions)), nil
+		})
+		// Call setup function, inject t *testing.T, and use t.Cleanup
+		repoImpl := componentUnderTest(t, runtime.WithIDGenerator(generator))
+		if repoImpl == nil {
+			t.Fatalf(
+				"%s: no implementation provided",
+				repoDesc,
+			)
+		}
+
+		created, err := repoImpl.Create(context.Background(), []*auto_generate.UuidV7{
+			auto_generate.UuidV7_builder{Data: "zero"}.Build(),
+			auto_generate.UuidV7_builder{Data: "one"}.Build(),
+		})
+		if err != nil {
+			t.Fatalf(
+				"%s: Create(): %s",
+				repoDesc,
+				err,
+			)
+		}
+
+		expected := []*auto_generate.UuidV7{
+			auto_generate.UuidV7_builder{Id: "00000000-0000-7000-8000-000000000001", Data: "zero"}.Build(),
+			auto_generate.UuidV7_builder{Id: "00000000-0000-7000-8000-000000000002", Data: "one"}.Build(),
+		}
+		if diff := cmp.Diff(expected, created, uuidV7DefaultCmpOpts()); diff != "" {
+			t.Fatal(mismatch(fmt.Sprintf("%s: Create():", repoDesc), diff))
+		}
+		if diff := cmp.Diff([]runtime.UUIDVersion{runtime.UUIDv7, runtime.UUIDv7}, versions); diff != "" {
+			t.Fatal(mismatch(fmt.Sprintf("%s: Create(): versions generated:", repoDesc), diff))
+		}
+	}
+}
+
+func uuidV4ImplementationsToTest() map[options.Implementation]uuidV4ComponentUnderTest {
+	return map[options.Implementation]uuidV4ComponentUnderTest{
+		options.Implementation_IMPLEMENTATION_SQLITE: sqliteUuidV4ComponentUnderTest,
+		options.Implementation_IMPLEMENTATION_PGSQL:  pgsqlUuidV4ComponentUnderTest,
+	}
+}
+
+func uuidV4DefaultCmpOpts() cmp.Options {
+	return cmp.Options{
+		cmpopts.IgnoreUnexported(auto_generate.UuidV4{}),
+		cmpopts.SortSlices(func(x, y *auto_generate.UuidV4) bool {
+			return x.GetId() < y.GetId()
+		}),
+	}
+}
+
+func uuidV7ImplementationsToTest() map[options.Implementation]uuidV7ComponentUnderTest {
+	return map[options.Implementation]uuidV7ComponentUnderTest{
+		options.Implementation_IMPLEMENTATION_SQLITE: sqliteUuidV7ComponentUnderTest,
+		options.Implementation_IMPLEMENTATION_PGSQL:  pgsqlUuidV7ComponentUnderTest,
+	}
+}
+
+func uuidV7DefaultCmpOpts() cmp.Options {
+	return cmp.Options{
+		cmpopts.IgnoreUnexported(auto_generate.UuidV7{}),
+		cmpopts.SortSlices(func(x, y *auto_generate.UuidV7) bool {
+			return x.GetId() < y.GetId()
+		}),
+	}
+}
@@ -0,0 +1,212 @@
+package batch_mode_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	batch_mode "github.com/samlitowitz/protoc-gen-crud/test-cases/batch-mode"
+
+	"github.com/samlitowitz/protoc-gen-crud/options"
+	"github.com/samlitowitz/protoc-gen-crud/runtime"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func readData(t *testing.T, repoDesc string, repo batch_mode.ItemRepository) []string {
+	res, err := repo.Read(context.Background(), nil)
+	if err != nil {
+		t.Fatalf(
+			"%s: Read(): %s",
+			repoDesc,
+			err,
+		)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].GetId() < res[j].GetId() })
+	var data []string
+	for _, item := range res {
+		data = append(data, item.GetData())
+	}
+	return data
+}
+
+func TestItemRepository_Create_BatchMode(t *testing.T) {
+	tests := map[string]struct {
+		opts            []runtime.Option
+		expectedCreated []int32
+		expectedIndexes []int
+		expectedData    []string
+	}{
+		"all or nothing": {
+			expectedData: []string{"existing"},
+		},
+		"partial success": {
+			opts:            []runtime.Option{runtime.WithBatchMode(runtime.BatchPartialSuccess)},
+			expectedCreated: []int32{2, 0, 3},
+			expectedIndexes: []int{1},
+			expectedData:    []string{"existing", "two", "three"},
+		},
+	}
+
+	for repoType, componentUnderTest := range batchModeImplementationsToTest() {
+		repoDesc := repoType.String()
+		for testDesc, tc := range tests {
+			// Call setup function, inject t *testing.T, and use t.Cleanup
+			repo := componentUnderTest(t, tc.opts...)
+			if repo == nil {
+				t.Fatalf(
+					"%s: no implementation provided",
+					repoDesc,
+				)
+			}
+
+			_, err := repo.Create(context.Background(), []*batch_mode.Item{
+				batch_mode.Item_builder{Id: 1, Data: "existing"}.Build(),
+			})
+			if err != nil {
+				t.Fatalf(
+					"%s: %s: Create(): %s",
+					repoDesc,
+					testDesc,
+					err,
+				)
+			}
+
+			created, err := repo.Create(context.Background(), []*batch_mode.Item{
+				batch_mode.Item_builder{Id: 2, Data: "two"}.Build(),
+				batch_mode.Item_builder{Id: 1, Data: "duplicate"}.Build(),
+				batch_mode.Item_builder{Id: 3, Data: "three"}.Build(),
+			})
+			if err == nil {
+				t.Fatalf(
+					"%s: %s: Create(): expected error",
+					repoDesc,
+					testDesc,
+				)
+			}
+
+			var batchErr *runtime.BatchError
+			if errors.As(err, &batchErr) != (tc.expectedIndexes != nil) {
+				t.Fatalf(
+					"%s: %s: Create(): unexpected error %v",
+					repoDesc,
+					testDesc,
+					err,
+				)
+			}
+			if batchErr != nil {
+				var indexes []int
+				for _, itemErr := range batchErr.Errors {
+					indexes = append(indexes, itemErr.Index)
+					if !errors.Is(itemErr, runtime.ErrAlreadyExists) {
+						t.Fatalf(
+							"%s: %s: Create(): item %d: expected %v, got %v",
+							repoDesc,
+							testDesc,
+							itemErr.Index,
+							runtime.ErrAlreadyExists,
+							itemErr.Err,
+						)
+					}
+				}
+				if diff := cmp.Diff(tc.expectedIndexes, indexes); diff != "" {
+					t.Fatal(mismatch(fmt.Sprintf("%s: %s: Create(): failed indexes:", repoDesc, testDesc), diff))
+				}
+				var createdIDs []int32
+				for _, item := range created {
+					createdIDs = append(createdIDs, item.GetId())
+				}
+				if diff := cmp.Diff(tc.expectedCreated, createdIDs); diff != "" {
+					t.Fatal(mismatch(fmt.Sprintf("%s: %s: Create(): created:", repoDesc, testDesc), diff))
+				}
+			}
+
+			if diff := cmp.Diff(tc.expectedData, readData(t, repoDesc, repo)); diff != "" {
+				t.Fatal(mismatch(fmt.Sprintf("%s: %s: Read():", repoDesc, testDesc), diff))
+			}
+		}
+	}
+}
+
+func TestItemRepository_Update_PartialSuccess(t *testing.T) {
+	for repoType, componentUnderTest := range batchModeImplementationsToTest() {
+		repoDesc := repoType.String()
+		// Call setup function, inject t *testing.T, and use t.Cleanup
+		repo := componentUnderTest(t, runtime.WithBatchMode(runtime.BatchPartialSuccess))
+		if repo == nil {
+			t.Fatalf(
+				"%s: no implementation provided",
+				repoDesc,
+			)
+		}
+
+		_, err := repo.Create(context.Background(), []*batch_mode.Item{
+			batch_mode.Item_builder{Id: 1, Data: "one"}.Build(),
+			batch_mode.Item_builder{Id: 2, Data: "two"}.Build(),
+		})
+		if err != nil {
+			t.Fatalf(
+				"%s: Create(): %s",
+				repoDesc,
+				err,
+			)
+		}
+
+		updated, err := repo.Update(context.Background(), []*batch_mode.Item{
+			// a field mask excluding the primary key cannot identify the item to update
+			batch_mode.Item_builder{
+				FieldMask: &fieldmaskpb.FieldMask{Paths: []string{"data"}},
+				Id:        1,
+				Data:      "uno",
+			}.Build(),
+			batch_mode.Item_builder{Id: 2, Data: "dos"}.Build(),
+			batch_mode.Item_builder{Id: 3, Data: "tres"}.Build(),
+		})
+		var batchErr *runtime.BatchError
+		if !errors.As(err, &batchErr) {
+			t.Fatalf(
+				"%s: Update(): expected %T, got %v",
+				repoDesc,
+				batchErr,
+				err,
+			)
+		}
+		if len(batchErr.Errors) != 2 || batchErr.Errors[0].Index != 0 || batchErr.Errors[1].Index != 2 {
+			t.Fatalf(
+				"%s: Update(): expected items 0 and 2 to fail, got %v",
+				repoDesc,
+				batchErr,
+			)
+		}
+		if !errors.Is(batchErr.Errors[1], runtime.ErrNotFound) {
+			t.Fatalf(
+				"%s: Update(): item 2: expected %v, got %v",
+				repoDesc,
+				runtime.ErrNotFound,
+				batchErr.Errors[1].Err,
+			)
+		}
+		if updated[0] != nil || updated[1].GetData() != "dos" || updated[2] != nil {
+			t.Fatalf(
+				"%s: Update(): expected only item 1 to be updated, got %v",
+				repoDesc,
+				updated,
+			)
+		}
+
+		if diff := cmp.Diff([]string{"one", "dos"}, readData(t, repoDesc, repo)); diff != "" {
+			t.Fatal(mismatch(fmt.Sprintf("%s: Read():", repoDesc), diff))
+		}
+	}
+}
+
+func batchModeImplementationsToTest() map[options.Implementation]batchModeComponentUnderTest {
+	return map[options.Implementation]batchModeComponentUnderTest{
+		options.Implementation_IMPLEMENTATION_SQLITE: sqliteBatchModeComponentUnderTest,
+		options.Implementation_IMPLEMENTATION_PGSQL:  pgsqlBatchModeComponentUnderTest,
+	}
+}
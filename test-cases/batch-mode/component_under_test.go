@@ -0,0 +1,12 @@
+package batch_mode_test
+
+import (
+	"testing"
+
+	batch_mode "github.com/samlitowitz/protoc-gen-crud/test-cases/batch-mode"
+
+	"github.com/samlitowitz/protoc-gen-crud/runtime"
+)
+
+// batchModeComponentUnderTest is to be implemented to do setup and tear down for each implementation
+type batchModeComponentUnderTest func(t *testing.T, opts ...runtime.Option) batch_mode.ItemRepository
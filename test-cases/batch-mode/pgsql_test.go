@@ -0,0 +1,46 @@
+package batch_mode_test
+
+import (
+	"database/sql"
+	"os"
+	"testing"
+
+	batch_mode "github.com/samlitowitz/protoc-gen-crud/test-cases/batch-mode"
+
+	"github.com/samlitowitz/protoc-gen-crud/runtime"
+
+	test_cases "github.com/samlitowitz/protoc-gen-crud/test-cases"
+)
+
+func pgsqlBatchModeComponentUnderTest(t *testing.T, opts ...runtime.Option) batch_mode.ItemRepository {
+	dburl, err := test_cases.PgSQLDBURLFromEnv()
+	if err != nil {
+		t.Fatal("pgsql: dburl: ", err)
+	}
+	db, err := sql.Open("pgx", dburl)
+	if err != nil {
+		t.Fatal("pgsql: ", err)
+	}
+	t.Cleanup(func() {
+		err := db.Close()
+		if err != nil {
+			t.Fatal("pgsql: ", err)
+		}
+	})
+
+	origDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal("pgsql: finding working dir:", err)
+	}
+
+	err = test_cases.PgSQLExecSQLFile(db, origDir+string(os.PathSeparator)+"test.pgsql.sql")
+	if err != nil {
+		t.Fatal("pgsql: executing setup SQL: ", err)
+	}
+
+	repo, err := batch_mode.NewPgSQLItemRepository(db, opts...)
+	if err != nil {
+		t.Fatal("pgsql: creating repository: ", err)
+	}
+	return repo
+}
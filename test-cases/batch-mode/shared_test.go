@@ -0,0 +1,11 @@
+package batch_mode_test
+
+import "fmt"
+
+func mismatch(prefix, diff string) string {
+	return fmt.Sprintf(
+		"%s mismatch (-want +got):\n%s",
+		prefix,
+		diff,
+	)
+}
@@ -0,0 +1,16 @@
+package constraints_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/samlitowitz/protoc-gen-crud/test-cases/constraints"
+)
+
+type constraintsComponents struct {
+	db       *sql.DB
+	products constraints.ProductRepository
+}
+
+// constraintsComponentUnderTest is to be implemented to do setup and tear down for each implementation
+type constraintsComponentUnderTest func(t *testing.T) *constraintsComponents
@@ -0,0 +1,169 @@
+package constraints_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/samlitowitz/protoc-gen-crud/test-cases/constraints"
+
+	"github.com/samlitowitz/protoc-gen-crud/options"
+	"github.com/samlitowitz/protoc-gen-crud/runtime"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func readProducts(t *testing.T, repoDesc string, repo constraints.ProductRepository) []*constraints.Product {
+	res, err := repo.Read(context.Background(), nil)
+	if err != nil {
+		t.Fatalf(
+			"%s: Read(): %s",
+			repoDesc,
+			err,
+		)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].GetId() < res[j].GetId() })
+	return res
+}
+
+func TestProductRepository_OptionalFieldsRoundTrip(t *testing.T) {
+	// optional fields set to their zero value are distinct from unset ones, which are stored as NULL
+	expected := []*constraints.Product{
+		constraints.Product_builder{Id: 1, Name: "unset"}.Build(),
+		constraints.Product_builder{Id: 2, Name: "zero", Description: proto.String(""), Stock: proto.Int32(0)}.Build(),
+		constraints.Product_builder{Id: 3, Name: "set", Description: proto.String("three"), Stock: proto.Int32(3)}.Build(),
+	}
+
+	for repoType, componentUnderTest := range constraintsImplementationsToTest() {
+		repoDesc := repoType.String()
+		// Call setup function, inject t *testing.T, and use t.Cleanup
+		components := componentUnderTest(t)
+		if components == nil {
+			t.Fatalf(
+				"%s: no implementation provided",
+				repoDesc,
+			)
+		}
+
+		_, err := components.products.Create(context.Background(), expected)
+		if err != nil {
+			t.Fatalf(
+				"%s: Create(): %s",
+				repoDesc,
+				err,
+			)
+		}
+		if diff := cmp.Diff(expected, readProducts(t, repoDesc, components.products), protocmp.Transform()); diff != "" {
+			t.Fatal(mismatch(fmt.Sprintf("%s: Create():", repoDesc), diff))
+		}
+
+		// clearing an optional field stores NULL
+		_, err = components.products.Update(context.Background(), []*constraints.Product{
+			constraints.Product_builder{Id: 3, Name: "cleared"}.Build(),
+		})
+		if err != nil {
+			t.Fatalf(
+				"%s: Update(): %s",
+				repoDesc,
+				err,
+			)
+		}
+		expectedUpdated := []*constraints.Product{
+			expected[0],
+			expected[1],
+			constraints.Product_builder{Id: 3, Name: "cleared"}.Build(),
+		}
+		if diff := cmp.Diff(expectedUpdated, readProducts(t, repoDesc, components.products), protocmp.Transform()); diff != "" {
+			t.Fatal(mismatch(fmt.Sprintf("%s: Update():", repoDesc), diff))
+		}
+	}
+}
+
+func TestProductRepository_Create_CheckViolation(t *testing.T) {
+	tests := map[string]struct {
+		toCreate *constraints.Product
+	}{
+		"empty name": {
+			toCreate: constraints.Product_builder{Id: 1, Name: ""}.Build(),
+		},
+		"negative price": {
+			toCreate: constraints.Product_builder{Id: 1, Name: "one", Price: -1}.Build(),
+		},
+	}
+
+	for repoType, componentUnderTest := range constraintsImplementationsToTest() {
+		repoDesc := repoType.String()
+		for testDesc, tc := range tests {
+			// Call setup function, inject t *testing.T, and use t.Cleanup
+			components := componentUnderTest(t)
+			if components == nil {
+				t.Fatalf(
+					"%s: no implementation provided",
+					repoDesc,
+				)
+			}
+
+			_, err := components.products.Create(context.Background(), []*constraints.Product{tc.toCreate})
+			if !errors.Is(err, runtime.ErrConstraintViolation) {
+				t.Fatalf(
+					"%s: %s: Create(): expected %v, got %v",
+					repoDesc,
+					testDesc,
+					runtime.ErrConstraintViolation,
+					err,
+				)
+			}
+		}
+	}
+}
+
+func TestProductRepository_Read_ColumnsWrittenOutsideRepository(t *testing.T) {
+	for repoType, componentUnderTest := range constraintsImplementationsToTest() {
+		repoDesc := repoType.String()
+		// Call setup function, inject t *testing.T, and use t.Cleanup
+		components := componentUnderTest(t)
+		if components == nil {
+			t.Fatalf(
+				"%s: no implementation provided",
+				repoDesc,
+			)
+		}
+
+		// name is NOT NULL
+		_, err := components.db.Exec(`INSERT INTO "product" ("id") VALUES (1)`)
+		if err == nil {
+			t.Fatalf(
+				"%s: Exec(): expected NOT NULL violation",
+				repoDesc,
+			)
+		}
+
+		// price takes its default while the nullable sku is read as its zero value
+		_, err = components.db.Exec(`INSERT INTO "product" ("id", "name") VALUES (1, 'raw')`)
+		if err != nil {
+			t.Fatalf(
+				"%s: Exec(): %s",
+				repoDesc,
+				err,
+			)
+		}
+		expected := []*constraints.Product{
+			constraints.Product_builder{Id: 1, Name: "raw", Price: 1.5}.Build(),
+		}
+		if diff := cmp.Diff(expected, readProducts(t, repoDesc, components.products), protocmp.Transform()); diff != "" {
+			t.Fatal(mismatch(fmt.Sprintf("%s: Read():", repoDesc), diff))
+		}
+	}
+}
+
+func constraintsImplementationsToTest() map[options.Implementation]constraintsComponentUnderTest {
+	return map[options.Implementation]constraintsComponentUnderTest{
+		options.Implementation_IMPLEMENTATION_SQLITE: sqliteConstraintsComponentUnderTest,
+		options.Implementation_IMPLEMENTATION_PGSQL:  pgsqlConstraintsComponentUnderTest,
+	}
+}
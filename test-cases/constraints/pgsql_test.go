@@ -0,0 +1,44 @@
+package constraints_test
+
+import (
+	"database/sql"
+	"os"
+	"testing"
+
+	"github.com/samlitowitz/protoc-gen-crud/test-cases/constraints"
+
+	test_cases "github.com/samlitowitz/protoc-gen-crud/test-cases"
+)
+
+func pgsqlConstraintsComponentUnderTest(t *testing.T) *constraintsComponents {
+	dburl, err := test_cases.PgSQLDBURLFromEnv()
+	if err != nil {
+		t.Fatal("pgsql: dburl: ", err)
+	}
+	db, err := sql.Open("pgx", dburl)
+	if err != nil {
+		t.Fatal("pgsql: ", err)
+	}
+	t.Cleanup(func() {
+		err := db.Close()
+		if err != nil {
+			t.Fatal("pgsql: ", err)
+		}
+	})
+
+	origDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal("pgsql: finding working dir:", err)
+	}
+
+	err = test_cases.PgSQLExecSQLFile(db, origDir+string(os.PathSeparator)+"test.pgsql.sql")
+	if err != nil {
+		t.Fatal("pgsql: executing setup SQL: ", err)
+	}
+
+	repo, err := constraints.NewPgSQLProductRepository(db)
+	if err != nil {
+		t.Fatal("pgsql: creating repository: ", err)
+	}
+	return &constraintsComponents{db: db, products: repo}
+}
@@ -4,7 +4,9 @@ import (
 	"testing"
 
 	created_at "github.com/samlitowitz/protoc-gen-crud/test-cases/created-at"
+
+	"github.com/samlitowitz/protoc-gen-crud/runtime"
 )
 
 // createdAtComponentUnderTest is to be implemented to do setup and tear down for each implementation
-type createdAtComponentUnderTest func(t *testing.T) created_at.CreatedAtRepository
+type createdAtComponentUnderTest func(t *testing.T, opts ...runtime.Option) created_at.CreatedAtRepository
@@ -2,16 +2,20 @@ package created_at_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	created_at "github.com/samlitowitz/protoc-gen-crud/test-cases/created-at"
 
+	"google.golang.org/protobuf/testing/protocmp"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	test_cases "github.com/samlitowitz/protoc-gen-crud/test-cases"
 
 	"github.com/samlitowitz/protoc-gen-crud/options"
+	"github.com/samlitowitz/protoc-gen-crud/runtime"
 
 	"github.com/samlitowitz/expressions"
 
@@ -296,10 +300,11 @@ func TestCreatedAtRepository_Update_WithUnLocatablePrimaryKeyUpdatesNothing(t *t
 			expected = append(expected, builder.Build())
 		}
 		_, err = repoImpl.Update(context.Background(), expected)
-		if err != nil {
+		if !errors.Is(err, runtime.ErrNotFound) {
 			t.Fatalf(
-				"%s: Update(): %s",
+				"%s: Update(): expected %v, got %v",
 				repoDesc,
+				runtime.ErrNotFound,
 				err,
 			)
 		}
@@ -616,7 +621,7 @@ func TestCreatedAtRepository_Delete_WithLocatablePrimaryKeySucceeds(t *testing.T
 				)
 			}
 
-			err = repoImpl.Delete(context.Background(), testCase.deleteExpression)
+			deleted, err := repoImpl.Delete(context.Background(), testCase.deleteExpression)
 			if err != nil {
 				t.Fatalf(
 					"%s: %s: Delete(): %s",
@@ -625,6 +630,15 @@ func TestCreatedAtRepository_Delete_WithLocatablePrimaryKeySucceeds(t *testing.T
 					err,
 				)
 			}
+			if deleted != int64(len(testCase.initial)-len(testCase.expected)) {
+				t.Fatalf(
+					"%s: %s: Delete(): expected %d deleted, got %d",
+					testDesc,
+					repoDesc,
+					len(testCase.initial)-len(testCase.expected),
+					deleted,
+				)
+			}
 
 			res, err = repoImpl.Read(context.Background(), nil)
 			if err != nil {
@@ -655,6 +669,57 @@ func TestCreatedAtRepository_Delete_WithLocatablePrimaryKeySucceeds(t *testing.T
 	}
 }
 
+func TestCreatedAtRepository_Create_SetsCreatedAtFromClock(t *testing.T) {
+	provided := time.Date(2020, time.June, 7, 8, 9, 10, 0, time.UTC)
+	for repoType, componentUnderTest := range createdAtImplementationsToTest() {
+		repoDesc := repoType.String()
+		clock := &frozenClock{now: time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC)}
+		// Call setup function, inject t *testing.T, and use t.Cleanup
+		repoImpl := componentUnderTest(t, runtime.WithClock(clock))
+		if repoImpl == nil {
+			t.Fatalf(
+				"%s: no implementation provided",
+				repoDesc,
+			)
+		}
+
+		created, err := repoImpl.Create(context.Background(), []*created_at.CreatedAt{
+			created_at.CreatedAt_builder{Id: 0, Data: "zero"}.Build(),
+			created_at.CreatedAt_builder{Id: 1, Data: "one", CreatedAt: timestamppb.New(provided)}.Build(),
+		})
+		if err != nil {
+			t.Fatalf(
+				"%s: Create(): %s",
+				repoDesc,
+				err,
+			)
+		}
+
+		// created at times already set are kept
+		expected := []*created_at.CreatedAt{
+			created_at.CreatedAt_builder{Id: 0, Data: "zero", CreatedAt: timestamppb.New(clock.now)}.Build(),
+			created_at.CreatedAt_builder{Id: 1, Data: "one", CreatedAt: timestamppb.New(provided)}.Build(),
+		}
+		if diff := cmp.Diff(expected, created, protocmp.Transform()); diff != "" {
+			t.Fatal(mismatch(fmt.Sprintf("%s: Create():", repoDesc), diff))
+		}
+
+		res, err := repoImpl.Read(context.Background(), nil)
+		if err != nil {
+			t.Fatalf(
+				"%s: Read(): %s",
+				repoDesc,
+				err,
+			)
+		}
+		if diff := cmp.Diff(expected, res, protocmp.Transform(), cmpopts.SortSlices(func(x, y *created_at.CreatedAt) bool {
+			return x.GetId() < y.GetId()
+		})); diff != "" {
+			t.Fatal(mismatch(fmt.Sprintf("%s: Read():", repoDesc), diff))
+		}
+	}
+}
+
 func createdAtImplementationsToTest() map[options.Implementation]createdAtComponentUnderTest {
 	return map[options.Implementation]createdAtComponentUnderTest{
 		options.Implementation_IMPLEMENTATION_SQLITE: sqliteCreatedAtComponentUnderTest,
@@ -672,3 +737,12 @@ func createdAtDefaultCmpOpts() cmp.Options {
 		}),
 	}
 }
+
+// frozenClock tells the same time until it is moved
+type frozenClock struct {
+	now time.Time
+}
+
+func (c *frozenClock) Now() time.Time {
+	return c.now
+}
@@ -7,10 +7,12 @@ import (
 
 	created_at "github.com/samlitowitz/protoc-gen-crud/test-cases/created-at"
 
+	"github.com/samlitowitz/protoc-gen-crud/runtime"
+
 	test_cases "github.com/samlitowitz/protoc-gen-crud/test-cases"
 )
 
-func pgsqlCreatedAtComponentUnderTest(t *testing.T) created_at.CreatedAtRepository {
+func pgsqlCreatedAtComponentUnderTest(t *testing.T, opts ...runtime.Option) created_at.CreatedAtRepository {
 	dburl, err := test_cases.PgSQLDBURLFromEnv()
 	if err != nil {
 		t.Fatal("pgsql: dburl: ", err)
@@ -36,7 +38,7 @@ func pgsqlCreatedAtComponentUnderTest(t *testing.T) created_at.CreatedAtReposito
 		t.Fatal("pgsql: executing setup SQL: ", err)
 	}
 
-	repo, err := created_at.NewPgSQLCreatedAtRepository(db)
+	repo, err := created_at.NewPgSQLCreatedAtRepository(db, opts...)
 	if err != nil {
 		t.Fatal("pgsql: creating repository: ", err)
 	}
@@ -6,6 +6,8 @@ import (
 	"testing"
 
 	created_at "github.com/samlitowitz/protoc-gen-crud/test-cases/created-at"
+
+	"github.com/samlitowitz/protoc-gen-crud/runtime"
 )
 
 func sqliteExecSQLFile(db *sql.DB, file string) error {
@@ -24,7 +26,7 @@ func sqliteExecSQLFile(db *sql.DB, file string) error {
 	return nil
 }
 
-func sqliteCreatedAtComponentUnderTest(t *testing.T) created_at.CreatedAtRepository {
+func sqliteCreatedAtComponentUnderTest(t *testing.T, opts ...runtime.Option) created_at.CreatedAtRepository {
 	db, err := sql.Open("sqlite", ":memory:")
 	if err != nil {
 		t.Fatal("sqlite: ", err)
@@ -46,7 +48,7 @@ func sqliteCreatedAtComponentUnderTest(t *testing.T) created_at.CreatedAtReposit
 		t.Fatal("sqlite: executing setup SQL: ", err)
 	}
 
-	repo, err := created_at.NewSQLiteCreatedAtRepository(db)
+	repo, err := created_at.NewSQLiteCreatedAtRepository(db, opts...)
 	if err != nil {
 		t.Fatal("sqlite: creating repository: ", err)
 	}
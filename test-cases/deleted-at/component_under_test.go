@@ -0,0 +1,10 @@
+package deleted_at_test
+
+import (
+	"testing"
+
+	deleted_at "github.com/samlitowitz/protoc-gen-crud/test-cases/deleted-at"
+)
+
+// deletedAtComponentUnderTest is to be implemented to do setup and tear down for each implementation
+type deletedAtComponentUnderTest func(t *testing.T) deleted_at.DeletedAtRepository
@@ -0,0 +1,420 @@
+package deleted_at_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	deleted_at "github.com/samlitowitz/protoc-gen-crud/test-cases/deleted-at"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/samlitowitz/protoc-gen-crud/options"
+	"github.com/samlitowitz/protoc-gen-crud/runtime"
+
+	"github.com/samlitowitz/expressions"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestDeletedAtRepository_Delete_SoftDeletesMatchingEntities(t *testing.T) {
+	opts := deletedAtDefaultCmpOpts()
+	for repoType, componentUnderTest := range deletedAtImplementationsToTest() {
+		repoDesc := repoType.String()
+		// Call setup function, inject t *testing.T, and use t.Cleanup
+		repoImpl := componentUnderTest(t)
+		if repoImpl == nil {
+			t.Fatalf(
+				"%s: no implementation provided",
+				repoDesc,
+			)
+		}
+
+		initial := deletedAtInitial()
+		_, err := repoImpl.Create(context.Background(), initial)
+		if err != nil {
+			t.Fatalf(
+				"%s: Create(): %s",
+				repoDesc,
+				err,
+			)
+		}
+
+		deleted, err := repoImpl.Delete(context.Background(), deletedAtIdIn(2, 3))
+		if err != nil {
+			t.Fatalf(
+				"%s: Delete(): %s",
+				repoDesc,
+				err,
+			)
+		}
+		if deleted != 2 {
+			t.Fatalf(
+				"%s: Delete(): expected 2 deleted, got %d",
+				repoDesc,
+				deleted,
+			)
+		}
+
+		res, err := repoImpl.Read(context.Background(), nil)
+		if err != nil {
+			t.Fatalf(
+				"%s: Read(): %s",
+				repoDesc,
+				err,
+			)
+		}
+		if diff := cmp.Diff(initial[:2], res, opts); diff != "" {
+			t.Fatal(
+				mismatch(
+					fmt.Sprintf(
+						"%s: Read():",
+						repoDesc,
+					),
+					diff,
+				),
+			)
+		}
+
+		res, err = repoImpl.Read(context.Background(), deletedAtIdIn(2))
+		if err != nil {
+			t.Fatalf(
+				"%s: Read(): %s",
+				repoDesc,
+				err,
+			)
+		}
+		if len(res) != 0 {
+			t.Fatalf(
+				"%s: Read(): soft deleted entity returned: got %d items",
+				repoDesc,
+				len(res),
+			)
+		}
+	}
+}
+
+func TestDeletedAtRepository_Update_DoesNotModifySoftDeletedEntities(t *testing.T) {
+	opts := deletedAtDefaultCmpOpts()
+	for repoType, componentUnderTest := range deletedAtImplementationsToTest() {
+		repoDesc := repoType.String()
+		// Call setup function, inject t *testing.T, and use t.Cleanup
+		repoImpl := componentUnderTest(t)
+		if repoImpl == nil {
+			t.Fatalf(
+				"%s: no implementation provided",
+				repoDesc,
+			)
+		}
+
+		initial := deletedAtInitial()
+		_, err := repoImpl.Create(context.Background(), initial)
+		if err != nil {
+			t.Fatalf(
+				"%s: Create(): %s",
+				repoDesc,
+				err,
+			)
+		}
+
+		deleted, err := repoImpl.Delete(context.Background(), deletedAtIdIn(3))
+		if err != nil {
+			t.Fatalf(
+				"%s: Delete(): %s",
+				repoDesc,
+				err,
+			)
+		}
+		if deleted != 1 {
+			t.Fatalf(
+				"%s: Delete(): expected 1 deleted, got %d",
+				repoDesc,
+				deleted,
+			)
+		}
+
+		_, err = repoImpl.Update(
+			context.Background(),
+			[]*deleted_at.DeletedAt{
+				deleted_at.DeletedAt_builder{Id: 3, Data: "three - updated"}.Build(),
+			},
+		)
+		if !errors.Is(err, runtime.ErrNotFound) {
+			t.Fatalf(
+				"%s: Update(): expected %v, got %v",
+				repoDesc,
+				runtime.ErrNotFound,
+				err,
+			)
+		}
+
+		restored, err := repoImpl.Restore(context.Background(), deletedAtIdIn(3))
+		if err != nil {
+			t.Fatalf(
+				"%s: Restore(): %s",
+				repoDesc,
+				err,
+			)
+		}
+		if restored != 1 {
+			t.Fatalf(
+				"%s: Restore(): expected 1 restored, got %d",
+				repoDesc,
+				restored,
+			)
+		}
+
+		res, err := repoImpl.Read(context.Background(), nil)
+		if err != nil {
+			t.Fatalf(
+				"%s: Read(): %s",
+				repoDesc,
+				err,
+			)
+		}
+		if diff := cmp.Diff(initial, res, opts); diff != "" {
+			t.Fatal(
+				mismatch(
+					fmt.Sprintf(
+						"%s: Read():",
+						repoDesc,
+					),
+					diff,
+				),
+			)
+		}
+	}
+}
+
+func TestDeletedAtRepository_Restore_RestoresSoftDeletedEntities(t *testing.T) {
+	opts := deletedAtDefaultCmpOpts()
+	for repoType, componentUnderTest := range deletedAtImplementationsToTest() {
+		repoDesc := repoType.String()
+		// Call setup function, inject t *testing.T, and use t.Cleanup
+		repoImpl := componentUnderTest(t)
+		if repoImpl == nil {
+			t.Fatalf(
+				"%s: no implementation provided",
+				repoDesc,
+			)
+		}
+
+		initial := deletedAtInitial()
+		_, err := repoImpl.Create(context.Background(), initial)
+		if err != nil {
+			t.Fatalf(
+				"%s: Create(): %s",
+				repoDesc,
+				err,
+			)
+		}
+
+		deleted, err := repoImpl.Delete(context.Background(), deletedAtIdIn(1, 2, 3))
+		if err != nil {
+			t.Fatalf(
+				"%s: Delete(): %s",
+				repoDesc,
+				err,
+			)
+		}
+		if deleted != 3 {
+			t.Fatalf(
+				"%s: Delete(): expected 3 deleted, got %d",
+				repoDesc,
+				deleted,
+			)
+		}
+
+		restored, err := repoImpl.Restore(context.Background(), deletedAtIdIn(1, 2))
+		if err != nil {
+			t.Fatalf(
+				"%s: Restore(): %s",
+				repoDesc,
+				err,
+			)
+		}
+		if restored != 2 {
+			t.Fatalf(
+				"%s: Restore(): expected 2 restored, got %d",
+				repoDesc,
+				restored,
+			)
+		}
+
+		res, err := repoImpl.Read(context.Background(), nil)
+		if err != nil {
+			t.Fatalf(
+				"%s: Read(): %s",
+				repoDesc,
+				err,
+			)
+		}
+		if diff := cmp.Diff(initial[:3], res, opts); diff != "" {
+			t.Fatal(
+				mismatch(
+					fmt.Sprintf(
+						"%s: Read():",
+						repoDesc,
+					),
+					diff,
+				),
+			)
+		}
+	}
+}
+
+func TestDeletedAtRepository_Purge_PermanentlyDeletesEntities(t *testing.T) {
+	opts := deletedAtDefaultCmpOpts()
+	for repoType, componentUnderTest := range deletedAtImplementationsToTest() {
+		repoDesc := repoType.String()
+		// Call setup function, inject t *testing.T, and use t.Cleanup
+		repoImpl := componentUnderTest(t)
+		if repoImpl == nil {
+			t.Fatalf(
+				"%s: no implementation provided",
+				repoDesc,
+			)
+		}
+
+		initial := deletedAtInitial()
+		_, err := repoImpl.Create(context.Background(), initial)
+		if err != nil {
+			t.Fatalf(
+				"%s: Create(): %s",
+				repoDesc,
+				err,
+			)
+		}
+
+		deleted, err := repoImpl.Delete(context.Background(), deletedAtIdIn(2))
+		if err != nil {
+			t.Fatalf(
+				"%s: Delete(): %s",
+				repoDesc,
+				err,
+			)
+		}
+		if deleted != 1 {
+			t.Fatalf(
+				"%s: Delete(): expected 1 deleted, got %d",
+				repoDesc,
+				deleted,
+			)
+		}
+
+		// Purge affects soft deleted and not deleted entities alike
+		purged, err := repoImpl.Purge(context.Background(), deletedAtIdIn(2, 3))
+		if err != nil {
+			t.Fatalf(
+				"%s: Purge(): %s",
+				repoDesc,
+				err,
+			)
+		}
+		if purged != 2 {
+			t.Fatalf(
+				"%s: Purge(): expected 2 purged, got %d",
+				repoDesc,
+				purged,
+			)
+		}
+
+		restored, err := repoImpl.Restore(context.Background(), nil)
+		if err != nil {
+			t.Fatalf(
+				"%s: Restore(): %s",
+				repoDesc,
+				err,
+			)
+		}
+		if restored != 0 {
+			t.Fatalf(
+				"%s: Restore(): expected 0 restored, got %d",
+				repoDesc,
+				restored,
+			)
+		}
+
+		res, err := repoImpl.Read(context.Background(), nil)
+		if err != nil {
+			t.Fatalf(
+				"%s: Read(): %s",
+				repoDesc,
+				err,
+			)
+		}
+		if diff := cmp.Diff(initial[:2], res, opts); diff != "" {
+			t.Fatal(
+				mismatch(
+					fmt.Sprintf(
+						"%s: Read():",
+						repoDesc,
+					),
+					diff,
+				),
+			)
+		}
+	}
+}
+
+func deletedAtInitial() []*deleted_at.DeletedAt {
+	builders := []*deleted_at.DeletedAt_builder{
+		{
+			Id:   0,
+			Data: "zero",
+		},
+		{
+			Id:   1,
+			Data: "one",
+		},
+		{
+			Id:   2,
+			Data: "two",
+		},
+		{
+			Id:   3,
+			Data: "three",
+		},
+	}
+	initial := make([]*deleted_at.DeletedAt, 0, len(builders))
+	for _, builder := range builders {
+		initial = append(initial, builder.Build())
+	}
+	return initial
+}
+
+func deletedAtIdIn(ids ...int32) expressions.Expression {
+	var expr expressions.Expression
+	for _, id := range ids {
+		equals := expressions.NewEquals(
+			expressions.NewIdentifier(deleted_at.DeletedAt_Id_Field),
+			expressions.NewScalar(id),
+		)
+		if expr == nil {
+			expr = equals
+			continue
+		}
+		expr = expressions.NewOr(expr, equals)
+	}
+	return expr
+}
+
+func deletedAtImplementationsToTest() map[options.Implementation]deletedAtComponentUnderTest {
+	return map[options.Implementation]deletedAtComponentUnderTest{
+		options.Implementation_IMPLEMENTATION_SQLITE: sqliteDeletedAtComponentUnderTest,
+		options.Implementation_IMPLEMENTATION_PGSQL:  pgsqlDeletedAtComponentUnderTest,
+	}
+}
+
+func deletedAtDefaultCmpOpts() cmp.Options {
+	return cmp.Options{
+		cmpopts.IgnoreUnexported(deleted_at.DeletedAt{}),
+		cmpopts.IgnoreUnexported(timestamppb.Timestamp{}),
+		cmpopts.IgnoreFields(timestamppb.Timestamp{}, "Nanos"),
+		cmpopts.SortSlices(func(x, y *deleted_at.DeletedAt) bool {
+			return x.GetId() < y.GetId()
+		}),
+	}
+}
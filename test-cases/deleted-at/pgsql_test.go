@@ -0,0 +1,44 @@
+package deleted_at_test
+
+import (
+	"database/sql"
+	"os"
+	"testing"
+
+	deleted_at "github.com/samlitowitz/protoc-gen-crud/test-cases/deleted-at"
+
+	test_cases "github.com/samlitowitz/protoc-gen-crud/test-cases"
+)
+
+func pgsqlDeletedAtComponentUnderTest(t *testing.T) deleted_at.DeletedAtRepository {
+	dburl, err := test_cases.PgSQLDBURLFromEnv()
+	if err != nil {
+		t.Fatal("pgsql: dburl: ", err)
+	}
+	db, err := sql.Open("pgx", dburl)
+	if err != nil {
+		t.Fatal("pgsql: ", err)
+	}
+	t.Cleanup(func() {
+		err := db.Close()
+		if err != nil {
+			t.Fatal("pgsql: ", err)
+		}
+	})
+
+	origDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal("pgsql: finding working dir:", err)
+	}
+
+	err = test_cases.PgSQLExecSQLFile(db, origDir+string(os.PathSeparator)+"test.pgsql.sql")
+	if err != nil {
+		t.Fatal("pgsql: executing setup SQL: ", err)
+	}
+
+	repo, err := deleted_at.NewPgSQLDeletedAtRepository(db)
+	if err != nil {
+		t.Fatal("pgsql: creating repository: ", err)
+	}
+	return repo
+}
@@ -0,0 +1,17 @@
+package enum_storage_test
+
+import (
+	"database/sql"
+	"testing"
+
+	enum_storage "github.com/samlitowitz/protoc-gen-crud/test-cases/enum-storage"
+)
+
+type enumStorageComponents struct {
+	db          *sql.DB
+	items       enum_storage.ItemRepository
+	maskedItems enum_storage.MaskedItemRepository
+}
+
+// enumStorageComponentUnderTest is to be implemented to do setup and tear down for each implementation
+type enumStorageComponentUnderTest func(t *testing.T) *enumStorageComponents
@@ -0,0 +1,358 @@
+package enum_storage_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	enum_storage "github.com/samlitowitz/protoc-gen-crud/test-cases/enum-storage"
+
+	crudexpressions "github.com/samlitowitz/protoc-gen-crud/expressions"
+	"github.com/samlitowitz/protoc-gen-crud/options"
+	"github.com/samlitowitz/protoc-gen-crud/runtime"
+
+	"github.com/samlitowitz/expressions"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func seedItems(t *testing.T, repoDesc string, repo enum_storage.ItemRepository) []*enum_storage.Item {
+	items := []*enum_storage.Item{
+		enum_storage.Item_builder{
+			Color:    enum_storage.Color_COLOR_RED,
+			Size:     enum_storage.Size_SIZE_SMALL,
+			Priority: enum_storage.Priority_PRIORITY_LOW,
+			Name:     "small red",
+		}.Build(),
+		enum_storage.Item_builder{
+			Color:    enum_storage.Color_COLOR_RED,
+			Size:     enum_storage.Size_SIZE_MEDIUM,
+			Priority: enum_storage.Priority_PRIORITY_HIGH,
+			Trim:     enum_storage.Color_COLOR_BLUE.Enum(),
+			Fit:      enum_storage.Size_SIZE_LARGE.Enum(),
+			Name:     "medium red",
+		}.Build(),
+		// set to zero values, which are distinct from unset ones
+		enum_storage.Item_builder{
+			Color: enum_storage.Color_COLOR_GREEN,
+			Size:  enum_storage.Size_SIZE_LARGE,
+			Trim:  enum_storage.Color_COLOR_UNSPECIFIED.Enum(),
+			Fit:   enum_storage.Size_SIZE_UNSPECIFIED.Enum(),
+			Name:  "large green",
+		}.Build(),
+	}
+	_, err := repo.Create(context.Background(), items)
+	if err != nil {
+		t.Fatalf(
+			"%s: Create(): %s",
+			repoDesc,
+			err,
+		)
+	}
+	return items
+}
+
+func readItems(t *testing.T, repoDesc string, repo enum_storage.ItemRepository, expr expressions.Expression) []*enum_storage.Item {
+	res, err := repo.Read(context.Background(), expr)
+	if err != nil {
+		t.Fatalf(
+			"%s: Read(): %s",
+			repoDesc,
+			err,
+		)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].GetName() < res[j].GetName() })
+	return res
+}
+
+func TestItemRepository_RoundTrip(t *testing.T) {
+	for repoType, componentUnderTest := range enumStorageImplementationsToTest() {
+		repoDesc := repoType.String()
+		// Call setup function, inject t *testing.T, and use t.Cleanup
+		components := componentUnderTest(t)
+		if components == nil {
+			t.Fatalf(
+				"%s: no implementation provided",
+				repoDesc,
+			)
+		}
+		items := seedItems(t, repoDesc, components.items)
+
+		expected := []*enum_storage.Item{items[2], items[1], items[0]}
+		if diff := cmp.Diff(expected, readItems(t, repoDesc, components.items, nil), protocmp.Transform()); diff != "" {
+			t.Fatal(mismatch(fmt.Sprintf("%s: Read():", repoDesc), diff))
+		}
+
+		res, err := components.items.Get(context.Background(), enum_storage.ItemKeyOf(items[1]))
+		if err != nil {
+			t.Fatalf(
+				"%s: Get(): %s",
+				repoDesc,
+				err,
+			)
+		}
+		if diff := cmp.Diff(items[1], res, protocmp.Transform()); diff != "" {
+			t.Fatal(mismatch(fmt.Sprintf("%s: Get():", repoDesc), diff))
+		}
+
+		_, err = components.items.Update(context.Background(), []*enum_storage.Item{
+			enum_storage.Item_builder{
+				Color:    enum_storage.Color_COLOR_RED,
+				Size:     enum_storage.Size_SIZE_MEDIUM,
+				Priority: enum_storage.Priority_PRIORITY_LOW,
+				Trim:     enum_storage.Color_COLOR_GREEN.Enum(),
+				Name:     "medium red",
+			}.Build(),
+		})
+		if err != nil {
+			t.Fatalf(
+				"%s: Update(): %s",
+				repoDesc,
+				err,
+			)
+		}
+		byKey := expressions.NewAnd(
+			expressions.NewEquals(
+				expressions.NewIdentifier(enum_storage.Item_Color_Field),
+				expressions.NewScalar(enum_storage.Color_COLOR_RED),
+			),
+			expressions.NewEquals(
+				expressions.NewIdentifier(enum_storage.Item_Size_Field),
+				expressions.NewScalar(enum_storage.Size_SIZE_MEDIUM),
+			),
+		)
+		expectedUpdated := []*enum_storage.Item{
+			enum_storage.Item_builder{
+				Color:    enum_storage.Color_COLOR_RED,
+				Size:     enum_storage.Size_SIZE_MEDIUM,
+				Priority: enum_storage.Priority_PRIORITY_LOW,
+				Trim:     enum_storage.Color_COLOR_GREEN.Enum(),
+				Name:     "medium red",
+			}.Build(),
+		}
+		if diff := cmp.Diff(expectedUpdated, readItems(t, repoDesc, components.items, byKey), protocmp.Transform()); diff != "" {
+			t.Fatal(mismatch(fmt.Sprintf("%s: Update():", repoDesc), diff))
+		}
+	}
+}
+
+func TestMaskedItemRepository_Update_WithFieldMaskLocatesEnumKeys(t *testing.T) {
+	for repoType, componentUnderTest := range enumStorageImplementationsToTest() {
+		repoDesc := repoType.String()
+		// Call setup function, inject t *testing.T, and use t.Cleanup
+		components := componentUnderTest(t)
+		if components == nil {
+			t.Fatalf(
+				"%s: no implementation provided",
+				repoDesc,
+			)
+		}
+		_, err := components.maskedItems.Create(context.Background(), []*enum_storage.MaskedItem{
+			enum_storage.MaskedItem_builder{
+				Color:    enum_storage.Color_COLOR_BLUE,
+				Size:     enum_storage.Size_SIZE_LARGE,
+				Priority: enum_storage.Priority_PRIORITY_HIGH,
+				Name:     "large blue",
+			}.Build(),
+		})
+		if err != nil {
+			t.Fatalf(
+				"%s: Create(): %s",
+				repoDesc,
+				err,
+			)
+		}
+
+		_, err = components.maskedItems.Update(context.Background(), []*enum_storage.MaskedItem{
+			enum_storage.MaskedItem_builder{
+				FieldMask: &field_mask.FieldMask{Paths: []string{"color", "size", "name"}},
+				Color:     enum_storage.Color_COLOR_BLUE,
+				Size:      enum_storage.Size_SIZE_LARGE,
+				Name:      "renamed",
+			}.Build(),
+		})
+		if err != nil {
+			t.Fatalf(
+				"%s: Update(): %s",
+				repoDesc,
+				err,
+			)
+		}
+
+		res, err := components.maskedItems.Read(context.Background(), nil)
+		if err != nil {
+			t.Fatalf(
+				"%s: Read(): %s",
+				repoDesc,
+				err,
+			)
+		}
+		expected := []*enum_storage.MaskedItem{
+			enum_storage.MaskedItem_builder{
+				Color:    enum_storage.Color_COLOR_BLUE,
+				Size:     enum_storage.Size_SIZE_LARGE,
+				Priority: enum_storage.Priority_PRIORITY_HIGH,
+				Name:     "renamed",
+			}.Build(),
+		}
+		if diff := cmp.Diff(expected, res, protocmp.Transform()); diff != "" {
+			t.Fatal(mismatch(fmt.Sprintf("%s: Read():", repoDesc), diff))
+		}
+	}
+}
+
+func TestItemRepository_Read_FiltersByEnum(t *testing.T) {
+	tests := map[string]struct {
+		expr          expressions.Expression
+		expectedNames []string
+	}{
+		"text equals": {
+			expr: expressions.NewEquals(
+				expressions.NewIdentifier(enum_storage.Item_Color_Field),
+				expressions.NewScalar(enum_storage.Color_COLOR_GREEN),
+			),
+			expectedNames: []string{"large green"},
+		},
+		"native equals with the field on the right": {
+			expr: expressions.NewEquals(
+				expressions.NewScalar(enum_storage.Size_SIZE_SMALL),
+				expressions.NewIdentifier(enum_storage.Item_Size_Field),
+			),
+			expectedNames: []string{"small red"},
+		},
+		"native not equals": {
+			expr: crudexpressions.NewNotEquals(
+				expressions.NewIdentifier(enum_storage.Item_Size_Field),
+				expressions.NewScalar(enum_storage.Size_SIZE_SMALL),
+			),
+			expectedNames: []string{"large green", "medium red"},
+		},
+		"look-up table equals": {
+			expr: expressions.NewEquals(
+				expressions.NewIdentifier(enum_storage.Item_Priority_Field),
+				expressions.NewScalar(enum_storage.Priority_PRIORITY_HIGH),
+			),
+			expectedNames: []string{"medium red"},
+		},
+		"in": {
+			expr: crudexpressions.NewIn(
+				expressions.NewIdentifier(enum_storage.Item_Fit_Field),
+				expressions.NewScalar(enum_storage.Size_SIZE_UNSPECIFIED),
+				expressions.NewScalar(enum_storage.Size_SIZE_LARGE),
+			),
+			expectedNames: []string{"large green", "medium red"},
+		},
+		"optional is null": {
+			expr:          crudexpressions.NewIsNull(expressions.NewIdentifier(enum_storage.Item_Trim_Field)),
+			expectedNames: []string{"small red"},
+		},
+		"optional equals zero": {
+			expr: expressions.NewEquals(
+				expressions.NewIdentifier(enum_storage.Item_Trim_Field),
+				expressions.NewScalar(enum_storage.Color_COLOR_UNSPECIFIED),
+			),
+			expectedNames: []string{"large green"},
+		},
+	}
+
+	for repoType, componentUnderTest := range enumStorageImplementationsToTest() {
+		repoDesc := repoType.String()
+		// Call setup function, inject t *testing.T, and use t.Cleanup
+		components := componentUnderTest(t)
+		if components == nil {
+			t.Fatalf(
+				"%s: no implementation provided",
+				repoDesc,
+			)
+		}
+		seedItems(t, repoDesc, components.items)
+
+		for testDesc, tc := range tests {
+			var names []string
+			for _, item := range readItems(t, repoDesc, components.items, tc.expr) {
+				names = append(names, item.GetName())
+			}
+			if diff := cmp.Diff(tc.expectedNames, names); diff != "" {
+				t.Fatal(mismatch(fmt.Sprintf("%s: %s: Read():", repoDesc, testDesc), diff))
+			}
+		}
+	}
+}
+
+func TestItemRepository_Create_StoresChosenRepresentation(t *testing.T) {
+	// SQLite always stores enums as integers referencing a look-up table
+	expected := map[options.Implementation][]string{
+		options.Implementation_IMPLEMENTATION_SQLITE: {"1", "2", "2"},
+		options.Implementation_IMPLEMENTATION_PGSQL:  {"COLOR_RED", "SIZE_MEDIUM", "2"},
+	}
+
+	for repoType, componentUnderTest := range enumStorageImplementationsToTest() {
+		repoDesc := repoType.String()
+		// Call setup function, inject t *testing.T, and use t.Cleanup
+		components := componentUnderTest(t)
+		if components == nil {
+			t.Fatalf(
+				"%s: no implementation provided",
+				repoDesc,
+			)
+		}
+		seedItems(t, repoDesc, components.items)
+
+		stored := make([]string, 3)
+		err := components.db.QueryRow(`SELECT "color", "size", "priority" FROM "item" WHERE "name" = 'medium red'`).Scan(&stored[0], &stored[1], &stored[2])
+		if err != nil {
+			t.Fatalf(
+				"%s: QueryRow(): %s",
+				repoDesc,
+				err,
+			)
+		}
+		if diff := cmp.Diff(expected[repoType], stored); diff != "" {
+			t.Fatal(mismatch(fmt.Sprintf("%s: stored:", repoDesc), diff))
+		}
+	}
+}
+
+func TestItemRepository_Create_UndeclaredValueFails(t *testing.T) {
+	// text columns are checked against the names of the values, look-up table columns reference their ids
+	expected := map[options.Implementation]error{
+		options.Implementation_IMPLEMENTATION_SQLITE: runtime.ErrForeignKeyViolation,
+		options.Implementation_IMPLEMENTATION_PGSQL:  runtime.ErrConstraintViolation,
+	}
+
+	for repoType, componentUnderTest := range enumStorageImplementationsToTest() {
+		repoDesc := repoType.String()
+		// Call setup function, inject t *testing.T, and use t.Cleanup
+		components := componentUnderTest(t)
+		if components == nil {
+			t.Fatalf(
+				"%s: no implementation provided",
+				repoDesc,
+			)
+		}
+
+		_, err := components.items.Create(context.Background(), []*enum_storage.Item{
+			enum_storage.Item_builder{Color: enum_storage.Color(7), Size: enum_storage.Size_SIZE_SMALL, Name: "undeclared"}.Build(),
+		})
+		if !errors.Is(err, expected[repoType]) {
+			t.Fatalf(
+				"%s: Create(): expected %v, got %v",
+				repoDesc,
+				expected[repoType],
+				err,
+			)
+		}
+	}
+}
+
+func enumStorageImplementationsToTest() map[options.Implementation]enumStorageComponentUnderTest {
+	return map[options.Implementation]enumStorageComponentUnderTest{
+		options.Implementation_IMPLEMENTATION_SQLITE: sqliteEnumStorageComponentUnderTest,
+		options.Implementation_IMPLEMENTATION_PGSQL:  pgsqlEnumStorageComponentUnderTest,
+	}
+}
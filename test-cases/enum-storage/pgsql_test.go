@@ -0,0 +1,48 @@
+package enum_storage_test
+
+import (
+	"database/sql"
+	"os"
+	"testing"
+
+	enum_storage "github.com/samlitowitz/protoc-gen-crud/test-cases/enum-storage"
+
+	test_cases "github.com/samlitowitz/protoc-gen-crud/test-cases"
+)
+
+func pgsqlEnumStorageComponentUnderTest(t *testing.T) *enumStorageComponents {
+	dburl, err := test_cases.PgSQLDBURLFromEnv()
+	if err != nil {
+		t.Fatal("pgsql: dburl: ", err)
+	}
+	db, err := sql.Open("pgx", dburl)
+	if err != nil {
+		t.Fatal("pgsql: ", err)
+	}
+	t.Cleanup(func() {
+		err := db.Close()
+		if err != nil {
+			t.Fatal("pgsql: ", err)
+		}
+	})
+
+	origDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal("pgsql: finding working dir:", err)
+	}
+
+	err = test_cases.PgSQLExecSQLFile(db, origDir+string(os.PathSeparator)+"test.pgsql.sql")
+	if err != nil {
+		t.Fatal("pgsql: executing setup SQL: ", err)
+	}
+
+	repo, err := enum_storage.NewPgSQLItemRepository(db)
+	if err != nil {
+		t.Fatal("pgsql: creating repository: ", err)
+	}
+	maskedRepo, err := enum_storage.NewPgSQLMaskedItemRepository(db)
+	if err != nil {
+		t.Fatal("pgsql: creating repository: ", err)
+	}
+	return &enumStorageComponents{db: db, items: repo, maskedItems: maskedRepo}
+}
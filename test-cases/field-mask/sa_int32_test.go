@@ -2,6 +2,7 @@ package field_mask_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"google.golang.org/genproto/protobuf/field_mask"
@@ -9,6 +10,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 
+	crudruntime "github.com/samlitowitz/protoc-gen-crud/runtime"
 	fieldMask "github.com/samlitowitz/protoc-gen-crud/test-cases/field-mask"
 )
 
@@ -382,6 +384,58 @@ func TestSAInt32Repository_Update_WithNonPrimeAttributesExcludedByFieldMaskAreNo
 	}
 }
 
+func TestSAInt32Repository_Update_WithEmptyEffectiveFieldMaskAndUnlocatablePrimaryKeyFails(t *testing.T) {
+	opts := saInt32DefaultCmpOpts()
+
+	for repoDesc, componentUnderTest := range saInt32ImplementationsToTest() {
+		// Call setup function, inject t *testing.T, and use t.Cleanup
+		repoImpl := componentUnderTest(t)
+		if repoImpl == nil {
+			t.Fatalf(
+				"%s: no implementation provided",
+				repoDesc,
+			)
+		}
+
+		toCreateBuilder := []*fieldMask.SAInt32_builder{
+			{
+				Id:   0,
+				Data: "0",
+			},
+		}
+		toCreate := saInt32Build(toCreateBuilder)
+		err := sqliteSAInt32CreateSuccessWithReadAfterCheck(opts, repoImpl, context.Background(), toCreate, toCreate)
+		if err != nil {
+			t.Fatalf("%s: %s", repoDesc, err)
+		}
+
+		toUpdateBuilder := []*fieldMask.SAInt32_builder{
+			{
+				FieldMask: &field_mask.FieldMask{
+					Paths: []string{"id"},
+				},
+				Id:   1,
+				Data: "SHOULD NOT UPDATE",
+			},
+		}
+		toUpdate := saInt32Build(toUpdateBuilder)
+		_, err = repoImpl.Update(context.Background(), toUpdate)
+		if !errors.Is(err, crudruntime.ErrNotFound) {
+			t.Fatalf(
+				"%s: Update: expected %s, got %v",
+				repoDesc,
+				crudruntime.ErrNotFound,
+				err,
+			)
+		}
+
+		err = sqliteSAInt32ReadCheck(opts, repoImpl, context.Background(), nil, toCreate)
+		if err != nil {
+			t.Fatalf("%s: %s", repoDesc, err)
+		}
+	}
+}
+
 func TestSAInt32Repository_Update_WithNoFieldMaskUsedModifiesAllNonPrimeAttributes(t *testing.T) {
 	opts := saInt32DefaultCmpOpts()
 
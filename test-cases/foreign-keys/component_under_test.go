@@ -0,0 +1,19 @@
+package foreign_keys_test
+
+import (
+	"database/sql"
+	"testing"
+
+	foreign_keys "github.com/samlitowitz/protoc-gen-crud/test-cases/foreign-keys"
+)
+
+type foreignKeysComponents struct {
+	db         *sql.DB
+	authors    foreign_keys.AuthorRepository
+	books      foreign_keys.BookRepository
+	shelves    foreign_keys.ShelfRepository
+	shelfBooks foreign_keys.ShelfBookRepository
+}
+
+// foreignKeysComponentUnderTest is to be implemented to do setup and tear down for each implementation
+type foreignKeysComponentUnderTest func(t *testing.T) *foreignKeysComponents
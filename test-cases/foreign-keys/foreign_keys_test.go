@@ -0,0 +1,254 @@
+package foreign_keys_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	foreign_keys "github.com/samlitowitz/protoc-gen-crud/test-cases/foreign-keys"
+
+	"github.com/samlitowitz/protoc-gen-crud/options"
+	"github.com/samlitowitz/protoc-gen-crud/runtime"
+
+	"github.com/samlitowitz/expressions"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func seedLibrary(t *testing.T, repoDesc string, components *foreignKeysComponents) {
+	_, err := components.authors.Create(context.Background(), []*foreign_keys.Author{
+		foreign_keys.Author_builder{Id: 1, Name: "one"}.Build(),
+		foreign_keys.Author_builder{Id: 2, Name: "two"}.Build(),
+	})
+	if err != nil {
+		t.Fatalf(
+			"%s: Create(): authors: %s",
+			repoDesc,
+			err,
+		)
+	}
+	_, err = components.books.Create(context.Background(), []*foreign_keys.Book{
+		foreign_keys.Book_builder{Id: 1, AuthorId: 1, EditorId: proto.Int32(2), Title: "first", Status: foreign_keys.Status_STATUS_PUBLISHED}.Build(),
+		foreign_keys.Book_builder{Id: 2, AuthorId: 2, EditorId: proto.Int32(1), Title: "second", Status: foreign_keys.Status_STATUS_DRAFT}.Build(),
+	})
+	if err != nil {
+		t.Fatalf(
+			"%s: Create(): books: %s",
+			repoDesc,
+			err,
+		)
+	}
+	_, err = components.shelves.Create(context.Background(), []*foreign_keys.Shelf{
+		foreign_keys.Shelf_builder{Id: 1, Name: "shelf"}.Build(),
+	})
+	if err != nil {
+		t.Fatalf(
+			"%s: Create(): shelves: %s",
+			repoDesc,
+			err,
+		)
+	}
+	_, err = components.shelfBooks.Create(context.Background(), []*foreign_keys.ShelfBook{
+		foreign_keys.ShelfBook_builder{ShelfId: 1, BookId: 1}.Build(),
+		foreign_keys.ShelfBook_builder{ShelfId: 1, BookId: 2}.Build(),
+	})
+	if err != nil {
+		t.Fatalf(
+			"%s: Create(): shelf books: %s",
+			repoDesc,
+			err,
+		)
+	}
+}
+
+func readBooks(t *testing.T, repoDesc string, repo foreign_keys.BookRepository) []*foreign_keys.Book {
+	res, err := repo.Read(context.Background(), nil)
+	if err != nil {
+		t.Fatalf(
+			"%s: Read(): %s",
+			repoDesc,
+			err,
+		)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].GetId() < res[j].GetId() })
+	return res
+}
+
+func readShelfBooks(t *testing.T, repoDesc string, repo foreign_keys.ShelfBookRepository) []*foreign_keys.ShelfBook {
+	res, err := repo.Read(context.Background(), nil)
+	if err != nil {
+		t.Fatalf(
+			"%s: Read(): %s",
+			repoDesc,
+			err,
+		)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].GetBookId() < res[j].GetBookId() })
+	return res
+}
+
+func TestBookRepository_Create_MissingReferenceFails(t *testing.T) {
+	tests := map[string]struct {
+		toCreate *foreign_keys.Book
+	}{
+		"missing author": {
+			toCreate: foreign_keys.Book_builder{Id: 3, AuthorId: 3, Title: "third"}.Build(),
+		},
+		"missing editor": {
+			toCreate: foreign_keys.Book_builder{Id: 3, AuthorId: 1, EditorId: proto.Int32(3), Title: "third"}.Build(),
+		},
+		"undeclared enum value": {
+			toCreate: foreign_keys.Book_builder{Id: 3, AuthorId: 1, Title: "third", Status: foreign_keys.Status(3)}.Build(),
+		},
+	}
+
+	for repoType, componentUnderTest := range foreignKeysImplementationsToTest() {
+		repoDesc := repoType.String()
+		for testDesc, tc := range tests {
+			// Call setup function, inject t *testing.T, and use t.Cleanup
+			components := componentUnderTest(t)
+			if components == nil {
+				t.Fatalf(
+					"%s: no implementation provided",
+					repoDesc,
+				)
+			}
+			seedLibrary(t, repoDesc, components)
+
+			_, err := components.books.Create(context.Background(), []*foreign_keys.Book{tc.toCreate})
+			if !errors.Is(err, runtime.ErrForeignKeyViolation) {
+				t.Fatalf(
+					"%s: %s: Create(): expected %v, got %v",
+					repoDesc,
+					testDesc,
+					runtime.ErrForeignKeyViolation,
+					err,
+				)
+			}
+		}
+	}
+}
+
+func TestAuthorRepository_Delete_AppliesReferentialActions(t *testing.T) {
+	for repoType, componentUnderTest := range foreignKeysImplementationsToTest() {
+		repoDesc := repoType.String()
+		// Call setup function, inject t *testing.T, and use t.Cleanup
+		components := componentUnderTest(t)
+		if components == nil {
+			t.Fatalf(
+				"%s: no implementation provided",
+				repoDesc,
+			)
+		}
+		seedLibrary(t, repoDesc, components)
+
+		// the books of the author are deleted, cascading to their shelves, while the books they edited lose their editor
+		_, err := components.authors.Delete(context.Background(), expressions.NewEquals(
+			expressions.NewIdentifier(foreign_keys.Author_Id_Field),
+			expressions.NewScalar(int32(1)),
+		))
+		if err != nil {
+			t.Fatalf(
+				"%s: Delete(): %s",
+				repoDesc,
+				err,
+			)
+		}
+
+		expectedBooks := []*foreign_keys.Book{
+			foreign_keys.Book_builder{Id: 2, AuthorId: 2, Title: "second", Status: foreign_keys.Status_STATUS_DRAFT}.Build(),
+		}
+		if diff := cmp.Diff(expectedBooks, readBooks(t, repoDesc, components.books), protocmp.Transform()); diff != "" {
+			t.Fatal(mismatch(fmt.Sprintf("%s: Read(): books:", repoDesc), diff))
+		}
+		expectedShelfBooks := []*foreign_keys.ShelfBook{
+			foreign_keys.ShelfBook_builder{ShelfId: 1, BookId: 2}.Build(),
+		}
+		if diff := cmp.Diff(expectedShelfBooks, readShelfBooks(t, repoDesc, components.shelfBooks), protocmp.Transform()); diff != "" {
+			t.Fatal(mismatch(fmt.Sprintf("%s: Read(): shelf books:", repoDesc), diff))
+		}
+	}
+}
+
+func TestShelfBookRepository_Create_MissingReferenceFails(t *testing.T) {
+	tests := map[string]struct {
+		toCreate *foreign_keys.ShelfBook
+	}{
+		"missing shelf": {
+			toCreate: foreign_keys.ShelfBook_builder{ShelfId: 2, BookId: 1}.Build(),
+		},
+		"missing book": {
+			toCreate: foreign_keys.ShelfBook_builder{ShelfId: 1, BookId: 3}.Build(),
+		},
+	}
+
+	for repoType, componentUnderTest := range foreignKeysImplementationsToTest() {
+		repoDesc := repoType.String()
+		for testDesc, tc := range tests {
+			// Call setup function, inject t *testing.T, and use t.Cleanup
+			components := componentUnderTest(t)
+			if components == nil {
+				t.Fatalf(
+					"%s: no implementation provided",
+					repoDesc,
+				)
+			}
+			seedLibrary(t, repoDesc, components)
+
+			_, err := components.shelfBooks.Create(context.Background(), []*foreign_keys.ShelfBook{tc.toCreate})
+			if !errors.Is(err, runtime.ErrForeignKeyViolation) {
+				t.Fatalf(
+					"%s: %s: Create(): expected %v, got %v",
+					repoDesc,
+					testDesc,
+					runtime.ErrForeignKeyViolation,
+					err,
+				)
+			}
+		}
+	}
+}
+
+func TestStatus_Delete_RestrictedWhileReferenced(t *testing.T) {
+	for repoType, componentUnderTest := range foreignKeysImplementationsToTest() {
+		repoDesc := repoType.String()
+		// Call setup function, inject t *testing.T, and use t.Cleanup
+		components := componentUnderTest(t)
+		if components == nil {
+			t.Fatalf(
+				"%s: no implementation provided",
+				repoDesc,
+			)
+		}
+		seedLibrary(t, repoDesc, components)
+
+		// the enum look-up table is not managed by a repository
+		_, err := components.db.Exec(`DELETE FROM "status" WHERE "id" = 2`)
+		if err == nil {
+			t.Fatalf(
+				"%s: Exec(): expected referenced enum value not to be deleted",
+				repoDesc,
+			)
+		}
+		_, err = components.db.Exec(`DELETE FROM "status" WHERE "id" = 0`)
+		if err != nil {
+			t.Fatalf(
+				"%s: Exec(): %s",
+				repoDesc,
+				err,
+			)
+		}
+	}
+}
+
+func foreignKeysImplementationsToTest() map[options.Implementation]foreignKeysComponentUnderTest {
+	return map[options.Implementation]foreignKeysComponentUnderTest{
+		options.Implementation_IMPLEMENTATION_SQLITE: sqliteForeignKeysComponentUnderTest,
+		options.Implementation_IMPLEMENTATION_PGSQL:  pgsqlForeignKeysComponentUnderTest,
+	}
+}
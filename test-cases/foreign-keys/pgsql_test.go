@@ -0,0 +1,59 @@
+package foreign_keys_test
+
+import (
+	"database/sql"
+	"os"
+	"testing"
+
+	foreign_keys "github.com/samlitowitz/protoc-gen-crud/test-cases/foreign-keys"
+
+	test_cases "github.com/samlitowitz/protoc-gen-crud/test-cases"
+)
+
+func pgsqlForeignKeysComponentUnderTest(t *testing.T) *foreignKeysComponents {
+	dburl, err := test_cases.PgSQLDBURLFromEnv()
+	if err != nil {
+		t.Fatal("pgsql: dburl: ", err)
+	}
+	db, err := sql.Open("pgx", dburl)
+	if err != nil {
+		t.Fatal("pgsql: ", err)
+	}
+	t.Cleanup(func() {
+		err := db.Close()
+		if err != nil {
+			t.Fatal("pgsql: ", err)
+		}
+	})
+
+	origDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal("pgsql: finding working dir:", err)
+	}
+
+	for _, file := range []string{"test.pgsql.sql", "test.crud.pgsql.sql"} {
+		err = test_cases.PgSQLExecSQLFile(db, origDir+string(os.PathSeparator)+file)
+		if err != nil {
+			t.Fatal("pgsql: executing setup SQL: ", err)
+		}
+	}
+
+	components := &foreignKeysComponents{db: db}
+	components.authors, err = foreign_keys.NewPgSQLAuthorRepository(db)
+	if err != nil {
+		t.Fatal("pgsql: creating repository: ", err)
+	}
+	components.books, err = foreign_keys.NewPgSQLBookRepository(db)
+	if err != nil {
+		t.Fatal("pgsql: creating repository: ", err)
+	}
+	components.shelves, err = foreign_keys.NewPgSQLShelfRepository(db)
+	if err != nil {
+		t.Fatal("pgsql: creating repository: ", err)
+	}
+	components.shelfBooks, err = foreign_keys.NewPgSQLShelfBookRepository(db)
+	if err != nil {
+		t.Fatal("pgsql: creating repository: ", err)
+	}
+	return components
+}
@@ -0,0 +1,72 @@
+package foreign_keys_test
+
+import (
+	"database/sql"
+	"os"
+	"testing"
+
+	foreign_keys "github.com/samlitowitz/protoc-gen-crud/test-cases/foreign-keys"
+)
+
+func sqliteExecSQLFile(db *sql.DB, file string) error {
+	code, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	stmt, err := db.Prepare(string(code))
+	if err != nil {
+		return err
+	}
+	_, err = stmt.Exec()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func sqliteForeignKeysComponentUnderTest(t *testing.T) *foreignKeysComponents {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal("sqlite: ", err)
+	}
+	// every connection to :memory: is a distinct database, and foreign keys are only enforced on the connection
+	// executing the setup SQL
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() {
+		err := db.Close()
+		if err != nil {
+			t.Fatal("sqlite: ", err)
+		}
+	})
+
+	origDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal("sqlite: finding working dir:", err)
+	}
+
+	for _, file := range []string{"test.sqlite.sql", "test.crud.sqlite.sql"} {
+		err = sqliteExecSQLFile(db, origDir+string(os.PathSeparator)+file)
+		if err != nil {
+			t.Fatal("sqlite: executing setup SQL: ", err)
+		}
+	}
+
+	components := &foreignKeysComponents{db: db}
+	components.authors, err = foreign_keys.NewSQLiteAuthorRepository(db)
+	if err != nil {
+		t.Fatal("sqlite: creating repository: ", err)
+	}
+	components.books, err = foreign_keys.NewSQLiteBookRepository(db)
+	if err != nil {
+		t.Fatal("sqlite: creating repository: ", err)
+	}
+	components.shelves, err = foreign_keys.NewSQLiteShelfRepository(db)
+	if err != nil {
+		t.Fatal("sqlite: creating repository: ", err)
+	}
+	components.shelfBooks, err = foreign_keys.NewSQLiteShelfBookRepository(db)
+	if err != nil {
+		t.Fatal("sqlite: creating repository: ", err)
+	}
+	return components
+}
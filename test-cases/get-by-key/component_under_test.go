@@ -0,0 +1,16 @@
+package get_by_key_test
+
+import (
+	"testing"
+
+	get_by_key "github.com/samlitowitz/protoc-gen-crud/test-cases/get-by-key"
+)
+
+type getByKeyRepositories struct {
+	widgets    get_by_key.WidgetRepository
+	composites get_by_key.CompositeRepository
+	blobs      get_by_key.BlobRepository
+}
+
+// getByKeyComponentUnderTest is to be implemented to do setup and tear down for each implementation
+type getByKeyComponentUnderTest func(t *testing.T) *getByKeyRepositories
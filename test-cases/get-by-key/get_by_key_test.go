@@ -0,0 +1,264 @@
+package get_by_key_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"google.golang.org/protobuf/testing/protocmp"
+
+	get_by_key "github.com/samlitowitz/protoc-gen-crud/test-cases/get-by-key"
+
+	"github.com/samlitowitz/protoc-gen-crud/options"
+	"github.com/samlitowitz/protoc-gen-crud/runtime"
+
+	"github.com/samlitowitz/expressions"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWidgetRepository_Get(t *testing.T) {
+	for repoType, componentUnderTest := range getByKeyImplementationsToTest() {
+		repoDesc := repoType.String()
+		// Call setup function, inject t *testing.T, and use t.Cleanup
+		repos := componentUnderTest(t)
+		if repos == nil {
+			t.Fatalf(
+				"%s: no implementation provided",
+				repoDesc,
+			)
+		}
+
+		created, err := repos.widgets.Create(context.Background(), []*get_by_key.Widget{
+			get_by_key.Widget_builder{Id: 1, Name: "one"}.Build(),
+			get_by_key.Widget_builder{Id: 2, Name: "two"}.Build(),
+		})
+		if err != nil {
+			t.Fatalf(
+				"%s: Create(): %s",
+				repoDesc,
+				err,
+			)
+		}
+
+		res, err := repos.widgets.Get(context.Background(), get_by_key.WidgetKeyOf(created[0]))
+		if err != nil {
+			t.Fatalf(
+				"%s: Get(): %s",
+				repoDesc,
+				err,
+			)
+		}
+		if diff := cmp.Diff(created[0], res, protocmp.Transform()); diff != "" {
+			t.Fatal(
+				mismatch(
+					fmt.Sprintf(
+						"%s: Get():",
+						repoDesc,
+					),
+					diff,
+				),
+			)
+		}
+
+		res, err = repos.widgets.Get(context.Background(), get_by_key.WidgetKey{Id: 3})
+		if !errors.Is(err, runtime.ErrNotFound) {
+			t.Fatalf(
+				"%s: Get(): expected %v, got %v",
+				repoDesc,
+				runtime.ErrNotFound,
+				err,
+			)
+		}
+		if res != nil {
+			t.Fatalf(
+				"%s: Get(): expected no widget, got %v",
+				repoDesc,
+				res,
+			)
+		}
+
+		byID := expressions.NewEquals(
+			expressions.NewIdentifier(get_by_key.Widget_Id_Field),
+			expressions.NewScalar(int32(2)),
+		)
+		if _, err := repos.widgets.Delete(context.Background(), byID); err != nil {
+			t.Fatalf(
+				"%s: Delete(): %s",
+				repoDesc,
+				err,
+			)
+		}
+		_, err = repos.widgets.Get(context.Background(), get_by_key.WidgetKey{Id: 2})
+		if !errors.Is(err, runtime.ErrNotFound) {
+			t.Fatalf(
+				"%s: Get(): soft deleted: expected %v, got %v",
+				repoDesc,
+				runtime.ErrNotFound,
+				err,
+			)
+		}
+	}
+}
+
+func TestCompositeRepository_GetMany(t *testing.T) {
+	for repoType, componentUnderTest := range getByKeyImplementationsToTest() {
+		repoDesc := repoType.String()
+		// Call setup function, inject t *testing.T, and use t.Cleanup
+		repos := componentUnderTest(t)
+		if repos == nil {
+			t.Fatalf(
+				"%s: no implementation provided",
+				repoDesc,
+			)
+		}
+
+		created, err := repos.composites.Create(context.Background(), []*get_by_key.Composite{
+			get_by_key.Composite_builder{
+				IdEnum:   get_by_key.Kind_KIND_ONE,
+				IdInt32:  1,
+				IdInt64:  1,
+				IdUint32: 1,
+				IdUint64: 1,
+				IdString: "one",
+				Data:     "first",
+			}.Build(),
+			get_by_key.Composite_builder{
+				IdEnum:   get_by_key.Kind_KIND_ONE,
+				IdInt32:  1,
+				IdInt64:  1,
+				IdUint32: 1,
+				IdUint64: 1,
+				IdString: "two",
+				Data:     "second",
+			}.Build(),
+			get_by_key.Composite_builder{
+				IdEnum:   get_by_key.Kind_KIND_TWO,
+				IdInt32:  -2,
+				IdInt64:  -2,
+				IdUint32: 2,
+				IdUint64: 2,
+				IdString: "one",
+				Data:     "third",
+			}.Build(),
+		})
+		if err != nil {
+			t.Fatalf(
+				"%s: Create(): %s",
+				repoDesc,
+				err,
+			)
+		}
+
+		keys := []get_by_key.CompositeKey{
+			get_by_key.CompositeKeyOf(created[2]),
+			get_by_key.CompositeKeyOf(created[0]),
+			get_by_key.CompositeKeyOf(created[1]),
+		}
+		res, err := repos.composites.GetMany(context.Background(), keys)
+		if err != nil {
+			t.Fatalf(
+				"%s: GetMany(): %s",
+				repoDesc,
+				err,
+			)
+		}
+		expected := []*get_by_key.Composite{created[2], created[0], created[1]}
+		if diff := cmp.Diff(expected, res, protocmp.Transform()); diff != "" {
+			t.Fatal(
+				mismatch(
+					fmt.Sprintf(
+						"%s: GetMany():",
+						repoDesc,
+					),
+					diff,
+				),
+			)
+		}
+
+		missing := get_by_key.CompositeKeyOf(created[0])
+		missing.IdString = "missing"
+		res, err = repos.composites.GetMany(context.Background(), []get_by_key.CompositeKey{keys[0], missing, keys[1]})
+		if !errors.Is(err, runtime.ErrNotFound) {
+			t.Fatalf(
+				"%s: GetMany(): expected %v, got %v",
+				repoDesc,
+				runtime.ErrNotFound,
+				err,
+			)
+		}
+		expected = []*get_by_key.Composite{created[2], nil, created[0]}
+		if diff := cmp.Diff(expected, res, protocmp.Transform()); diff != "" {
+			t.Fatal(
+				mismatch(
+					fmt.Sprintf(
+						"%s: GetMany(): with missing key:",
+						repoDesc,
+					),
+					diff,
+				),
+			)
+		}
+	}
+}
+
+func TestBlobRepository_GetMany(t *testing.T) {
+	for repoType, componentUnderTest := range getByKeyImplementationsToTest() {
+		repoDesc := repoType.String()
+		// Call setup function, inject t *testing.T, and use t.Cleanup
+		repos := componentUnderTest(t)
+		if repos == nil {
+			t.Fatalf(
+				"%s: no implementation provided",
+				repoDesc,
+			)
+		}
+
+		created, err := repos.blobs.Create(context.Background(), []*get_by_key.Blob{
+			get_by_key.Blob_builder{Id: []byte{0x00, 0x01}, Data: "first"}.Build(),
+			get_by_key.Blob_builder{Id: []byte{0xff}, Data: "second"}.Build(),
+		})
+		if err != nil {
+			t.Fatalf(
+				"%s: Create(): %s",
+				repoDesc,
+				err,
+			)
+		}
+
+		// bytes keys are held as strings so keys stay comparable
+		res, err := repos.blobs.GetMany(context.Background(), []get_by_key.BlobKey{
+			get_by_key.BlobKeyOf(created[1]),
+			{Id: "missing"},
+			get_by_key.BlobKeyOf(created[0]),
+		})
+		if !errors.Is(err, runtime.ErrNotFound) {
+			t.Fatalf(
+				"%s: GetMany(): expected %v, got %v",
+				repoDesc,
+				runtime.ErrNotFound,
+				err,
+			)
+		}
+		expected := []*get_by_key.Blob{created[1], nil, created[0]}
+		if diff := cmp.Diff(expected, res, protocmp.Transform()); diff != "" {
+			t.Fatal(
+				mismatch(
+					fmt.Sprintf(
+						"%s: GetMany():",
+						repoDesc,
+					),
+					diff,
+				),
+			)
+		}
+	}
+}
+
+func getByKeyImplementationsToTest() map[options.Implementation]getByKeyComponentUnderTest {
+	return map[options.Implementation]getByKeyComponentUnderTest{
+		options.Implementation_IMPLEMENTATION_SQLITE: sqliteGetByKeyComponentUnderTest,
+		options.Implementation_IMPLEMENTATION_PGSQL:  pgsqlGetByKeyComponentUnderTest,
+	}
+}
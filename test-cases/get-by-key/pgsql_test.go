@@ -0,0 +1,56 @@
+package get_by_key_test
+
+import (
+	"database/sql"
+	"os"
+	"testing"
+
+	get_by_key "github.com/samlitowitz/protoc-gen-crud/test-cases/get-by-key"
+
+	test_cases "github.com/samlitowitz/protoc-gen-crud/test-cases"
+)
+
+func pgsqlGetByKeyComponentUnderTest(t *testing.T) *getByKeyRepositories {
+	dburl, err := test_cases.PgSQLDBURLFromEnv()
+	if err != nil {
+		t.Fatal("pgsql: dburl: ", err)
+	}
+	db, err := sql.Open("pgx", dburl)
+	if err != nil {
+		t.Fatal("pgsql: ", err)
+	}
+	t.Cleanup(func() {
+		err := db.Close()
+		if err != nil {
+			t.Fatal("pgsql: ", err)
+		}
+	})
+
+	origDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal("pgsql: finding working dir:", err)
+	}
+
+	err = test_cases.PgSQLExecSQLFile(db, origDir+string(os.PathSeparator)+"test.pgsql.sql")
+	if err != nil {
+		t.Fatal("pgsql: executing setup SQL: ", err)
+	}
+
+	widgets, err := get_by_key.NewPgSQLWidgetRepository(db)
+	if err != nil {
+		t.Fatal("pgsql: creating repository: ", err)
+	}
+	composites, err := get_by_key.NewPgSQLCompositeRepository(db)
+	if err != nil {
+		t.Fatal("pgsql: creating repository: ", err)
+	}
+	blobs, err := get_by_key.NewPgSQLBlobRepository(db)
+	if err != nil {
+		t.Fatal("pgsql: creating repository: ", err)
+	}
+	return &getByKeyRepositories{
+		widgets:    widgets,
+		composites: composites,
+		blobs:      blobs,
+	}
+}
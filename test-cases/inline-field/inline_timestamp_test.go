@@ -2,6 +2,7 @@ package inline_field_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
@@ -13,6 +14,7 @@ import (
 	test_cases "github.com/samlitowitz/protoc-gen-crud/test-cases"
 
 	"github.com/samlitowitz/protoc-gen-crud/options"
+	"github.com/samlitowitz/protoc-gen-crud/runtime"
 
 	"github.com/samlitowitz/expressions"
 
@@ -299,10 +301,11 @@ func TestInlineTimestamp_DescriptorRepository_Update_WithUnLocatablePrimaryKeyUp
 			expected = append(expected, builder.Build())
 		}
 		_, err = repoImpl.Update(context.Background(), expected)
-		if err != nil {
+		if !errors.Is(err, runtime.ErrNotFound) {
 			t.Fatalf(
-				"%s: Update(): %s",
+				"%s: Update(): expected %v, got %v",
 				repoDesc,
+				runtime.ErrNotFound,
 				err,
 			)
 		}
@@ -621,7 +624,7 @@ func TestInlineTimestamp_DescriptorRepository_Delete_WithLocatablePrimaryKeySucc
 				)
 			}
 
-			err = repoImpl.Delete(context.Background(), testCase.deleteExpression)
+			deleted, err := repoImpl.Delete(context.Background(), testCase.deleteExpression)
 			if err != nil {
 				t.Fatalf(
 					"%s: %s: Delete(): %s",
@@ -630,6 +633,15 @@ func TestInlineTimestamp_DescriptorRepository_Delete_WithLocatablePrimaryKeySucc
 					err,
 				)
 			}
+			if deleted != int64(len(testCase.initial)-len(testCase.expected)) {
+				t.Fatalf(
+					"%s: %s: Delete(): expected %d deleted, got %d",
+					testDesc,
+					repoDesc,
+					len(testCase.initial)-len(testCase.expected),
+					deleted,
+				)
+			}
 
 			res, err = repoImpl.Read(context.Background(), nil)
 			if err != nil {
@@ -0,0 +1,31 @@
+package isolation_level_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	isolation_level "github.com/samlitowitz/protoc-gen-crud/test-cases/isolation-level"
+
+	"github.com/samlitowitz/protoc-gen-crud/runtime"
+)
+
+// recordingQuerier records the isolation level of every transaction begun on it
+type recordingQuerier struct {
+	*sql.DB
+	isolationLevels []sql.IsolationLevel
+}
+
+func (q *recordingQuerier) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	q.isolationLevels = append(q.isolationLevels, opts.Isolation)
+	return q.DB.BeginTx(ctx, opts)
+}
+
+type isolationComponents struct {
+	db       *recordingQuerier
+	accounts func(opts ...runtime.Option) (isolation_level.AccountRepository, error)
+	events   func(opts ...runtime.Option) (isolation_level.EventRepository, error)
+}
+
+// isolationComponentUnderTest is to be implemented to do setup and tear down for each implementation
+type isolationComponentUnderTest func(t *testing.T) *isolationComponents
@@ -0,0 +1,127 @@
+package isolation_level_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	isolation_level "github.com/samlitowitz/protoc-gen-crud/test-cases/isolation-level"
+
+	"github.com/samlitowitz/protoc-gen-crud/options"
+
+	"github.com/samlitowitz/protoc-gen-crud/runtime"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRepository_BeginsTransactionsWithIsolationLevel(t *testing.T) {
+	testCases := map[string]struct {
+		opts     []runtime.Option
+		run      func(components *isolationComponents, opts ...runtime.Option) error
+		expected sql.IsolationLevel
+	}{
+		"message default": {
+			run:      isolationAccountCreateAndUpdate,
+			expected: sql.LevelSerializable,
+		},
+		"message option": {
+			run:      isolationEventCreateAndUpdate,
+			expected: sql.LevelReadCommitted,
+		},
+		"message default overridden": {
+			opts:     []runtime.Option{runtime.WithIsolationLevel(sql.LevelRepeatableRead)},
+			run:      isolationAccountCreateAndUpdate,
+			expected: sql.LevelRepeatableRead,
+		},
+		"message option overridden": {
+			opts:     []runtime.Option{runtime.WithIsolationLevel(sql.LevelSerializable)},
+			run:      isolationEventCreateAndUpdate,
+			expected: sql.LevelSerializable,
+		},
+	}
+
+	for repoType, componentUnderTest := range isolationImplementationsToTest() {
+		repoDesc := repoType.String()
+		for testDesc, testCase := range testCases {
+			// Call setup function, inject t *testing.T, and use t.Cleanup
+			components := componentUnderTest(t)
+			if components == nil {
+				t.Fatalf(
+					"%s: no implementation provided",
+					repoDesc,
+				)
+			}
+
+			if err := testCase.run(components, testCase.opts...); err != nil {
+				t.Fatalf(
+					"%s: %s: %s",
+					repoDesc,
+					testDesc,
+					err,
+				)
+			}
+			// one transaction for each of Create and Update
+			expected := []sql.IsolationLevel{testCase.expected, testCase.expected}
+			if diff := cmp.Diff(expected, components.db.isolationLevels); diff != "" {
+				t.Fatal(
+					mismatch(
+						fmt.Sprintf(
+							"%s: %s: isolation levels:",
+							repoDesc,
+							testDesc,
+						),
+						diff,
+					),
+				)
+			}
+		}
+	}
+}
+
+func isolationAccountCreateAndUpdate(components *isolationComponents, opts ...runtime.Option) error {
+	repo, err := components.accounts(opts...)
+	if err != nil {
+		return fmt.Errorf("creating repository: %w", err)
+	}
+	_, err = repo.Create(context.Background(), []*isolation_level.Account{
+		isolation_level.Account_builder{Id: 1, Balance: 10}.Build(),
+	})
+	if err != nil {
+		return fmt.Errorf("Create(): %w", err)
+	}
+	_, err = repo.Update(context.Background(), []*isolation_level.Account{
+		isolation_level.Account_builder{Id: 1, Balance: 20}.Build(),
+	})
+	if err != nil {
+		return fmt.Errorf("Update(): %w", err)
+	}
+	return nil
+}
+
+func isolationEventCreateAndUpdate(components *isolationComponents, opts ...runtime.Option) error {
+	repo, err := components.events(opts...)
+	if err != nil {
+		return fmt.Errorf("creating repository: %w", err)
+	}
+	_, err = repo.Create(context.Background(), []*isolation_level.Event{
+		isolation_level.Event_builder{Id: 1, Data: "created"}.Build(),
+	})
+	if err != nil {
+		return fmt.Errorf("Create(): %w", err)
+	}
+	_, err = repo.Update(context.Background(), []*isolation_level.Event{
+		isolation_level.Event_builder{Id: 1, Data: "updated"}.Build(),
+	})
+	if err != nil {
+		return fmt.Errorf("Update(): %w", err)
+	}
+	return nil
+}
+
+func isolationImplementationsToTest() map[options.Implementation]isolationComponentUnderTest {
+	return map[options.Implementation]isolationComponentUnderTest{
+		options.Implementation_IMPLEMENTATION_SQLITE: sqliteIsolationComponentUnderTest,
+		options.Implementation_IMPLEMENTATION_PGSQL:  pgsqlIsolationComponentUnderTest,
+	}
+}
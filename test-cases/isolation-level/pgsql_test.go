@@ -0,0 +1,51 @@
+package isolation_level_test
+
+import (
+	"database/sql"
+	"os"
+	"testing"
+
+	isolation_level "github.com/samlitowitz/protoc-gen-crud/test-cases/isolation-level"
+
+	"github.com/samlitowitz/protoc-gen-crud/runtime"
+
+	test_cases "github.com/samlitowitz/protoc-gen-crud/test-cases"
+)
+
+func pgsqlIsolationComponentUnderTest(t *testing.T) *isolationComponents {
+	dburl, err := test_cases.PgSQLDBURLFromEnv()
+	if err != nil {
+		t.Fatal("pgsql: dburl: ", err)
+	}
+	db, err := sql.Open("pgx", dburl)
+	if err != nil {
+		t.Fatal("pgsql: ", err)
+	}
+	t.Cleanup(func() {
+		err := db.Close()
+		if err != nil {
+			t.Fatal("pgsql: ", err)
+		}
+	})
+
+	origDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal("pgsql: finding working dir:", err)
+	}
+
+	err = test_cases.PgSQLExecSQLFile(db, origDir+string(os.PathSeparator)+"test.pgsql.sql")
+	if err != nil {
+		t.Fatal("pgsql: executing setup SQL: ", err)
+	}
+
+	querier := &recordingQuerier{DB: db}
+	return &isolationComponents{
+		db: querier,
+		accounts: func(opts ...runtime.Option) (isolation_level.AccountRepository, error) {
+			return isolation_level.NewPgSQLAccountRepository(querier, opts...)
+		},
+		events: func(opts ...runtime.Option) (isolation_level.EventRepository, error) {
+			return isolation_level.NewPgSQLEventRepository(querier, opts...)
+		},
+	}
+}
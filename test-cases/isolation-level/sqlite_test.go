@@ -0,0 +1,63 @@
+package isolation_level_test
+
+import (
+	"database/sql"
+	"os"
+	"testing"
+
+	isolation_level "github.com/samlitowitz/protoc-gen-crud/test-cases/isolation-level"
+
+	"github.com/samlitowitz/protoc-gen-crud/runtime"
+)
+
+func sqliteExecSQLFile(db *sql.DB, file string) error {
+	code, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	stmt, err := db.Prepare(string(code))
+	if err != nil {
+		return err
+	}
+	_, err = stmt.Exec()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func sqliteIsolationComponentUnderTest(t *testing.T) *isolationComponents {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal("sqlite: ", err)
+	}
+	// every connection to :memory: is a distinct database
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() {
+		err := db.Close()
+		if err != nil {
+			t.Fatal("sqlite: ", err)
+		}
+	})
+
+	origDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal("sqlite: finding working dir:", err)
+	}
+
+	err = sqliteExecSQLFile(db, origDir+string(os.PathSeparator)+"test.sqlite.sql")
+	if err != nil {
+		t.Fatal("sqlite: executing setup SQL: ", err)
+	}
+
+	querier := &recordingQuerier{DB: db}
+	return &isolationComponents{
+		db: querier,
+		accounts: func(opts ...runtime.Option) (isolation_level.AccountRepository, error) {
+			return isolation_level.NewSQLiteAccountRepository(querier, opts...)
+		},
+		events: func(opts ...runtime.Option) (isolation_level.EventRepository, error) {
+			return isolation_level.NewSQLiteEventRepository(querier, opts...)
+		},
+	}
+}
@@ -0,0 +1,10 @@
+package nullable_test
+
+import (
+	"testing"
+
+	"github.com/samlitowitz/protoc-gen-crud/test-cases/nullable"
+)
+
+// nullableComponentUnderTest is to be implemented to do setup and tear down for each implementation
+type nullableComponentUnderTest func(t *testing.T) nullable.ContactRepository
@@ -0,0 +1,222 @@
+package nullable_test
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/samlitowitz/protoc-gen-crud/test-cases/nullable"
+
+	crudexpressions "github.com/samlitowitz/protoc-gen-crud/expressions"
+	"github.com/samlitowitz/protoc-gen-crud/options"
+
+	"github.com/samlitowitz/expressions"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func seedContacts(t *testing.T, repoDesc string, repo nullable.ContactRepository) []*nullable.Contact {
+	contacts := []*nullable.Contact{
+		nullable.Contact_builder{Id: 1, Name: "unset"}.Build(),
+		// set to zero values, which are distinct from unset ones
+		nullable.Contact_builder{
+			Id:       2,
+			Name:     "zero",
+			Email:    wrapperspb.String(""),
+			Age:      wrapperspb.Int64(0),
+			Verified: wrapperspb.Bool(false),
+			Score:    wrapperspb.Double(0),
+			Avatar:   wrapperspb.Bytes(nil),
+			Visits:   wrapperspb.UInt64(0),
+			Rank:     proto.Int32(0),
+		}.Build(),
+		nullable.Contact_builder{
+			Id:       3,
+			Name:     "set",
+			Email:    wrapperspb.String("three@example.com"),
+			Age:      wrapperspb.Int64(33),
+			Verified: wrapperspb.Bool(true),
+			Score:    wrapperspb.Double(3.5),
+			Avatar:   wrapperspb.Bytes([]byte{3}),
+			Visits:   wrapperspb.UInt64(3),
+			Rank:     proto.Int32(3),
+		}.Build(),
+	}
+	_, err := repo.Create(context.Background(), contacts)
+	if err != nil {
+		t.Fatalf(
+			"%s: Create(): %s",
+			repoDesc,
+			err,
+		)
+	}
+	return contacts
+}
+
+func readContacts(t *testing.T, repoDesc string, repo nullable.ContactRepository, expr expressions.Expression) []*nullable.Contact {
+	res, err := repo.Read(context.Background(), expr)
+	if err != nil {
+		t.Fatalf(
+			"%s: Read(): %s",
+			repoDesc,
+			err,
+		)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].GetId() < res[j].GetId() })
+	return res
+}
+
+func TestContactRepository_Create_RoundTripsUnsetAsNull(t *testing.T) {
+	for repoType, componentUnderTest := range nullableImplementationsToTest() {
+		repoDesc := repoType.String()
+		// Call setup function, inject t *testing.T, and use t.Cleanup
+		repo := componentUnderTest(t)
+		if repo == nil {
+			t.Fatalf(
+				"%s: no implementation provided",
+				repoDesc,
+			)
+		}
+		expected := seedContacts(t, repoDesc, repo)
+
+		if diff := cmp.Diff(expected, readContacts(t, repoDesc, repo, nil), nullableDefaultCmpOpts()...); diff != "" {
+			t.Fatal(mismatch(fmt.Sprintf("%s: Read():", repoDesc), diff))
+		}
+	}
+}
+
+func TestContactRepository_Update_ClearsToNull(t *testing.T) {
+	tests := map[string]struct {
+		fieldMask *fieldmaskpb.FieldMask
+		expected  *nullable.Contact
+	}{
+		"without field mask": {
+			expected: nullable.Contact_builder{Id: 3, Name: "cleared"}.Build(),
+		},
+		"with field mask": {
+			fieldMask: &fieldmaskpb.FieldMask{Paths: []string{"id", "email", "rank"}},
+			expected: nullable.Contact_builder{
+				Id:       3,
+				Name:     "set",
+				Age:      wrapperspb.Int64(33),
+				Verified: wrapperspb.Bool(true),
+				Score:    wrapperspb.Double(3.5),
+				Avatar:   wrapperspb.Bytes([]byte{3}),
+				Visits:   wrapperspb.UInt64(3),
+			}.Build(),
+		},
+	}
+
+	for repoType, componentUnderTest := range nullableImplementationsToTest() {
+		repoDesc := repoType.String()
+		for testDesc, tc := range tests {
+			// Call setup function, inject t *testing.T, and use t.Cleanup
+			repo := componentUnderTest(t)
+			if repo == nil {
+				t.Fatalf(
+					"%s: no implementation provided",
+					repoDesc,
+				)
+			}
+			seedContacts(t, repoDesc, repo)
+
+			_, err := repo.Update(context.Background(), []*nullable.Contact{
+				nullable.Contact_builder{FieldMask: tc.fieldMask, Id: 3, Name: "cleared"}.Build(),
+			})
+			if err != nil {
+				t.Fatalf(
+					"%s: %s: Update(): %s",
+					repoDesc,
+					testDesc,
+					err,
+				)
+			}
+
+			byID := expressions.NewEquals(
+				expressions.NewIdentifier(nullable.Contact_Id_Field),
+				expressions.NewScalar(int32(3)),
+			)
+			expected := []*nullable.Contact{tc.expected}
+			if diff := cmp.Diff(expected, readContacts(t, repoDesc, repo, byID), nullableDefaultCmpOpts()...); diff != "" {
+				t.Fatal(mismatch(fmt.Sprintf("%s: %s: Read():", repoDesc, testDesc), diff))
+			}
+		}
+	}
+}
+
+func TestContactRepository_Read_FiltersByNull(t *testing.T) {
+	tests := map[string]struct {
+		expr        expressions.Expression
+		expectedIDs []int32
+	}{
+		"wrapper is null": {
+			expr:        crudexpressions.NewIsNull(expressions.NewIdentifier(nullable.Contact_Email_Field)),
+			expectedIDs: []int32{1},
+		},
+		"wrapper is not null": {
+			expr:        crudexpressions.NewIsNotNull(expressions.NewIdentifier(nullable.Contact_Email_Field)),
+			expectedIDs: []int32{2, 3},
+		},
+		"wrapper equals": {
+			expr: expressions.NewEquals(
+				expressions.NewIdentifier(nullable.Contact_Age_Field),
+				expressions.NewScalar(int64(33)),
+			),
+			expectedIDs: []int32{3},
+		},
+		"optional is null": {
+			expr:        crudexpressions.NewIsNull(expressions.NewIdentifier(nullable.Contact_Rank_Field)),
+			expectedIDs: []int32{1},
+		},
+		"optional equals zero": {
+			expr: expressions.NewEquals(
+				expressions.NewIdentifier(nullable.Contact_Rank_Field),
+				expressions.NewScalar(int32(0)),
+			),
+			expectedIDs: []int32{2},
+		},
+	}
+
+	for repoType, componentUnderTest := range nullableImplementationsToTest() {
+		repoDesc := repoType.String()
+		// Call setup function, inject t *testing.T, and use t.Cleanup
+		repo := componentUnderTest(t)
+		if repo == nil {
+			t.Fatalf(
+				"%s: no implementation provided",
+				repoDesc,
+			)
+		}
+		seedContacts(t, repoDesc, repo)
+
+		for testDesc, tc := range tests {
+			var ids []int32
+			for _, contact := range readContacts(t, repoDesc, repo, tc.expr) {
+				ids = append(ids, contact.GetId())
+			}
+			if diff := cmp.Diff(tc.expectedIDs, ids); diff != "" {
+				t.Fatal(mismatch(fmt.Sprintf("%s: %s: Read():", repoDesc, testDesc), diff))
+			}
+		}
+	}
+}
+
+func nullableImplementationsToTest() map[options.Implementation]nullableComponentUnderTest {
+	return map[options.Implementation]nullableComponentUnderTest{
+		options.Implementation_IMPLEMENTATION_SQLITE: sqliteNullableComponentUnderTest,
+		options.Implementation_IMPLEMENTATION_PGSQL:  pgsqlNullableComponentUnderTest,
+	}
+}
+
+func nullableDefaultCmpOpts() cmp.Options {
+	return cmp.Options{
+		protocmp.Transform(),
+		protocmp.IgnoreFields(&nullable.Contact{}, "fieldMask"),
+	}
+}
@@ -0,0 +1,12 @@
+package optimistic_locking_test
+
+import (
+	"testing"
+
+	optimistic_locking "github.com/samlitowitz/protoc-gen-crud/test-cases/optimistic-locking"
+
+	"github.com/samlitowitz/protoc-gen-crud/runtime"
+)
+
+// optimisticLockingComponentUnderTest is to be implemented to do setup and tear down for each implementation
+type optimisticLockingComponentUnderTest func(t *testing.T, opts ...runtime.Option) optimistic_locking.SettingRepository
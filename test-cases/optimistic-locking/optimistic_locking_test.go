@@ -0,0 +1,328 @@
+package optimistic_locking_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	optimistic_locking "github.com/samlitowitz/protoc-gen-crud/test-cases/optimistic-locking"
+
+	"github.com/samlitowitz/protoc-gen-crud/options"
+	"github.com/samlitowitz/protoc-gen-crud/runtime"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func seedSettings(t *testing.T, repoDesc string, repo optimistic_locking.SettingRepository) {
+	_, err := repo.Create(context.Background(), []*optimistic_locking.Setting{
+		optimistic_locking.Setting_builder{Id: 1, Name: "timeout", Value: "30s", Version: 1}.Build(),
+		optimistic_locking.Setting_builder{Id: 2, Name: "retries", Value: "3", Version: 1}.Build(),
+	})
+	if err != nil {
+		t.Fatalf(
+			"%s: Create(): %s",
+			repoDesc,
+			err,
+		)
+	}
+}
+
+func readSettings(t *testing.T, repoDesc string, repo optimistic_locking.SettingRepository) []*optimistic_locking.Setting {
+	res, err := repo.Read(context.Background(), nil)
+	if err != nil {
+		t.Fatalf(
+			"%s: Read(): %s",
+			repoDesc,
+			err,
+		)
+	}
+	return res
+}
+
+func TestSettingRepository_Update_IncrementsVersion(t *testing.T) {
+	tests := map[string]struct {
+		toUpdate *optimistic_locking.Setting
+		expected []*optimistic_locking.Setting
+	}{
+		"without field mask": {
+			toUpdate: optimistic_locking.Setting_builder{Id: 1, Name: "timeout", Value: "60s", Version: 1}.Build(),
+			expected: []*optimistic_locking.Setting{
+				optimistic_locking.Setting_builder{Id: 1, Name: "timeout", Value: "60s", Version: 2}.Build(),
+				optimistic_locking.Setting_builder{Id: 2, Name: "retries", Value: "3", Version: 1}.Build(),
+			},
+		},
+		"with field mask": {
+			toUpdate: optimistic_locking.Setting_builder{
+				FieldMask: &fieldmaskpb.FieldMask{Paths: []string{"id", "value"}},
+				Id:        2,
+				Value:     "5",
+				Version:   1,
+			}.Build(),
+			expected: []*optimistic_locking.Setting{
+				optimistic_locking.Setting_builder{Id: 1, Name: "timeout", Value: "30s", Version: 1}.Build(),
+				optimistic_locking.Setting_builder{Id: 2, Name: "retries", Value: "5", Version: 2}.Build(),
+			},
+		},
+	}
+
+	for repoType, componentUnderTest := range optimisticLockingImplementationsToTest() {
+		repoDesc := repoType.String()
+		for testDesc, tc := range tests {
+			// Call setup function, inject t *testing.T, and use t.Cleanup
+			repo := componentUnderTest(t)
+			if repo == nil {
+				t.Fatalf(
+					"%s: no implementation provided",
+					repoDesc,
+				)
+			}
+			seedSettings(t, repoDesc, repo)
+
+			updated, err := repo.Update(context.Background(), []*optimistic_locking.Setting{tc.toUpdate})
+			if err != nil {
+				t.Fatalf(
+					"%s: %s: Update(): %s",
+					repoDesc,
+					testDesc,
+					err,
+				)
+			}
+			if updated[0].GetVersion() != 2 {
+				t.Fatalf(
+					"%s: %s: Update(): expected version 2, got %d",
+					repoDesc,
+					testDesc,
+					updated[0].GetVersion(),
+				)
+			}
+
+			if diff := cmp.Diff(tc.expected, readSettings(t, repoDesc, repo), protocmp.Transform()); diff != "" {
+				t.Fatal(mismatch(fmt.Sprintf("%s: %s: Read():", repoDesc, testDesc), diff))
+			}
+		}
+	}
+}
+
+func TestSettingRepository_Update_StaleVersionConflicts(t *testing.T) {
+	tests := map[string]struct {
+		fieldMask *fieldmaskpb.FieldMask
+	}{
+		"without field mask": {},
+		"with field mask": {
+			fieldMask: &fieldmaskpb.FieldMask{Paths: []string{"id", "value"}},
+		},
+	}
+
+	for repoType, componentUnderTest := range optimisticLockingImplementationsToTest() {
+		repoDesc := repoType.String()
+		for testDesc, tc := range tests {
+			// Call setup function, inject t *testing.T, and use t.Cleanup
+			repo := componentUnderTest(t)
+			if repo == nil {
+				t.Fatalf(
+					"%s: no implementation provided",
+					repoDesc,
+				)
+			}
+			seedSettings(t, repoDesc, repo)
+
+			// two writers read the same version, the first to update wins
+			first := optimistic_locking.Setting_builder{FieldMask: tc.fieldMask, Id: 1, Name: "timeout", Value: "60s", Version: 1}.Build()
+			second := optimistic_locking.Setting_builder{FieldMask: tc.fieldMask, Id: 1, Name: "timeout", Value: "90s", Version: 1}.Build()
+			_, err := repo.Update(context.Background(), []*optimistic_locking.Setting{first})
+			if err != nil {
+				t.Fatalf(
+					"%s: %s: Update(): first: %s",
+					repoDesc,
+					testDesc,
+					err,
+				)
+			}
+			_, err = repo.Update(context.Background(), []*optimistic_locking.Setting{second})
+			if !errors.Is(err, runtime.ErrVersionConflict) {
+				t.Fatalf(
+					"%s: %s: Update(): second: expected %v, got %v",
+					repoDesc,
+					testDesc,
+					runtime.ErrVersionConflict,
+					err,
+				)
+			}
+			if second.GetVersion() != 1 {
+				t.Fatalf(
+					"%s: %s: Update(): second: expected version 1, got %d",
+					repoDesc,
+					testDesc,
+					second.GetVersion(),
+				)
+			}
+
+			expected := []*optimistic_locking.Setting{
+				optimistic_locking.Setting_builder{Id: 1, Name: "timeout", Value: "60s", Version: 2}.Build(),
+				optimistic_locking.Setting_builder{Id: 2, Name: "retries", Value: "3", Version: 1}.Build(),
+			}
+			if diff := cmp.Diff(expected, readSettings(t, repoDesc, repo), protocmp.Transform()); diff != "" {
+				t.Fatal(mismatch(fmt.Sprintf("%s: %s: Read():", repoDesc, testDesc), diff))
+			}
+		}
+	}
+}
+
+func TestSettingRepository_Upsert_StaleVersionConflicts(t *testing.T) {
+	tests := map[string]struct {
+		fieldMask *fieldmaskpb.FieldMask
+	}{
+		"without field mask": {},
+		"with field mask": {
+			fieldMask: &fieldmaskpb.FieldMask{Paths: []string{"id", "value"}},
+		},
+	}
+
+	for repoType, componentUnderTest := range optimisticLockingImplementationsToTest() {
+		repoDesc := repoType.String()
+		for testDesc, tc := range tests {
+			// Call setup function, inject t *testing.T, and use t.Cleanup
+			repo := componentUnderTest(t)
+			if repo == nil {
+				t.Fatalf(
+					"%s: no implementation provided",
+					repoDesc,
+				)
+			}
+			seedSettings(t, repoDesc, repo)
+
+			// two writers read the same version, the first to upsert wins and a new setting is created as provided
+			first := optimistic_locking.Setting_builder{FieldMask: tc.fieldMask, Id: 1, Name: "timeout", Value: "60s", Version: 1}.Build()
+			created := optimistic_locking.Setting_builder{FieldMask: tc.fieldMask, Id: 3, Name: "backoff", Value: "1s", Version: 1}.Build()
+			second := optimistic_locking.Setting_builder{FieldMask: tc.fieldMask, Id: 1, Name: "timeout", Value: "90s", Version: 1}.Build()
+			_, err := repo.Upsert(context.Background(), []*optimistic_locking.Setting{first, created})
+			if err != nil {
+				t.Fatalf(
+					"%s: %s: Upsert(): first: %s",
+					repoDesc,
+					testDesc,
+					err,
+				)
+			}
+			if first.GetVersion() != 2 {
+				t.Fatalf(
+					"%s: %s: Upsert(): first: expected version 2, got %d",
+					repoDesc,
+					testDesc,
+					first.GetVersion(),
+				)
+			}
+			_, err = repo.Upsert(context.Background(), []*optimistic_locking.Setting{second})
+			if !errors.Is(err, runtime.ErrVersionConflict) {
+				t.Fatalf(
+					"%s: %s: Upsert(): second: expected %v, got %v",
+					repoDesc,
+					testDesc,
+					runtime.ErrVersionConflict,
+					err,
+				)
+			}
+
+			expected := []*optimistic_locking.Setting{
+				optimistic_locking.Setting_builder{Id: 1, Name: "timeout", Value: "60s", Version: 2}.Build(),
+				optimistic_locking.Setting_builder{Id: 2, Name: "retries", Value: "3", Version: 1}.Build(),
+				optimistic_locking.Setting_builder{Id: 3, Name: "backoff", Value: "1s", Version: 1}.Build(),
+			}
+			if tc.fieldMask != nil {
+				// the name is neither created nor updated when masked out
+				expected[2].SetName("")
+			}
+			if diff := cmp.Diff(expected, readSettings(t, repoDesc, repo), protocmp.Transform()); diff != "" {
+				t.Fatal(mismatch(fmt.Sprintf("%s: %s: Read():", repoDesc, testDesc), diff))
+			}
+		}
+	}
+}
+
+func TestSettingRepository_Update_MissingIsNotAConflict(t *testing.T) {
+	for repoType, componentUnderTest := range optimisticLockingImplementationsToTest() {
+		repoDesc := repoType.String()
+		// Call setup function, inject t *testing.T, and use t.Cleanup
+		repo := componentUnderTest(t)
+		if repo == nil {
+			t.Fatalf(
+				"%s: no implementation provided",
+				repoDesc,
+			)
+		}
+		seedSettings(t, repoDesc, repo)
+
+		_, err := repo.Update(context.Background(), []*optimistic_locking.Setting{
+			optimistic_locking.Setting_builder{Id: 3, Name: "missing", Version: 1}.Build(),
+		})
+		if !errors.Is(err, runtime.ErrNotFound) || errors.Is(err, runtime.ErrVersionConflict) {
+			t.Fatalf(
+				"%s: Update(): expected %v, got %v",
+				repoDesc,
+				runtime.ErrNotFound,
+				err,
+			)
+		}
+	}
+}
+
+func TestSettingRepository_Update_PartialSuccess(t *testing.T) {
+	for repoType, componentUnderTest := range optimisticLockingImplementationsToTest() {
+		repoDesc := repoType.String()
+		// Call setup function, inject t *testing.T, and use t.Cleanup
+		repo := componentUnderTest(t, runtime.WithBatchMode(runtime.BatchPartialSuccess))
+		if repo == nil {
+			t.Fatalf(
+				"%s: no implementation provided",
+				repoDesc,
+			)
+		}
+		seedSettings(t, repoDesc, repo)
+
+		stale := optimistic_locking.Setting_builder{Id: 1, Name: "timeout", Value: "60s", Version: 0}.Build()
+		current := optimistic_locking.Setting_builder{Id: 2, Name: "retries", Value: "5", Version: 1}.Build()
+		updated, err := repo.Update(context.Background(), []*optimistic_locking.Setting{stale, current})
+		var batchErr *runtime.BatchError
+		if !errors.As(err, &batchErr) {
+			t.Fatalf(
+				"%s: Update(): expected %T, got %v",
+				repoDesc,
+				batchErr,
+				err,
+			)
+		}
+		if len(batchErr.Errors) != 1 || batchErr.Errors[0].Index != 0 || !errors.Is(batchErr.Errors[0], runtime.ErrVersionConflict) {
+			t.Fatalf(
+				"%s: Update(): expected item 0 to conflict, got %v",
+				repoDesc,
+				batchErr,
+			)
+		}
+		if updated[0] != nil || stale.GetVersion() != 0 || current.GetVersion() != 2 {
+			t.Fatalf(
+				"%s: Update(): expected only item 1 to be updated, got %v",
+				repoDesc,
+				updated,
+			)
+		}
+
+		expected := []*optimistic_locking.Setting{
+			optimistic_locking.Setting_builder{Id: 1, Name: "timeout", Value: "30s", Version: 1}.Build(),
+			optimistic_locking.Setting_builder{Id: 2, Name: "retries", Value: "5", Version: 2}.Build(),
+		}
+		if diff := cmp.Diff(expected, readSettings(t, repoDesc, repo), protocmp.Transform()); diff != "" {
+			t.Fatal(mismatch(fmt.Sprintf("%s: Read():", repoDesc), diff))
+		}
+	}
+}
+
+func optimisticLockingImplementationsToTest() map[options.Implementation]optimisticLockingComponentUnderTest {
+	return map[options.Implementation]optimisticLockingComponentUnderTest{
+		options.Implementation_IMPLEMENTATION_SQLITE: sqliteOptimisticLockingComponentUnderTest,
+		options.Implementation_IMPLEMENTATION_PGSQL:  pgsqlOptimisticLockingComponentUnderTest,
+	}
+}
@@ -0,0 +1,46 @@
+package optimistic_locking_test
+
+import (
+	"database/sql"
+	"os"
+	"testing"
+
+	optimistic_locking "github.com/samlitowitz/protoc-gen-crud/test-cases/optimistic-locking"
+
+	"github.com/samlitowitz/protoc-gen-crud/runtime"
+
+	test_cases "github.com/samlitowitz/protoc-gen-crud/test-cases"
+)
+
+func pgsqlOptimisticLockingComponentUnderTest(t *testing.T, opts ...runtime.Option) optimistic_locking.SettingRepository {
+	dburl, err := test_cases.PgSQLDBURLFromEnv()
+	if err != nil {
+		t.Fatal("pgsql: dburl: ", err)
+	}
+	db, err := sql.Open("pgx", dburl)
+	if err != nil {
+		t.Fatal("pgsql: ", err)
+	}
+	t.Cleanup(func() {
+		err := db.Close()
+		if err != nil {
+			t.Fatal("pgsql: ", err)
+		}
+	})
+
+	origDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal("pgsql: finding working dir:", err)
+	}
+
+	err = test_cases.PgSQLExecSQLFile(db, origDir+string(os.PathSeparator)+"test.pgsql.sql")
+	if err != nil {
+		t.Fatal("pgsql: executing setup SQL: ", err)
+	}
+
+	repo, err := optimistic_locking.NewPgSQLSettingRepository(db, opts...)
+	if err != nil {
+		t.Fatal("pgsql: creating repository: ", err)
+	}
+	return repo
+}
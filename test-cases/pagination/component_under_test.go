@@ -0,0 +1,10 @@
+package pagination_test
+
+import (
+	"testing"
+
+	pagination "github.com/samlitowitz/protoc-gen-crud/test-cases/pagination"
+)
+
+// listingComponentUnderTest is to be implemented to do setup and tear down for each implementation
+type listingComponentUnderTest func(t *testing.T) pagination.ListingRepository
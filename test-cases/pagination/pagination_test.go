@@ -0,0 +1,520 @@
+package pagination_test
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	pagination "github.com/samlitowitz/protoc-gen-crud/test-cases/pagination"
+
+	"github.com/samlitowitz/protoc-gen-crud/options"
+
+	crudexpressions "github.com/samlitowitz/protoc-gen-crud/expressions"
+
+	"github.com/samlitowitz/expressions"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const listingPageSize = 3
+
+func TestListingRepository_ReadWithOptions_OrdersLimitsAndOffsets(t *testing.T) {
+	testCases := map[string]struct {
+		options     *pagination.ListingReadOptions
+		expectedIDs []int32
+	}{
+		"order by primary key": {
+			options:     &pagination.ListingReadOptions{Limit: 4},
+			expectedIDs: []int32{0, 1, 2, 3},
+		},
+		"order by rank with primary key tie breaker": {
+			options: &pagination.ListingReadOptions{
+				OrderBy: []pagination.ListingOrderBy{
+					{Field: pagination.Listing_Rank_Field},
+				},
+			},
+			expectedIDs: []int32{0, 3, 6, 9, 1, 4, 7, 2, 5, 8},
+		},
+		"order by rank descending then id descending": {
+			options: &pagination.ListingReadOptions{
+				OrderBy: []pagination.ListingOrderBy{
+					{Field: pagination.Listing_Rank_Field, Descending: true},
+					{Field: pagination.Listing_Id_Field, Descending: true},
+				},
+			},
+			expectedIDs: []int32{8, 5, 2, 7, 4, 1, 9, 6, 3, 0},
+		},
+		"limit and offset": {
+			options: &pagination.ListingReadOptions{
+				OrderBy: []pagination.ListingOrderBy{
+					{Field: pagination.Listing_Name_Field},
+				},
+				Limit:  3,
+				Offset: 2,
+			},
+			expectedIDs: []int32{5, 4, 3},
+		},
+		"offset without limit": {
+			options:     &pagination.ListingReadOptions{Offset: 7},
+			expectedIDs: []int32{7, 8, 9},
+		},
+	}
+
+	for repoType, componentUnderTest := range listingImplementationsToTest() {
+		repoDesc := repoType.String()
+		// Call setup function, inject t *testing.T, and use t.Cleanup
+		repoImpl := componentUnderTest(t)
+		if repoImpl == nil {
+			t.Fatalf(
+				"%s: no implementation provided",
+				repoDesc,
+			)
+		}
+
+		_, err := repoImpl.Create(context.Background(), listingInitial())
+		if err != nil {
+			t.Fatalf(
+				"%s: Create(): %s",
+				repoDesc,
+				err,
+			)
+		}
+
+		for testDesc, testCase := range testCases {
+			res, _, err := repoImpl.ReadWithOptions(context.Background(), nil, testCase.options)
+			if err != nil {
+				t.Fatalf(
+					"%s: %s: ReadWithOptions(): %s",
+					repoDesc,
+					testDesc,
+					err,
+				)
+			}
+			if diff := cmp.Diff(testCase.expectedIDs, listingIDs(res)); diff != "" {
+				t.Fatal(
+					mismatch(
+						fmt.Sprintf(
+							"%s: %s: ReadWithOptions():",
+							repoDesc,
+							testDesc,
+						),
+						diff,
+					),
+				)
+			}
+		}
+	}
+}
+
+func TestListingRepository_ReadWithOptions_PagesWithTokens(t *testing.T) {
+	rank := expressions.NewIdentifier(pagination.Listing_Rank_Field)
+
+	testCases := map[string]struct {
+		expression expressions.Expression
+		orderBy    []pagination.ListingOrderBy
+	}{
+		"primary key": {},
+		"rank": {
+			orderBy: []pagination.ListingOrderBy{
+				{Field: pagination.Listing_Rank_Field},
+			},
+		},
+		"rank descending": {
+			orderBy: []pagination.ListingOrderBy{
+				{Field: pagination.Listing_Rank_Field, Descending: true},
+			},
+		},
+		"rank descending then name": {
+			orderBy: []pagination.ListingOrderBy{
+				{Field: pagination.Listing_Rank_Field, Descending: true},
+				{Field: pagination.Listing_Name_Field},
+			},
+		},
+		"filtered by rank": {
+			expression: crudexpressions.NewGreaterThan(rank, expressions.NewScalar(int32(0))),
+			orderBy: []pagination.ListingOrderBy{
+				{Field: pagination.Listing_Name_Field, Descending: true},
+			},
+		},
+	}
+
+	for repoType, componentUnderTest := range listingImplementationsToTest() {
+		repoDesc := repoType.String()
+		// Call setup function, inject t *testing.T, and use t.Cleanup
+		repoImpl := componentUnderTest(t)
+		if repoImpl == nil {
+			t.Fatalf(
+				"%s: no implementation provided",
+				repoDesc,
+			)
+		}
+
+		initial := listingInitial()
+		_, err := repoImpl.Create(context.Background(), initial)
+		if err != nil {
+			t.Fatalf(
+				"%s: Create(): %s",
+				repoDesc,
+				err,
+			)
+		}
+
+		for testDesc, testCase := range testCases {
+			expected := listingSorted(listingFiltered(initial, testCase.expression), testCase.orderBy)
+
+			var ids []int32
+			pageToken := ""
+			for page := 0; ; page++ {
+				if page > len(initial) {
+					t.Fatalf(
+						"%s: %s: ReadWithOptions(): pagination did not terminate",
+						repoDesc,
+						testDesc,
+					)
+				}
+				res, nextPageToken, err := repoImpl.ReadWithOptions(
+					context.Background(),
+					testCase.expression,
+					&pagination.ListingReadOptions{
+						OrderBy:   testCase.orderBy,
+						Limit:     listingPageSize,
+						PageToken: pageToken,
+					},
+				)
+				if err != nil {
+					t.Fatalf(
+						"%s: %s: ReadWithOptions(): page %d: %s",
+						repoDesc,
+						testDesc,
+						page,
+						err,
+					)
+				}
+				if len(res) > listingPageSize {
+					t.Fatalf(
+						"%s: %s: ReadWithOptions(): page %d: expected at most %d items, got %d items",
+						repoDesc,
+						testDesc,
+						page,
+						listingPageSize,
+						len(res),
+					)
+				}
+				ids = append(ids, listingIDs(res)...)
+				if nextPageToken == "" {
+					break
+				}
+				pageToken = nextPageToken
+			}
+
+			if diff := cmp.Diff(listingIDs(expected), ids); diff != "" {
+				t.Fatal(
+					mismatch(
+						fmt.Sprintf(
+							"%s: %s: ReadWithOptions():",
+							repoDesc,
+							testDesc,
+						),
+						diff,
+					),
+				)
+			}
+		}
+	}
+}
+
+func TestListingRepository_ReadWithOptions_PagesWithTokensOrderedByNullableFields(t *testing.T) {
+	testCases := map[string][]pagination.ListingOrderBy{
+		"optional": {
+			{Field: pagination.Listing_Priority_Field},
+		},
+		"optional descending": {
+			{Field: pagination.Listing_Priority_Field, Descending: true},
+		},
+		"wrapper then optional descending": {
+			{Field: pagination.Listing_Note_Field},
+			{Field: pagination.Listing_Priority_Field, Descending: true},
+		},
+		"optional descending then wrapper": {
+			{Field: pagination.Listing_Priority_Field, Descending: true},
+			{Field: pagination.Listing_Note_Field},
+		},
+	}
+
+	for repoType, componentUnderTest := range listingImplementationsToTest() {
+		repoDesc := repoType.String()
+		// Call setup function, inject t *testing.T, and use t.Cleanup
+		repoImpl := componentUnderTest(t)
+		if repoImpl == nil {
+			t.Fatalf(
+				"%s: no implementation provided",
+				repoDesc,
+			)
+		}
+
+		initial := listingInitial()
+		_, err := repoImpl.Create(context.Background(), initial)
+		if err != nil {
+			t.Fatalf(
+				"%s: Create(): %s",
+				repoDesc,
+				err,
+			)
+		}
+
+		for testDesc, orderBy := range testCases {
+			// where NULLs are ordered differs between implementations, a single unpaginated read is the reference
+			expected, _, err := repoImpl.ReadWithOptions(
+				context.Background(),
+				nil,
+				&pagination.ListingReadOptions{OrderBy: orderBy},
+			)
+			if err != nil {
+				t.Fatalf(
+					"%s: %s: ReadWithOptions(): %s",
+					repoDesc,
+					testDesc,
+					err,
+				)
+			}
+			if len(expected) != len(initial) {
+				t.Fatalf(
+					"%s: %s: ReadWithOptions(): expected %d items, got %d items",
+					repoDesc,
+					testDesc,
+					len(initial),
+					len(expected),
+				)
+			}
+
+			var ids []int32
+			pageToken := ""
+			for page := 0; ; page++ {
+				if page > len(initial) {
+					t.Fatalf(
+						"%s: %s: ReadWithOptions(): pagination did not terminate",
+						repoDesc,
+						testDesc,
+					)
+				}
+				res, nextPageToken, err := repoImpl.ReadWithOptions(
+					context.Background(),
+					nil,
+					&pagination.ListingReadOptions{
+						OrderBy:   orderBy,
+						Limit:     listingPageSize,
+						PageToken: pageToken,
+					},
+				)
+				if err != nil {
+					t.Fatalf(
+						"%s: %s: ReadWithOptions(): page %d: %s",
+						repoDesc,
+						testDesc,
+						page,
+						err,
+					)
+				}
+				ids = append(ids, listingIDs(res)...)
+				if nextPageToken == "" {
+					break
+				}
+				pageToken = nextPageToken
+			}
+
+			if diff := cmp.Diff(listingIDs(expected), ids); diff != "" {
+				t.Fatal(
+					mismatch(
+						fmt.Sprintf(
+							"%s: %s: ReadWithOptions():",
+							repoDesc,
+							testDesc,
+						),
+						diff,
+					),
+				)
+			}
+		}
+	}
+}
+
+func TestListingRepository_ReadWithOptions_RejectsInvalidOptions(t *testing.T) {
+	for repoType, componentUnderTest := range listingImplementationsToTest() {
+		repoDesc := repoType.String()
+		// Call setup function, inject t *testing.T, and use t.Cleanup
+		repoImpl := componentUnderTest(t)
+		if repoImpl == nil {
+			t.Fatalf(
+				"%s: no implementation provided",
+				repoDesc,
+			)
+		}
+
+		_, err := repoImpl.Create(context.Background(), listingInitial())
+		if err != nil {
+			t.Fatalf(
+				"%s: Create(): %s",
+				repoDesc,
+				err,
+			)
+		}
+
+		_, pageToken, err := repoImpl.ReadWithOptions(
+			context.Background(),
+			nil,
+			&pagination.ListingReadOptions{Limit: listingPageSize},
+		)
+		if err != nil {
+			t.Fatalf(
+				"%s: ReadWithOptions(): %s",
+				repoDesc,
+				err,
+			)
+		}
+		if pageToken == "" {
+			t.Fatalf(
+				"%s: ReadWithOptions(): expected a next page token",
+				repoDesc,
+			)
+		}
+
+		testCases := map[string]*pagination.ListingReadOptions{
+			"negative limit":  {Limit: -1},
+			"negative offset": {Offset: -1},
+			"unknown order by field": {
+				OrderBy: []pagination.ListingOrderBy{{Field: "unknown"}},
+			},
+			"duplicate order by field": {
+				OrderBy: []pagination.ListingOrderBy{
+					{Field: pagination.Listing_Rank_Field},
+					{Field: pagination.Listing_Rank_Field, Descending: true},
+				},
+			},
+			"offset with page token": {
+				Limit:     listingPageSize,
+				Offset:    1,
+				PageToken: pageToken,
+			},
+			"page token with different ordering": {
+				OrderBy: []pagination.ListingOrderBy{
+					{Field: pagination.Listing_Rank_Field},
+				},
+				Limit:     listingPageSize,
+				PageToken: pageToken,
+			},
+			"malformed page token": {
+				Limit:     listingPageSize,
+				PageToken: "not a page token",
+			},
+			"paginated order by nullable field without presence": {
+				OrderBy: []pagination.ListingOrderBy{
+					{Field: pagination.Listing_Tag_Field},
+				},
+				Limit: listingPageSize,
+			},
+		}
+
+		for testDesc, readOptions := range testCases {
+			_, _, err := repoImpl.ReadWithOptions(context.Background(), nil, readOptions)
+			if err == nil {
+				t.Fatalf(
+					"%s: %s: ReadWithOptions(): expected error",
+					repoDesc,
+					testDesc,
+				)
+			}
+		}
+	}
+}
+
+// listingFiltered applies the subset of expressions used by the tests
+func listingFiltered(listings []*pagination.Listing, expr expressions.Expression) []*pagination.Listing {
+	if expr == nil {
+		return listings
+	}
+	greaterThan, ok := expr.(*crudexpressions.GreaterThan)
+	if !ok {
+		panic(fmt.Sprintf("unhandled expression %T", expr))
+	}
+	threshold := greaterThan.Right().(*expressions.Scalar).Value().(int32)
+	var filtered []*pagination.Listing
+	for _, listing := range listings {
+		if listing.GetRank() > threshold {
+			filtered = append(filtered, listing)
+		}
+	}
+	return filtered
+}
+
+// listingSorted orders listings as ReadWithOptions does, breaking ties by id
+func listingSorted(listings []*pagination.Listing, orderBy []pagination.ListingOrderBy) []*pagination.Listing {
+	sorted := append([]*pagination.Listing{}, listings...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		for _, by := range orderBy {
+			var order int
+			switch by.Field {
+			case pagination.Listing_Id_Field:
+				order = int(sorted[i].GetId() - sorted[j].GetId())
+			case pagination.Listing_Rank_Field:
+				order = int(sorted[i].GetRank() - sorted[j].GetRank())
+			case pagination.Listing_Name_Field:
+				switch {
+				case sorted[i].GetName() < sorted[j].GetName():
+					order = -1
+				case sorted[i].GetName() > sorted[j].GetName():
+					order = 1
+				}
+			default:
+				panic(fmt.Sprintf("unhandled field id %s", by.Field))
+			}
+			if by.Descending {
+				order = -order
+			}
+			if order != 0 {
+				return order < 0
+			}
+		}
+		return sorted[i].GetId() < sorted[j].GetId()
+	})
+	return sorted
+}
+
+func listingIDs(listings []*pagination.Listing) []int32 {
+	var ids []int32
+	for _, listing := range listings {
+		ids = append(ids, listing.GetId())
+	}
+	return ids
+}
+
+func listingInitial() []*pagination.Listing {
+	names := []string{"alpha", "echo", "bravo", "delta", "charlie", "bravo", "golf", "foxtrot", "hotel", "delta"}
+	initial := make([]*pagination.Listing, 0, len(names))
+	for id, name := range names {
+		listing := pagination.Listing_builder{
+			Id:   int32(id),
+			Rank: int32(id % 3),
+			Name: name,
+			Tag:  name,
+		}.Build()
+		// every other listing is without a priority and every third without a note, ties and NULLs span pages
+		if id%2 == 0 {
+			listing.SetPriority(int32(id % 3))
+		}
+		if id%3 != 0 {
+			listing.SetNote(wrapperspb.String(names[id%4]))
+		}
+		initial = append(initial, listing)
+	}
+	return initial
+}
+
+func listingImplementationsToTest() map[options.Implementation]listingComponentUnderTest {
+	return map[options.Implementation]listingComponentUnderTest{
+		options.Implementation_IMPLEMENTATION_SQLITE: sqliteListingComponentUnderTest,
+		options.Implementation_IMPLEMENTATION_PGSQL:  pgsqlListingComponentUnderTest,
+	}
+}
@@ -2,6 +2,7 @@ package primary_key_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"testing"
@@ -9,6 +10,7 @@ import (
 	test_cases "github.com/samlitowitz/protoc-gen-crud/test-cases"
 
 	"github.com/samlitowitz/protoc-gen-crud/options"
+	"github.com/samlitowitz/protoc-gen-crud/runtime"
 
 	"github.com/samlitowitz/expressions"
 
@@ -297,10 +299,11 @@ func TestSAEnumRepository_Update_WithUnLocatablePrimaryKeyUpdatesNothing(t *test
 			expected = append(expected, builder.Build())
 		}
 		_, err = repoImpl.Update(context.Background(), expected)
-		if err != nil {
+		if !errors.Is(err, runtime.ErrNotFound) {
 			t.Fatalf(
-				"%s: Update(): %s",
+				"%s: Update(): expected %v, got %v",
 				repoDesc,
+				runtime.ErrNotFound,
 				err,
 			)
 		}
@@ -615,7 +618,7 @@ func TestSAEnumRepository_Delete_WithLocatablePrimaryKeySucceeds(t *testing.T) {
 				)
 			}
 
-			err = repoImpl.Delete(context.Background(), testCase.deleteExpression)
+			deleted, err := repoImpl.Delete(context.Background(), testCase.deleteExpression)
 			if err != nil {
 				t.Fatalf(
 					"%s: %s: Update(): %s",
@@ -624,6 +627,15 @@ func TestSAEnumRepository_Delete_WithLocatablePrimaryKeySucceeds(t *testing.T) {
 					err,
 				)
 			}
+			if deleted != int64(len(testCase.initial)-len(testCase.expected)) {
+				t.Fatalf(
+					"%s: %s: Delete(): expected %d deleted, got %d",
+					testDesc,
+					repoDesc,
+					len(testCase.initial)-len(testCase.expected),
+					deleted,
+				)
+			}
 
 			res, err = repoImpl.Read(context.Background(), nil)
 			if err != nil {
@@ -0,0 +1,10 @@
+package projection_test
+
+import (
+	"testing"
+
+	"github.com/samlitowitz/protoc-gen-crud/test-cases/projection"
+)
+
+// projectionComponentUnderTest is to be implemented to do setup and tear down for each implementation
+type projectionComponentUnderTest func(t *testing.T) projection.DocumentRepository
@@ -0,0 +1,240 @@
+package projection_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/samlitowitz/protoc-gen-crud/test-cases/projection"
+
+	"github.com/samlitowitz/protoc-gen-crud/options"
+
+	"github.com/samlitowitz/expressions"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+var projectionCreatedAt = time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+func seedDocuments(t *testing.T, repoDesc string, repo projection.DocumentRepository) {
+	_, err := repo.Create(context.Background(), []*projection.Document{
+		projection.Document_builder{Id: 1, Title: "one", Body: "first body", Size: 10, CreatedAt: timestamppb.New(projectionCreatedAt)}.Build(),
+		projection.Document_builder{Id: 2, Title: "two", Body: "second body", Size: 20, CreatedAt: timestamppb.New(projectionCreatedAt)}.Build(),
+	})
+	if err != nil {
+		t.Fatalf(
+			"%s: Create(): %s",
+			repoDesc,
+			err,
+		)
+	}
+}
+
+func TestDocumentRepository_ReadWithOptions_Fields(t *testing.T) {
+	tests := map[string]struct {
+		opts     *projection.DocumentReadOptions
+		expected []*projection.Document
+	}{
+		"fields": {
+			opts: &projection.DocumentReadOptions{
+				Fields: []expressions.ID{projection.Document_Title_Field},
+			},
+			expected: []*projection.Document{
+				projection.Document_builder{
+					FieldMask: &fieldmaskpb.FieldMask{Paths: []string{"title", "id"}},
+					Id:        1,
+					Title:     "one",
+				}.Build(),
+				projection.Document_builder{
+					FieldMask: &fieldmaskpb.FieldMask{Paths: []string{"title", "id"}},
+					Id:        2,
+					Title:     "two",
+				}.Build(),
+			},
+		},
+		"fields and order by": {
+			opts: &projection.DocumentReadOptions{
+				OrderBy: []projection.DocumentOrderBy{{Field: projection.Document_Size_Field, Descending: true}},
+				Fields:  []expressions.ID{projection.Document_CreatedAt_Field, projection.Document_CreatedAt_Field},
+			},
+			expected: []*projection.Document{
+				projection.Document_builder{
+					FieldMask: &fieldmaskpb.FieldMask{Paths: []string{"createdAt", "size", "id"}},
+					Id:        2,
+					Size:      20,
+					CreatedAt: timestamppb.New(projectionCreatedAt),
+				}.Build(),
+				projection.Document_builder{
+					FieldMask: &fieldmaskpb.FieldMask{Paths: []string{"createdAt", "size", "id"}},
+					Id:        1,
+					Size:      10,
+					CreatedAt: timestamppb.New(projectionCreatedAt),
+				}.Build(),
+			},
+		},
+	}
+
+	for repoType, componentUnderTest := range projectionImplementationsToTest() {
+		repoDesc := repoType.String()
+		// Call setup function, inject t *testing.T, and use t.Cleanup
+		repo := componentUnderTest(t)
+		if repo == nil {
+			t.Fatalf(
+				"%s: no implementation provided",
+				repoDesc,
+			)
+		}
+		seedDocuments(t, repoDesc, repo)
+
+		for testDesc, tc := range tests {
+			res, _, err := repo.ReadWithOptions(context.Background(), nil, tc.opts)
+			if err != nil {
+				t.Fatalf(
+					"%s: %s: ReadWithOptions(): %s",
+					repoDesc,
+					testDesc,
+					err,
+				)
+			}
+			if diff := cmp.Diff(tc.expected, res, protocmp.Transform()); diff != "" {
+				t.Fatal(
+					mismatch(
+						fmt.Sprintf(
+							"%s: %s: ReadWithOptions():",
+							repoDesc,
+							testDesc,
+						),
+						diff,
+					),
+				)
+			}
+		}
+	}
+}
+
+func TestDocumentRepository_ReadWithOptions_FieldsPaginated(t *testing.T) {
+	for repoType, componentUnderTest := range projectionImplementationsToTest() {
+		repoDesc := repoType.String()
+		// Call setup function, inject t *testing.T, and use t.Cleanup
+		repo := componentUnderTest(t)
+		if repo == nil {
+			t.Fatalf(
+				"%s: no implementation provided",
+				repoDesc,
+			)
+		}
+		seedDocuments(t, repoDesc, repo)
+
+		opts := &projection.DocumentReadOptions{
+			OrderBy: []projection.DocumentOrderBy{{Field: projection.Document_Title_Field}},
+			Limit:   1,
+			Fields:  []expressions.ID{projection.Document_Size_Field},
+		}
+		var sizes []int64
+		for {
+			res, nextPageToken, err := repo.ReadWithOptions(context.Background(), nil, opts)
+			if err != nil {
+				t.Fatalf(
+					"%s: ReadWithOptions(): %s",
+					repoDesc,
+					err,
+				)
+			}
+			for _, document := range res {
+				sizes = append(sizes, document.GetSize())
+				if document.GetBody() != "" {
+					t.Fatalf(
+						"%s: ReadWithOptions(): %d: expected body to be unset",
+						repoDesc,
+						document.GetId(),
+					)
+				}
+			}
+			if nextPageToken == "" {
+				break
+			}
+			opts.PageToken = nextPageToken
+		}
+		if diff := cmp.Diff([]int64{10, 20}, sizes); diff != "" {
+			t.Fatal(
+				mismatch(
+					fmt.Sprintf(
+						"%s: ReadWithOptions():",
+						repoDesc,
+					),
+					diff,
+				),
+			)
+		}
+	}
+}
+
+func TestDocumentFieldsFromFieldMask(t *testing.T) {
+	tests := map[string]struct {
+		fieldMask     *fieldmaskpb.FieldMask
+		expected      []expressions.ID
+		expectedError bool
+	}{
+		"nil": {},
+		"paths": {
+			fieldMask: &fieldmaskpb.FieldMask{Paths: []string{"body", "createdAt"}},
+			expected:  []expressions.ID{projection.Document_Body_Field, projection.Document_CreatedAt_Field},
+		},
+		"unknown path": {
+			fieldMask:     &fieldmaskpb.FieldMask{Paths: []string{"unknown"}},
+			expectedError: true,
+		},
+	}
+
+	for testDesc, tc := range tests {
+		fields, err := projection.DocumentFieldsFromFieldMask(tc.fieldMask)
+		if tc.expectedError {
+			if err == nil {
+				t.Fatalf("%s: DocumentFieldsFromFieldMask(): expected error", testDesc)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: DocumentFieldsFromFieldMask(): %s", testDesc, err)
+		}
+		if diff := cmp.Diff(tc.expected, fields); diff != "" {
+			t.Fatal(mismatch(fmt.Sprintf("%s: DocumentFieldsFromFieldMask():", testDesc), diff))
+		}
+	}
+}
+
+func TestDocumentRepository_ReadWithOptions_InvalidField(t *testing.T) {
+	for repoType, componentUnderTest := range projectionImplementationsToTest() {
+		repoDesc := repoType.String()
+		// Call setup function, inject t *testing.T, and use t.Cleanup
+		repo := componentUnderTest(t)
+		if repo == nil {
+			t.Fatalf(
+				"%s: no implementation provided",
+				repoDesc,
+			)
+		}
+
+		_, _, err := repo.ReadWithOptions(context.Background(), nil, &projection.DocumentReadOptions{
+			Fields: []expressions.ID{"invalid"},
+		})
+		if err == nil {
+			t.Fatalf(
+				"%s: ReadWithOptions(): expected error reading an invalid field",
+				repoDesc,
+			)
+		}
+	}
+}
+
+func projectionImplementationsToTest() map[options.Implementation]projectionComponentUnderTest {
+	return map[options.Implementation]projectionComponentUnderTest{
+		options.Implementation_IMPLEMENTATION_SQLITE: sqliteProjectionComponentUnderTest,
+		options.Implementation_IMPLEMENTATION_PGSQL:  pgsqlProjectionComponentUnderTest,
+	}
+}
@@ -0,0 +1,10 @@
+package read_iter_test
+
+import (
+	"testing"
+
+	read_iter "github.com/samlitowitz/protoc-gen-crud/test-cases/read-iter"
+)
+
+// recordComponentUnderTest is to be implemented to do setup and tear down for each implementation
+type recordComponentUnderTest func(t *testing.T) read_iter.RecordRepository
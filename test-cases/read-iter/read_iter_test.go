@@ -0,0 +1,208 @@
+package read_iter_test
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	read_iter "github.com/samlitowitz/protoc-gen-crud/test-cases/read-iter"
+
+	"github.com/samlitowitz/protoc-gen-crud/options"
+
+	crudexpressions "github.com/samlitowitz/protoc-gen-crud/expressions"
+
+	"github.com/samlitowitz/expressions"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const (
+	recordRowCount = 10
+	// recordBreakCount exceeds the single pooled connection so a leaked connection blocks
+	recordBreakCount = 3
+)
+
+func TestRecordRepository_ReadIter_YieldsMatching(t *testing.T) {
+	for repoType, componentUnderTest := range recordImplementationsToTest() {
+		repoDesc := repoType.String()
+		// Call setup function, inject t *testing.T, and use t.Cleanup
+		repoImpl := componentUnderTest(t)
+		if repoImpl == nil {
+			t.Fatalf(
+				"%s: no implementation provided",
+				repoDesc,
+			)
+		}
+
+		_, err := repoImpl.Create(context.Background(), recordInitial())
+		if err != nil {
+			t.Fatalf(
+				"%s: Create(): %s",
+				repoDesc,
+				err,
+			)
+		}
+
+		expr := crudexpressions.NewLessThan(
+			expressions.NewIdentifier(read_iter.Record_Id_Field),
+			expressions.NewScalar(int32(5)),
+		)
+		var ids []int32
+		for record, err := range repoImpl.ReadIter(context.Background(), expr) {
+			if err != nil {
+				t.Fatalf(
+					"%s: ReadIter(): %s",
+					repoDesc,
+					err,
+				)
+			}
+			if record.GetData() != recordData(record.GetId()) {
+				t.Fatalf(
+					"%s: ReadIter(): %d: expected %q, got %q",
+					repoDesc,
+					record.GetId(),
+					recordData(record.GetId()),
+					record.GetData(),
+				)
+			}
+			ids = append(ids, record.GetId())
+		}
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+		if diff := cmp.Diff([]int32{0, 1, 2, 3, 4}, ids); diff != "" {
+			t.Fatal(
+				mismatch(
+					fmt.Sprintf(
+						"%s: ReadIter():",
+						repoDesc,
+					),
+					diff,
+				),
+			)
+		}
+	}
+}
+
+func TestRecordRepository_ReadIter_EarlyBreakReleasesConnection(t *testing.T) {
+	for repoType, componentUnderTest := range recordImplementationsToTest() {
+		repoDesc := repoType.String()
+		// Call setup function, inject t *testing.T, and use t.Cleanup
+		repoImpl := componentUnderTest(t)
+		if repoImpl == nil {
+			t.Fatalf(
+				"%s: no implementation provided",
+				repoDesc,
+			)
+		}
+
+		_, err := repoImpl.Create(context.Background(), recordInitial())
+		if err != nil {
+			t.Fatalf(
+				"%s: Create(): %s",
+				repoDesc,
+				err,
+			)
+		}
+
+		// the only connection is unavailable if an iterator failed to close its rows
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		for i := 0; i < recordBreakCount; i++ {
+			yielded := 0
+			for _, err := range repoImpl.ReadIter(ctx, nil) {
+				if err != nil {
+					t.Fatalf(
+						"%s: ReadIter(): %d: %s",
+						repoDesc,
+						i,
+						err,
+					)
+				}
+				yielded++
+				if yielded == 2 {
+					break
+				}
+			}
+		}
+
+		res, err := repoImpl.Read(ctx, nil)
+		if err != nil {
+			t.Fatalf(
+				"%s: Read() after ReadIter() break: %s",
+				repoDesc,
+				err,
+			)
+		}
+		if len(res) != recordRowCount {
+			t.Fatalf(
+				"%s: Read() after ReadIter() break: expected %d items, got %d items",
+				repoDesc,
+				recordRowCount,
+				len(res),
+			)
+		}
+	}
+}
+
+func TestRecordRepository_ReadIter_YieldsErrors(t *testing.T) {
+	for repoType, componentUnderTest := range recordImplementationsToTest() {
+		repoDesc := repoType.String()
+		// Call setup function, inject t *testing.T, and use t.Cleanup
+		repoImpl := componentUnderTest(t)
+		if repoImpl == nil {
+			t.Fatalf(
+				"%s: no implementation provided",
+				repoDesc,
+			)
+		}
+
+		expr := expressions.NewEquals(
+			expressions.NewIdentifier("unknown"),
+			expressions.NewScalar(int32(0)),
+		)
+		yielded := 0
+		for record, err := range repoImpl.ReadIter(context.Background(), expr) {
+			yielded++
+			if err == nil {
+				t.Fatalf(
+					"%s: ReadIter(): expected error, got %v",
+					repoDesc,
+					record,
+				)
+			}
+		}
+		if yielded != 1 {
+			t.Fatalf(
+				"%s: ReadIter(): expected 1 error, got %d items",
+				repoDesc,
+				yielded,
+			)
+		}
+	}
+}
+
+func recordData(id int32) string {
+	return fmt.Sprintf("record %d", id)
+}
+
+func recordInitial() []*read_iter.Record {
+	initial := make([]*read_iter.Record, 0, recordRowCount)
+	for id := int32(0); id < recordRowCount; id++ {
+		initial = append(
+			initial,
+			read_iter.Record_builder{
+				Id:   id,
+				Data: recordData(id),
+			}.Build(),
+		)
+	}
+	return initial
+}
+
+func recordImplementationsToTest() map[options.Implementation]recordComponentUnderTest {
+	return map[options.Implementation]recordComponentUnderTest{
+		options.Implementation_IMPLEMENTATION_SQLITE: sqliteRecordComponentUnderTest,
+		options.Implementation_IMPLEMENTATION_PGSQL:  pgsqlRecordComponentUnderTest,
+	}
+}
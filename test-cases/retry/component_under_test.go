@@ -0,0 +1,12 @@
+package retry_test
+
+import (
+	"testing"
+
+	"github.com/samlitowitz/protoc-gen-crud/test-cases/retry"
+
+	"github.com/samlitowitz/protoc-gen-crud/runtime"
+)
+
+// counterComponentUnderTest is to be implemented to do setup and tear down for each implementation
+type counterComponentUnderTest func(t *testing.T, opts ...runtime.Option) retry.CounterRepository
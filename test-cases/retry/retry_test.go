@@ -0,0 +1,173 @@
+package retry_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/samlitowitz/protoc-gen-crud/test-cases/retry"
+
+	"github.com/samlitowitz/protoc-gen-crud/options"
+
+	"github.com/samlitowitz/protoc-gen-crud/runtime"
+)
+
+const (
+	counterWriters          = 16
+	counterUpdatesPerWriter = 10
+)
+
+func TestCounterRepository_Update_RetriesConcurrentUpdates(t *testing.T) {
+	policy := runtime.RetryPolicy{
+		MaxAttempts:    100,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     50 * time.Millisecond,
+		Jitter:         1,
+	}
+
+	for repoType, componentUnderTest := range counterImplementationsToTest() {
+		repoDesc := repoType.String()
+		// Call setup function, inject t *testing.T, and use t.Cleanup
+		repoImpl := componentUnderTest(t, runtime.WithRetryPolicy(policy))
+		if repoImpl == nil {
+			t.Fatalf(
+				"%s: no implementation provided",
+				repoDesc,
+			)
+		}
+
+		_, err := repoImpl.Create(context.Background(), []*retry.Counter{
+			retry.Counter_builder{Id: 1}.Build(),
+		})
+		if err != nil {
+			t.Fatalf(
+				"%s: Create(): %s",
+				repoDesc,
+				err,
+			)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		errs := make(chan error, counterWriters*counterUpdatesPerWriter)
+		var wg sync.WaitGroup
+		for writer := 0; writer < counterWriters; writer++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := 0; i < counterUpdatesPerWriter; i++ {
+					_, err := repoImpl.Update(ctx, []*retry.Counter{
+						retry.Counter_builder{
+							Id:    1,
+							Value: int32(writer*counterUpdatesPerWriter + i),
+						}.Build(),
+					})
+					if err != nil {
+						errs <- err
+					}
+				}
+			}()
+		}
+		wg.Wait()
+		close(errs)
+
+		for err := range errs {
+			t.Fatalf(
+				"%s: Update(): %s",
+				repoDesc,
+				err,
+			)
+		}
+
+		res, err := repoImpl.Read(context.Background(), nil)
+		if err != nil {
+			t.Fatalf(
+				"%s: Read(): %s",
+				repoDesc,
+				err,
+			)
+		}
+		if len(res) != 1 {
+			t.Fatalf(
+				"%s: Read(): expected 1 item, got %d items",
+				repoDesc,
+				len(res),
+			)
+		}
+		if res[0].GetValue()%counterUpdatesPerWriter != counterUpdatesPerWriter-1 {
+			t.Fatalf(
+				"%s: Read(): expected the last update of a writer, got %d",
+				repoDesc,
+				res[0].GetValue(),
+			)
+		}
+	}
+}
+
+func TestCounterRepository_Update_NotRetriedWithoutPolicy(t *testing.T) {
+	for repoType, componentUnderTest := range counterImplementationsToTest() {
+		repoDesc := repoType.String()
+		// Call setup function, inject t *testing.T, and use t.Cleanup
+		repoImpl := componentUnderTest(t)
+		if repoImpl == nil {
+			t.Fatalf(
+				"%s: no implementation provided",
+				repoDesc,
+			)
+		}
+
+		_, err := repoImpl.Create(context.Background(), []*retry.Counter{
+			retry.Counter_builder{Id: 1}.Build(),
+		})
+		if err != nil {
+			t.Fatalf(
+				"%s: Create(): %s",
+				repoDesc,
+				err,
+			)
+		}
+
+		errs := make(chan error, counterWriters*counterUpdatesPerWriter)
+		var wg sync.WaitGroup
+		for writer := 0; writer < counterWriters; writer++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := 0; i < counterUpdatesPerWriter; i++ {
+					_, err := repoImpl.Update(context.Background(), []*retry.Counter{
+						retry.Counter_builder{
+							Id:    1,
+							Value: int32(writer*counterUpdatesPerWriter + i),
+						}.Build(),
+					})
+					if err != nil {
+						errs <- err
+					}
+				}
+			}()
+		}
+		wg.Wait()
+		close(errs)
+
+		// contention is not guaranteed, but every failure must be reported as a serialization failure
+		for err := range errs {
+			if !errors.Is(err, runtime.ErrSerializationFailure) {
+				t.Fatalf(
+					"%s: Update(): expected %s, got %s",
+					repoDesc,
+					runtime.ErrSerializationFailure,
+					err,
+				)
+			}
+		}
+	}
+}
+
+func counterImplementationsToTest() map[options.Implementation]counterComponentUnderTest {
+	return map[options.Implementation]counterComponentUnderTest{
+		options.Implementation_IMPLEMENTATION_SQLITE: sqliteCounterComponentUnderTest,
+		options.Implementation_IMPLEMENTATION_PGSQL:  pgsqlCounterComponentUnderTest,
+	}
+}
@@ -0,0 +1,58 @@
+package retry_test
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/samlitowitz/protoc-gen-crud/test-cases/retry"
+
+	"github.com/samlitowitz/protoc-gen-crud/runtime"
+)
+
+func sqliteExecSQLFile(db *sql.DB, file string) error {
+	code, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	stmt, err := db.Prepare(string(code))
+	if err != nil {
+		return err
+	}
+	_, err = stmt.Exec()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func sqliteCounterComponentUnderTest(t *testing.T, opts ...runtime.Option) retry.CounterRepository {
+	// concurrent writers need connections sharing one database, every connection to :memory: is distinct
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "retry.db"))
+	if err != nil {
+		t.Fatal("sqlite: ", err)
+	}
+	t.Cleanup(func() {
+		err := db.Close()
+		if err != nil {
+			t.Fatal("sqlite: ", err)
+		}
+	})
+
+	origDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal("sqlite: finding working dir:", err)
+	}
+
+	err = sqliteExecSQLFile(db, origDir+string(os.PathSeparator)+"test.sqlite.sql")
+	if err != nil {
+		t.Fatal("sqlite: executing setup SQL: ", err)
+	}
+
+	repo, err := retry.NewSQLiteCounterRepository(db, opts...)
+	if err != nil {
+		t.Fatal("sqlite: creating repository: ", err)
+	}
+	return repo
+}
@@ -0,0 +1,10 @@
+package returning_test
+
+import (
+	"testing"
+
+	"github.com/samlitowitz/protoc-gen-crud/test-cases/returning"
+)
+
+// returningComponentUnderTest is to be implemented to do setup and tear down for each implementation
+type returningComponentUnderTest func(t *testing.T) returning.EventRepository
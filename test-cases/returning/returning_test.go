@@ -0,0 +1,135 @@
+package returning_test
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	"github.com/samlitowitz/protoc-gen-crud/test-cases/returning"
+
+	"github.com/samlitowitz/protoc-gen-crud/options"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func readEvents(t *testing.T, repoDesc string, repo returning.EventRepository) []*returning.Event {
+	res, err := repo.Read(context.Background(), nil)
+	if err != nil {
+		t.Fatalf(
+			"%s: Read(): %s",
+			repoDesc,
+			err,
+		)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].GetId() < res[j].GetId() })
+	return res
+}
+
+func TestEventRepository_Create_ReturnsStoredValues(t *testing.T) {
+	for repoType, componentUnderTest := range returningImplementationsToTest() {
+		repoDesc := repoType.String()
+		// Call setup function, inject t *testing.T, and use t.Cleanup
+		repo := componentUnderTest(t)
+		if repo == nil {
+			t.Fatalf(
+				"%s: no implementation provided",
+				repoDesc,
+			)
+		}
+
+		// the database generates the keys and stores the created and updated at times at its own precision
+		created, err := repo.Create(context.Background(), []*returning.Event{
+			returning.Event_builder{Name: "one"}.Build(),
+			returning.Event_builder{
+				FieldMask: &fieldmaskpb.FieldMask{Paths: []string{"name", "createdAt", "updatedAt"}},
+				Name:      "two",
+			}.Build(),
+		})
+		if err != nil {
+			t.Fatalf(
+				"%s: Create(): %s",
+				repoDesc,
+				err,
+			)
+		}
+
+		if diff := cmp.Diff(readEvents(t, repoDesc, repo), created, returningDefaultCmpOpts()...); diff != "" {
+			t.Fatal(mismatch(fmt.Sprintf("%s: Create():", repoDesc), diff))
+		}
+	}
+}
+
+func TestEventRepository_Update_ReturnsStoredValues(t *testing.T) {
+	tests := map[string]struct {
+		fieldMask *fieldmaskpb.FieldMask
+	}{
+		"without field mask": {},
+		"with field mask": {
+			fieldMask: &fieldmaskpb.FieldMask{Paths: []string{"id", "name", "updatedAt"}},
+		},
+	}
+
+	for repoType, componentUnderTest := range returningImplementationsToTest() {
+		repoDesc := repoType.String()
+		for testDesc, tc := range tests {
+			// Call setup function, inject t *testing.T, and use t.Cleanup
+			repo := componentUnderTest(t)
+			if repo == nil {
+				t.Fatalf(
+					"%s: no implementation provided",
+					repoDesc,
+				)
+			}
+
+			created, err := repo.Create(context.Background(), []*returning.Event{
+				returning.Event_builder{Name: "one"}.Build(),
+			})
+			if err != nil {
+				t.Fatalf(
+					"%s: %s: Create(): %s",
+					repoDesc,
+					testDesc,
+					err,
+				)
+			}
+
+			toUpdate := returning.Event_builder{
+				FieldMask: tc.fieldMask,
+				Id:        created[0].GetId(),
+				Name:      "uno",
+				CreatedAt: created[0].GetCreatedAt(),
+			}.Build()
+			updated, err := repo.Update(context.Background(), []*returning.Event{toUpdate})
+			if err != nil {
+				t.Fatalf(
+					"%s: %s: Update(): %s",
+					repoDesc,
+					testDesc,
+					err,
+				)
+			}
+
+			if diff := cmp.Diff(readEvents(t, repoDesc, repo), updated, returningDefaultCmpOpts()...); diff != "" {
+				t.Fatal(mismatch(fmt.Sprintf("%s: %s: Update():", repoDesc, testDesc), diff))
+			}
+		}
+	}
+}
+
+func returningImplementationsToTest() map[options.Implementation]returningComponentUnderTest {
+	return map[options.Implementation]returningComponentUnderTest{
+		options.Implementation_IMPLEMENTATION_SQLITE: sqliteReturningComponentUnderTest,
+		options.Implementation_IMPLEMENTATION_PGSQL:  pgsqlReturningComponentUnderTest,
+	}
+}
+
+func returningDefaultCmpOpts() cmp.Options {
+	return cmp.Options{
+		protocmp.Transform(),
+		protocmp.IgnoreFields(&returning.Event{}, "fieldMask"),
+	}
+}
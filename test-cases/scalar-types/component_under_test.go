@@ -0,0 +1,10 @@
+package scalar_types_test
+
+import (
+	"testing"
+
+	scalar_types "github.com/samlitowitz/protoc-gen-crud/test-cases/scalar-types"
+)
+
+// scalarTypesComponentUnderTest is to be implemented to do setup and tear down for each implementation
+type scalarTypesComponentUnderTest func(t *testing.T) scalar_types.ScalarsRepository
@@ -0,0 +1,206 @@
+package scalar_types_test
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"testing"
+
+	"google.golang.org/protobuf/testing/protocmp"
+
+	scalar_types "github.com/samlitowitz/protoc-gen-crud/test-cases/scalar-types"
+
+	"github.com/samlitowitz/protoc-gen-crud/options"
+
+	"github.com/samlitowitz/expressions"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func minScalars(id int32) *scalar_types.Scalars {
+	return scalar_types.Scalars_builder{
+		Id:            id,
+		DoubleValue:   -math.MaxFloat64,
+		FloatValue:    -math.MaxFloat32,
+		Int32Value:    math.MinInt32,
+		Int64Value:    math.MinInt64,
+		Sint32Value:   math.MinInt32,
+		Sint64Value:   math.MinInt64,
+		Sfixed32Value: math.MinInt32,
+		Sfixed64Value: math.MinInt64,
+		BoolValue:     false,
+		StringValue:   "",
+		BytesValue:    []byte{},
+	}.Build()
+}
+
+func maxScalars(repoType options.Implementation, id int32) *scalar_types.Scalars {
+	maxUint64 := uint64(math.MaxUint64)
+	if repoType == options.Implementation_IMPLEMENTATION_SQLITE {
+		// SQLite integers are signed 64-bit integers
+		maxUint64 = math.MaxInt64
+	}
+	return scalar_types.Scalars_builder{
+		Id:            id,
+		DoubleValue:   math.MaxFloat64,
+		FloatValue:    math.MaxFloat32,
+		Int32Value:    math.MaxInt32,
+		Int64Value:    math.MaxInt64,
+		Uint32Value:   math.MaxUint32,
+		Uint64Value:   maxUint64,
+		Sint32Value:   math.MaxInt32,
+		Sint64Value:   math.MaxInt64,
+		Fixed32Value:  math.MaxUint32,
+		Fixed64Value:  maxUint64,
+		Sfixed32Value: math.MaxInt32,
+		Sfixed64Value: math.MaxInt64,
+		BoolValue:     true,
+		StringValue:   "héllo, 世界",
+		BytesValue:    []byte{0x00, 0x7f, 0x80, 0xff},
+	}.Build()
+}
+
+func readScalars(t *testing.T, repoDesc string, repo scalar_types.ScalarsRepository, id int32) []*scalar_types.Scalars {
+	res, err := repo.Read(context.Background(), expressions.NewEquals(
+		expressions.NewIdentifier(scalar_types.Scalars_Id_Field),
+		expressions.NewScalar(id),
+	))
+	if err != nil {
+		t.Fatalf(
+			"%s: Read(): %s",
+			repoDesc,
+			err,
+		)
+	}
+	return res
+}
+
+func TestScalarsRepository_Create_RoundTripsMinAndMaxValues(t *testing.T) {
+	for repoType, componentUnderTest := range scalarTypesImplementationsToTest() {
+		repoDesc := repoType.String()
+		// Call setup function, inject t *testing.T, and use t.Cleanup
+		repo := componentUnderTest(t)
+		if repo == nil {
+			t.Fatalf(
+				"%s: no implementation provided",
+				repoDesc,
+			)
+		}
+
+		tests := map[string]*scalar_types.Scalars{
+			"min":  minScalars(1),
+			"max":  maxScalars(repoType, 2),
+			"zero": scalar_types.Scalars_builder{Id: 3}.Build(),
+		}
+		for testDesc, scalars := range tests {
+			if _, err := repo.Create(context.Background(), []*scalar_types.Scalars{scalars}); err != nil {
+				t.Fatalf(
+					"%s: %s: Create(): %s",
+					repoDesc,
+					testDesc,
+					err,
+				)
+			}
+
+			res := readScalars(t, repoDesc, repo, scalars.GetId())
+			if diff := cmp.Diff([]*scalar_types.Scalars{scalars}, res, protocmp.Transform()); diff != "" {
+				t.Fatal(mismatch(fmt.Sprintf("%s: %s: Read():", repoDesc, testDesc), diff))
+			}
+		}
+	}
+}
+
+func TestScalarsRepository_Update_RoundTripsMinAndMaxValues(t *testing.T) {
+	for repoType, componentUnderTest := range scalarTypesImplementationsToTest() {
+		repoDesc := repoType.String()
+		// Call setup function, inject t *testing.T, and use t.Cleanup
+		repo := componentUnderTest(t)
+		if repo == nil {
+			t.Fatalf(
+				"%s: no implementation provided",
+				repoDesc,
+			)
+		}
+
+		if _, err := repo.Create(context.Background(), []*scalar_types.Scalars{minScalars(1)}); err != nil {
+			t.Fatalf(
+				"%s: Create(): %s",
+				repoDesc,
+				err,
+			)
+		}
+		for _, scalars := range []*scalar_types.Scalars{maxScalars(repoType, 1), minScalars(1)} {
+			if _, err := repo.Update(context.Background(), []*scalar_types.Scalars{scalars}); err != nil {
+				t.Fatalf(
+					"%s: Update(): %s",
+					repoDesc,
+					err,
+				)
+			}
+
+			res := readScalars(t, repoDesc, repo, 1)
+			if diff := cmp.Diff([]*scalar_types.Scalars{scalars}, res, protocmp.Transform()); diff != "" {
+				t.Fatal(mismatch(fmt.Sprintf("%s: Update():", repoDesc), diff))
+			}
+		}
+	}
+}
+
+func TestScalarsRepository_Read_FiltersByMaxValues(t *testing.T) {
+	for repoType, componentUnderTest := range scalarTypesImplementationsToTest() {
+		repoDesc := repoType.String()
+		// Call setup function, inject t *testing.T, and use t.Cleanup
+		repo := componentUnderTest(t)
+		if repo == nil {
+			t.Fatalf(
+				"%s: no implementation provided",
+				repoDesc,
+			)
+		}
+
+		maxValues := maxScalars(repoType, 2)
+		if _, err := repo.Create(context.Background(), []*scalar_types.Scalars{minScalars(1), maxValues}); err != nil {
+			t.Fatalf(
+				"%s: Create(): %s",
+				repoDesc,
+				err,
+			)
+		}
+
+		tests := map[string]expressions.Expression{
+			"uint64": expressions.NewEquals(
+				expressions.NewIdentifier(scalar_types.Scalars_Uint64Value_Field),
+				expressions.NewScalar(maxValues.GetUint64Value()),
+			),
+			"bool": expressions.NewEquals(
+				expressions.NewIdentifier(scalar_types.Scalars_BoolValue_Field),
+				expressions.NewScalar(true),
+			),
+			"bytes": expressions.NewEquals(
+				expressions.NewIdentifier(scalar_types.Scalars_BytesValue_Field),
+				expressions.NewScalar(maxValues.GetBytesValue()),
+			),
+		}
+		for testDesc, expr := range tests {
+			res, err := repo.Read(context.Background(), expr)
+			if err != nil {
+				t.Fatalf(
+					"%s: %s: Read(): %s",
+					repoDesc,
+					testDesc,
+					err,
+				)
+			}
+			if diff := cmp.Diff([]*scalar_types.Scalars{maxValues}, res, protocmp.Transform()); diff != "" {
+				t.Fatal(mismatch(fmt.Sprintf("%s: %s: Read():", repoDesc, testDesc), diff))
+			}
+		}
+	}
+}
+
+func scalarTypesImplementationsToTest() map[options.Implementation]scalarTypesComponentUnderTest {
+	return map[options.Implementation]scalarTypesComponentUnderTest{
+		options.Implementation_IMPLEMENTATION_SQLITE: sqliteScalarTypesComponentUnderTest,
+		options.Implementation_IMPLEMENTATION_PGSQL:  pgsqlScalarTypesComponentUnderTest,
+	}
+}
@@ -0,0 +1,12 @@
+package timestamp_source_test
+
+import (
+	"testing"
+
+	timestamp_source "github.com/samlitowitz/protoc-gen-crud/test-cases/timestamp-source"
+
+	"github.com/samlitowitz/protoc-gen-crud/runtime"
+)
+
+// timestampSourceComponentUnderTest is to be implemented to do setup and tear down for each implementation
+type timestampSourceComponentUnderTest func(t *testing.T, opts ...runtime.Option) (timestamp_source.ClientEventRepository, timestamp_source.DatabaseEventRepository)
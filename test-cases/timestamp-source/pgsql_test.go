@@ -0,0 +1,50 @@
+package timestamp_source_test
+
+import (
+	"database/sql"
+	"os"
+	"testing"
+
+	timestamp_source "github.com/samlitowitz/protoc-gen-crud/test-cases/timestamp-source"
+
+	"github.com/samlitowitz/protoc-gen-crud/runtime"
+
+	test_cases "github.com/samlitowitz/protoc-gen-crud/test-cases"
+)
+
+func pgsqlTimestampSourceComponentUnderTest(t *testing.T, opts ...runtime.Option) (timestamp_source.ClientEventRepository, timestamp_source.DatabaseEventRepository) {
+	dburl, err := test_cases.PgSQLDBURLFromEnv()
+	if err != nil {
+		t.Fatal("pgsql: dburl: ", err)
+	}
+	db, err := sql.Open("pgx", dburl)
+	if err != nil {
+		t.Fatal("pgsql: ", err)
+	}
+	t.Cleanup(func() {
+		err := db.Close()
+		if err != nil {
+			t.Fatal("pgsql: ", err)
+		}
+	})
+
+	origDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal("pgsql: finding working dir:", err)
+	}
+
+	err = test_cases.PgSQLExecSQLFile(db, origDir+string(os.PathSeparator)+"test.pgsql.sql")
+	if err != nil {
+		t.Fatal("pgsql: executing setup SQL: ", err)
+	}
+
+	clientRepo, err := timestamp_source.NewPgSQLClientEventRepository(db, opts...)
+	if err != nil {
+		t.Fatal("pgsql: creating client event repository: ", err)
+	}
+	databaseRepo, err := timestamp_source.NewPgSQLDatabaseEventRepository(db, opts...)
+	if err != nil {
+		t.Fatal("pgsql: creating database event repository: ", err)
+	}
+	return clientRepo, databaseRepo
+}
@@ -0,0 +1,62 @@
+package timestamp_source_test
+
+import (
+	"database/sql"
+	"os"
+	"testing"
+
+	timestamp_source "github.com/samlitowitz/protoc-gen-crud/test-cases/timestamp-source"
+
+	"github.com/samlitowitz/protoc-gen-crud/runtime"
+)
+
+func sqliteExecSQLFile(db *sql.DB, file string) error {
+	code, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	stmt, err := db.Prepare(string(code))
+	if err != nil {
+		return err
+	}
+	_, err = stmt.Exec()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func sqliteTimestampSourceComponentUnderTest(t *testing.T, opts ...runtime.Option) (timestamp_source.ClientEventRepository, timestamp_source.DatabaseEventRepository) {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal("sqlite: ", err)
+	}
+	// every connection to :memory: is a distinct database
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() {
+		err := db.Close()
+		if err != nil {
+			t.Fatal("sqlite: ", err)
+		}
+	})
+
+	origDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal("sqlite: finding working dir:", err)
+	}
+
+	err = sqliteExecSQLFile(db, origDir+string(os.PathSeparator)+"test.sqlite.sql")
+	if err != nil {
+		t.Fatal("sqlite: executing setup SQL: ", err)
+	}
+
+	clientRepo, err := timestamp_source.NewSQLiteClientEventRepository(db, opts...)
+	if err != nil {
+		t.Fatal("sqlite: creating client event repository: ", err)
+	}
+	databaseRepo, err := timestamp_source.NewSQLiteDatabaseEventRepository(db, opts...)
+	if err != nil {
+		t.Fatal("sqlite: creating database event repository: ", err)
+	}
+	return clientRepo, databaseRepo
+}
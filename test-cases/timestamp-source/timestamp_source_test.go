@@ -0,0 +1,209 @@
+package timestamp_source_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	timestamp_source "github.com/samlitowitz/protoc-gen-crud/test-cases/timestamp-source"
+
+	"github.com/samlitowitz/protoc-gen-crud/options"
+	"github.com/samlitowitz/protoc-gen-crud/runtime"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+// frozenClock tells the same time until it is moved
+type frozenClock struct {
+	now time.Time
+}
+
+func (c *frozenClock) Now() time.Time {
+	return c.now
+}
+
+func TestClientEventRepository_Clock(t *testing.T) {
+	for repoType, componentUnderTest := range timestampSourceImplementationsToTest() {
+		repoDesc := repoType.String()
+		clock := &frozenClock{now: time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC)}
+		// Call setup function, inject t *testing.T, and use t.Cleanup
+		repo, _ := componentUnderTest(t, runtime.WithClock(clock))
+		if repo == nil {
+			t.Fatalf(
+				"%s: no implementation provided",
+				repoDesc,
+			)
+		}
+
+		created, err := repo.Create(context.Background(), []*timestamp_source.ClientEvent{
+			timestamp_source.ClientEvent_builder{Id: 1, Name: "one"}.Build(),
+		})
+		if err != nil {
+			t.Fatalf(
+				"%s: Create(): %s",
+				repoDesc,
+				err,
+			)
+		}
+		createdAt := clock.now
+
+		clock.now = clock.now.Add(time.Hour)
+		_, err = repo.Update(context.Background(), []*timestamp_source.ClientEvent{
+			timestamp_source.ClientEvent_builder{Id: 1, Name: "uno", CreatedAt: created[0].GetCreatedAt()}.Build(),
+		})
+		if err != nil {
+			t.Fatalf(
+				"%s: Update(): %s",
+				repoDesc,
+				err,
+			)
+		}
+
+		expected := []*timestamp_source.ClientEvent{
+			timestamp_source.ClientEvent_builder{
+				Id:        1,
+				Name:      "uno",
+				CreatedAt: timestamppb.New(createdAt),
+				UpdatedAt: timestamppb.New(clock.now),
+			}.Build(),
+		}
+		res, err := repo.Read(context.Background(), nil)
+		if err != nil {
+			t.Fatalf(
+				"%s: Read(): %s",
+				repoDesc,
+				err,
+			)
+		}
+		if diff := cmp.Diff(expected, res, protocmp.Transform()); diff != "" {
+			t.Fatal(mismatch(fmt.Sprintf("%s: Read():", repoDesc), diff))
+		}
+	}
+}
+
+func TestDatabaseEventRepository_DatabaseClock(t *testing.T) {
+	tests := map[string]struct {
+		fieldMask *fieldmaskpb.FieldMask
+	}{
+		"without field mask": {},
+		"with field mask": {
+			fieldMask: &fieldmaskpb.FieldMask{Paths: []string{"id", "name"}},
+		},
+	}
+
+	for repoType, componentUnderTest := range timestampSourceImplementationsToTest() {
+		repoDesc := repoType.String()
+		for testDesc, tc := range tests {
+			// the clock of the repository is ignored
+			stale := time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+			// Call setup function, inject t *testing.T, and use t.Cleanup
+			_, repo := componentUnderTest(t, runtime.WithClock(runtime.ClockFunc(func() time.Time { return stale })))
+			if repo == nil {
+				t.Fatalf(
+					"%s: no implementation provided",
+					repoDesc,
+				)
+			}
+
+			before := time.Now().Add(-time.Minute)
+			created, err := repo.Create(context.Background(), []*timestamp_source.DatabaseEvent{
+				timestamp_source.DatabaseEvent_builder{
+					FieldMask: tc.fieldMask,
+					Id:        1,
+					Name:      "one",
+					// the created at time is always set by the database
+					CreatedAt: timestamppb.New(stale),
+				}.Build(),
+			})
+			if err != nil {
+				t.Fatalf(
+					"%s: %s: Create(): %s",
+					repoDesc,
+					testDesc,
+					err,
+				)
+			}
+			updated, err := repo.Update(context.Background(), []*timestamp_source.DatabaseEvent{
+				timestamp_source.DatabaseEvent_builder{
+					FieldMask: tc.fieldMask,
+					Id:        1,
+					Name:      "uno",
+					CreatedAt: created[0].GetCreatedAt(),
+					// the updated at time is always set by the database
+					UpdatedAt: timestamppb.New(stale),
+				}.Build(),
+			})
+			if err != nil {
+				t.Fatalf(
+					"%s: %s: Update(): %s",
+					repoDesc,
+					testDesc,
+					err,
+				)
+			}
+			upserted, err := repo.Upsert(context.Background(), []*timestamp_source.DatabaseEvent{
+				timestamp_source.DatabaseEvent_builder{
+					FieldMask: tc.fieldMask,
+					Id:        2,
+					Name:      "two",
+					CreatedAt: timestamppb.New(stale),
+					UpdatedAt: timestamppb.New(stale),
+				}.Build(),
+			})
+			if err != nil {
+				t.Fatalf(
+					"%s: %s: Upsert(): %s",
+					repoDesc,
+					testDesc,
+					err,
+				)
+			}
+			after := time.Now().Add(time.Minute)
+
+			for desc, ts := range map[string]*timestamppb.Timestamp{
+				"created at":          updated[0].GetCreatedAt(),
+				"updated at":          updated[0].GetUpdatedAt(),
+				"upserted created at": upserted[0].GetCreatedAt(),
+				"upserted updated at": upserted[0].GetUpdatedAt(),
+			} {
+				if ts.AsTime().Before(before) || ts.AsTime().After(after) {
+					t.Fatalf(
+						"%s: %s: %s: expected the time of the database clock, got %s",
+						repoDesc,
+						testDesc,
+						desc,
+						ts.AsTime(),
+					)
+				}
+			}
+
+			res, err := repo.Read(context.Background(), nil)
+			if err != nil {
+				t.Fatalf(
+					"%s: %s: Read(): %s",
+					repoDesc,
+					testDesc,
+					err,
+				)
+			}
+			if diff := cmp.Diff(res, append(updated, upserted...), protocmp.Transform(), protocmp.IgnoreFields(&timestamp_source.DatabaseEvent{}, "fieldMask"), cmpopts.SortSlices(func(x, y *timestamp_source.DatabaseEvent) bool {
+				return x.GetId() < y.GetId()
+			})); diff != "" {
+				t.Fatal(mismatch(fmt.Sprintf("%s: %s: Read():", repoDesc, testDesc), diff))
+			}
+		}
+	}
+}
+
+func timestampSourceImplementationsToTest() map[options.Implementation]timestampSourceComponentUnderTest {
+	return map[options.Implementation]timestampSourceComponentUnderTest{
+		options.Implementation_IMPLEMENTATION_SQLITE: sqliteTimestampSourceComponentUnderTest,
+		options.Implementation_IMPLEMENTATION_PGSQL:  pgsqlTimestampSourceComponentUnderTest,
+	}
+}
@@ -0,0 +1,30 @@
+package unit_of_work_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	unit_of_work "github.com/samlitowitz/protoc-gen-crud/test-cases/unit-of-work"
+
+	crudruntime "github.com/samlitowitz/protoc-gen-crud/runtime"
+)
+
+// libraryComponentUnderTest is to be implemented to do setup and tear down for each implementation
+type libraryComponentUnderTest func(t *testing.T) *libraryComponents
+
+// libraryRepositories are the repositories of the messages defined in library.proto
+type libraryRepositories struct {
+	authors unit_of_work.AuthorRepository
+	books   unit_of_work.BookRepository
+}
+
+// libraryComponents adapts the transaction support generated for an implementation to the agnostic interfaces
+type libraryComponents struct {
+	db           *sql.DB
+	repositories *libraryRepositories
+	// withTx returns repositories taking part in tx
+	withTx func(tx *sql.Tx) *libraryRepositories
+	// unitOfWork runs fn with repositories sharing a transaction begun on db and configured by opts
+	unitOfWork func(ctx context.Context, db crudruntime.Querier, fn func(*libraryRepositories) error, opts ...crudruntime.Option) error
+}
@@ -0,0 +1,395 @@
+package unit_of_work_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	unit_of_work "github.com/samlitowitz/protoc-gen-crud/test-cases/unit-of-work"
+
+	"github.com/samlitowitz/protoc-gen-crud/options"
+	crudruntime "github.com/samlitowitz/protoc-gen-crud/runtime"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+var errLibraryAbort = errors.New("abort")
+
+func TestLibraryUnitOfWork_CommitsAllRepositories(t *testing.T) {
+	for repoType, componentUnderTest := range libraryImplementationsToTest() {
+		repoDesc := repoType.String()
+		// Call setup function, inject t *testing.T, and use t.Cleanup
+		components := componentUnderTest(t)
+		if components == nil {
+			t.Fatalf(
+				"%s: no implementation provided",
+				repoDesc,
+			)
+		}
+
+		err := components.unitOfWork(context.Background(), components.db, func(repos *libraryRepositories) error {
+			if _, err := repos.authors.Create(context.Background(), libraryAuthors()); err != nil {
+				return err
+			}
+			_, err := repos.books.Create(context.Background(), libraryBooks())
+			return err
+		})
+		if err != nil {
+			t.Fatalf(
+				"%s: UnitOfWork(): %s",
+				repoDesc,
+				err,
+			)
+		}
+
+		assertLibrary(t, components.repositories, []int32{1, 2}, []int32{1, 2, 3}, repoDesc)
+	}
+}
+
+func TestLibraryUnitOfWork_RollsBackOnError(t *testing.T) {
+	for repoType, componentUnderTest := range libraryImplementationsToTest() {
+		repoDesc := repoType.String()
+		// Call setup function, inject t *testing.T, and use t.Cleanup
+		components := componentUnderTest(t)
+		if components == nil {
+			t.Fatalf(
+				"%s: no implementation provided",
+				repoDesc,
+			)
+		}
+
+		err := components.unitOfWork(context.Background(), components.db, func(repos *libraryRepositories) error {
+			if _, err := repos.authors.Create(context.Background(), libraryAuthors()); err != nil {
+				return err
+			}
+			if _, err := repos.books.Create(context.Background(), libraryBooks()); err != nil {
+				return err
+			}
+			return errLibraryAbort
+		})
+		if !errors.Is(err, errLibraryAbort) {
+			t.Fatalf(
+				"%s: UnitOfWork(): expected %s, got %v",
+				repoDesc,
+				errLibraryAbort,
+				err,
+			)
+		}
+
+		assertLibrary(t, components.repositories, nil, nil, repoDesc)
+	}
+}
+
+func TestLibraryUnitOfWork_FailedOperationDoesNotAbortTransaction(t *testing.T) {
+	for repoType, componentUnderTest := range libraryImplementationsToTest() {
+		repoDesc := repoType.String()
+		// Call setup function, inject t *testing.T, and use t.Cleanup
+		components := componentUnderTest(t)
+		if components == nil {
+			t.Fatalf(
+				"%s: no implementation provided",
+				repoDesc,
+			)
+		}
+
+		err := components.unitOfWork(context.Background(), components.db, func(repos *libraryRepositories) error {
+			if _, err := repos.authors.Create(context.Background(), libraryAuthors()); err != nil {
+				return err
+			}
+			// duplicate primary keys, the failed create is rolled back to its savepoint
+			if _, err := repos.authors.Create(context.Background(), libraryAuthors()); err == nil {
+				return fmt.Errorf("Create(): expected duplicate error")
+			}
+			_, err := repos.books.Create(context.Background(), libraryBooks())
+			return err
+		})
+		if err != nil {
+			t.Fatalf(
+				"%s: UnitOfWork(): %s",
+				repoDesc,
+				err,
+			)
+		}
+
+		assertLibrary(t, components.repositories, []int32{1, 2}, []int32{1, 2, 3}, repoDesc)
+	}
+}
+
+func TestLibraryRepository_WithTx_SharesCallerTransaction(t *testing.T) {
+	for repoType, componentUnderTest := range libraryImplementationsToTest() {
+		repoDesc := repoType.String()
+		// Call setup function, inject t *testing.T, and use t.Cleanup
+		components := componentUnderTest(t)
+		if components == nil {
+			t.Fatalf(
+				"%s: no implementation provided",
+				repoDesc,
+			)
+		}
+
+		for _, commit := range []bool{false, true} {
+			tx, err := components.db.BeginTx(context.Background(), nil)
+			if err != nil {
+				t.Fatalf(
+					"%s: BeginTx(): %s",
+					repoDesc,
+					err,
+				)
+			}
+			repos := components.withTx(tx)
+			if _, err := repos.authors.Create(context.Background(), libraryAuthors()); err != nil {
+				t.Fatalf(
+					"%s: WithTx(): Create(): %s",
+					repoDesc,
+					err,
+				)
+			}
+			// hand written SQL takes part in the same transaction
+			if _, err := tx.ExecContext(context.Background(), `UPDATE "author" SET "name" = 'renamed' WHERE "id" = 1`); err != nil {
+				t.Fatalf(
+					"%s: ExecContext(): %s",
+					repoDesc,
+					err,
+				)
+			}
+			res, err := repos.authors.Read(context.Background(), nil)
+			if err != nil {
+				t.Fatalf(
+					"%s: WithTx(): Read(): %s",
+					repoDesc,
+					err,
+				)
+			}
+			if len(res) != 2 {
+				t.Fatalf(
+					"%s: WithTx(): Read(): expected 2 items, got %d items",
+					repoDesc,
+					len(res),
+				)
+			}
+			if !commit {
+				if err := tx.Rollback(); err != nil {
+					t.Fatalf(
+						"%s: Rollback(): %s",
+						repoDesc,
+						err,
+					)
+				}
+				assertLibrary(t, components.repositories, nil, nil, fmt.Sprintf("%s: rolled back", repoDesc))
+				continue
+			}
+			if err := tx.Commit(); err != nil {
+				t.Fatalf(
+					"%s: Commit(): %s",
+					repoDesc,
+					err,
+				)
+			}
+			assertLibrary(t, components.repositories, []int32{1, 2}, nil, fmt.Sprintf("%s: committed", repoDesc))
+		}
+
+		res, err := components.repositories.authors.Read(context.Background(), nil)
+		if err != nil {
+			t.Fatalf(
+				"%s: Read(): %s",
+				repoDesc,
+				err,
+			)
+		}
+		for _, author := range res {
+			if author.GetId() == 1 && author.GetName() != "renamed" {
+				t.Fatalf(
+					"%s: Read(): expected hand written update to be committed, got %q",
+					repoDesc,
+					author.GetName(),
+				)
+			}
+		}
+	}
+}
+
+func TestLibraryUnitOfWork_NestedInTransactionUsesSavepoint(t *testing.T) {
+	for repoType, componentUnderTest := range libraryImplementationsToTest() {
+		repoDesc := repoType.String()
+		// Call setup function, inject t *testing.T, and use t.Cleanup
+		components := componentUnderTest(t)
+		if components == nil {
+			t.Fatalf(
+				"%s: no implementation provided",
+				repoDesc,
+			)
+		}
+
+		tx, err := components.db.BeginTx(context.Background(), nil)
+		if err != nil {
+			t.Fatalf(
+				"%s: BeginTx(): %s",
+				repoDesc,
+				err,
+			)
+		}
+		defer tx.Rollback()
+
+		if _, err := components.withTx(tx).authors.Create(context.Background(), libraryAuthors()); err != nil {
+			t.Fatalf(
+				"%s: WithTx(): Create(): %s",
+				repoDesc,
+				err,
+			)
+		}
+		err = components.unitOfWork(context.Background(), tx, func(repos *libraryRepositories) error {
+			if _, err := repos.books.Create(context.Background(), libraryBooks()); err != nil {
+				return err
+			}
+			return errLibraryAbort
+		})
+		if !errors.Is(err, errLibraryAbort) {
+			t.Fatalf(
+				"%s: UnitOfWork(): expected %s, got %v",
+				repoDesc,
+				errLibraryAbort,
+				err,
+			)
+		}
+		if err := tx.Commit(); err != nil {
+			t.Fatalf(
+				"%s: Commit(): %s",
+				repoDesc,
+				err,
+			)
+		}
+
+		assertLibrary(t, components.repositories, []int32{1, 2}, nil, repoDesc)
+	}
+}
+
+func TestLibraryUnitOfWork_RepositoriesUseOptions(t *testing.T) {
+	now := time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC)
+	for repoType, componentUnderTest := range libraryImplementationsToTest() {
+		repoDesc := repoType.String()
+		// Call setup function, inject t *testing.T, and use t.Cleanup
+		components := componentUnderTest(t)
+		if components == nil {
+			t.Fatalf(
+				"%s: no implementation provided",
+				repoDesc,
+			)
+		}
+
+		err := components.unitOfWork(context.Background(), components.db, func(repos *libraryRepositories) error {
+			if _, err := repos.authors.Create(context.Background(), libraryAuthors()); err != nil {
+				return err
+			}
+			_, err := repos.books.Create(context.Background(), libraryBooks())
+			return err
+		}, crudruntime.WithClock(crudruntime.ClockFunc(func() time.Time { return now })))
+		if err != nil {
+			t.Fatalf(
+				"%s: UnitOfWork(): %s",
+				repoDesc,
+				err,
+			)
+		}
+
+		books, err := components.repositories.books.Read(context.Background(), nil)
+		if err != nil {
+			t.Fatalf(
+				"%s: Read(): %s",
+				repoDesc,
+				err,
+			)
+		}
+		expected := libraryBooks()
+		for _, book := range expected {
+			book.SetCreatedAt(timestamppb.New(now))
+		}
+		if diff := cmp.Diff(expected, books, protocmp.Transform(), cmpopts.SortSlices(func(x, y *unit_of_work.Book) bool {
+			return x.GetId() < y.GetId()
+		})); diff != "" {
+			t.Fatal(mismatch(fmt.Sprintf("%s: Read():", repoDesc), diff))
+		}
+	}
+}
+
+func assertLibrary(t *testing.T, repos *libraryRepositories, expectedAuthorIDs, expectedBookIDs []int32, prefix string) {
+	t.Helper()
+
+	authors, err := repos.authors.Read(context.Background(), nil)
+	if err != nil {
+		t.Fatalf(
+			"%s: Read(): authors: %s",
+			prefix,
+			err,
+		)
+	}
+	var authorIDs []int32
+	for _, author := range authors {
+		authorIDs = append(authorIDs, author.GetId())
+	}
+	sort.Slice(authorIDs, func(i, j int) bool { return authorIDs[i] < authorIDs[j] })
+	if diff := cmp.Diff(expectedAuthorIDs, authorIDs); diff != "" {
+		t.Fatal(
+			mismatch(
+				fmt.Sprintf(
+					"%s: Read(): authors:",
+					prefix,
+				),
+				diff,
+			),
+		)
+	}
+
+	books, err := repos.books.Read(context.Background(), nil)
+	if err != nil {
+		t.Fatalf(
+			"%s: Read(): books: %s",
+			prefix,
+			err,
+		)
+	}
+	var bookIDs []int32
+	for _, book := range books {
+		bookIDs = append(bookIDs, book.GetId())
+	}
+	sort.Slice(bookIDs, func(i, j int) bool { return bookIDs[i] < bookIDs[j] })
+	if diff := cmp.Diff(expectedBookIDs, bookIDs); diff != "" {
+		t.Fatal(
+			mismatch(
+				fmt.Sprintf(
+					"%s: Read(): books:",
+					prefix,
+				),
+				diff,
+			),
+		)
+	}
+}
+
+func libraryAuthors() []*unit_of_work.Author {
+	return []*unit_of_work.Author{
+		unit_of_work.Author_builder{Id: 1, Name: "Ursula K. Le Guin"}.Build(),
+		unit_of_work.Author_builder{Id: 2, Name: "Octavia E. Butler"}.Build(),
+	}
+}
+
+func libraryBooks() []*unit_of_work.Book {
+	return []*unit_of_work.Book{
+		unit_of_work.Book_builder{Id: 1, AuthorId: 1, Title: "The Dispossessed"}.Build(),
+		unit_of_work.Book_builder{Id: 2, AuthorId: 1, Title: "The Left Hand of Darkness"}.Build(),
+		unit_of_work.Book_builder{Id: 3, AuthorId: 2, Title: "Kindred"}.Build(),
+	}
+}
+
+func libraryImplementationsToTest() map[options.Implementation]libraryComponentUnderTest {
+	return map[options.Implementation]libraryComponentUnderTest{
+		options.Implementation_IMPLEMENTATION_SQLITE: sqliteLibraryComponentUnderTest,
+		options.Implementation_IMPLEMENTATION_PGSQL:  pgsqlLibraryComponentUnderTest,
+	}
+}
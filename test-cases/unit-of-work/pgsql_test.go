@@ -0,0 +1,71 @@
+package unit_of_work_test
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+
+	unit_of_work "github.com/samlitowitz/protoc-gen-crud/test-cases/unit-of-work"
+
+	crudruntime "github.com/samlitowitz/protoc-gen-crud/runtime"
+
+	test_cases "github.com/samlitowitz/protoc-gen-crud/test-cases"
+)
+
+func pgsqlLibraryComponentUnderTest(t *testing.T) *libraryComponents {
+	dburl, err := test_cases.PgSQLDBURLFromEnv()
+	if err != nil {
+		t.Fatal("pgsql: dburl: ", err)
+	}
+	db, err := sql.Open("pgx", dburl)
+	if err != nil {
+		t.Fatal("pgsql: ", err)
+	}
+	t.Cleanup(func() {
+		err := db.Close()
+		if err != nil {
+			t.Fatal("pgsql: ", err)
+		}
+	})
+
+	origDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal("pgsql: finding working dir:", err)
+	}
+
+	err = test_cases.PgSQLExecSQLFile(db, origDir+string(os.PathSeparator)+"library.pgsql.sql")
+	if err != nil {
+		t.Fatal("pgsql: executing setup SQL: ", err)
+	}
+
+	authors, err := unit_of_work.NewPgSQLAuthorRepository(db)
+	if err != nil {
+		t.Fatal("pgsql: creating repository: ", err)
+	}
+	books, err := unit_of_work.NewPgSQLBookRepository(db)
+	if err != nil {
+		t.Fatal("pgsql: creating repository: ", err)
+	}
+	return &libraryComponents{
+		db: db,
+		repositories: &libraryRepositories{
+			authors: authors,
+			books:   books,
+		},
+		withTx: func(tx *sql.Tx) *libraryRepositories {
+			return &libraryRepositories{
+				authors: authors.WithTx(tx),
+				books:   books.WithTx(tx),
+			}
+		},
+		unitOfWork: func(ctx context.Context, db crudruntime.Querier, fn func(*libraryRepositories) error, opts ...crudruntime.Option) error {
+			return unit_of_work.PgSQLLibraryUnitOfWork(ctx, db, nil, func(repos *unit_of_work.PgSQLLibraryRepositories) error {
+				return fn(&libraryRepositories{
+					authors: repos.Author,
+					books:   repos.Book,
+				})
+			}, opts...)
+		},
+	}
+}
@@ -0,0 +1,83 @@
+package unit_of_work_test
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+
+	unit_of_work "github.com/samlitowitz/protoc-gen-crud/test-cases/unit-of-work"
+
+	crudruntime "github.com/samlitowitz/protoc-gen-crud/runtime"
+)
+
+func sqliteExecSQLFile(db *sql.DB, file string) error {
+	code, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	stmt, err := db.Prepare(string(code))
+	if err != nil {
+		return err
+	}
+	_, err = stmt.Exec()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func sqliteLibraryComponentUnderTest(t *testing.T) *libraryComponents {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal("sqlite: ", err)
+	}
+	// every connection to :memory: is a distinct database, transactions must see the same one
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() {
+		err := db.Close()
+		if err != nil {
+			t.Fatal("sqlite: ", err)
+		}
+	})
+
+	origDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal("sqlite: finding working dir:", err)
+	}
+
+	err = sqliteExecSQLFile(db, origDir+string(os.PathSeparator)+"library.sqlite.sql")
+	if err != nil {
+		t.Fatal("sqlite: executing setup SQL: ", err)
+	}
+
+	authors, err := unit_of_work.NewSQLiteAuthorRepository(db)
+	if err != nil {
+		t.Fatal("sqlite: creating repository: ", err)
+	}
+	books, err := unit_of_work.NewSQLiteBookRepository(db)
+	if err != nil {
+		t.Fatal("sqlite: creating repository: ", err)
+	}
+	return &libraryComponents{
+		db: db,
+		repositories: &libraryRepositories{
+			authors: authors,
+			books:   books,
+		},
+		withTx: func(tx *sql.Tx) *libraryRepositories {
+			return &libraryRepositories{
+				authors: authors.WithTx(tx),
+				books:   books.WithTx(tx),
+			}
+		},
+		unitOfWork: func(ctx context.Context, db crudruntime.Querier, fn func(*libraryRepositories) error, opts ...crudruntime.Option) error {
+			return unit_of_work.SQLiteLibraryUnitOfWork(ctx, db, nil, func(repos *unit_of_work.SQLiteLibraryRepositories) error {
+				return fn(&libraryRepositories{
+					authors: repos.Author,
+					books:   repos.Book,
+				})
+			}, opts...)
+		},
+	}
+}
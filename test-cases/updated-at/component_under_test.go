@@ -4,7 +4,9 @@ import (
 	"testing"
 
 	updated_at "github.com/samlitowitz/protoc-gen-crud/test-cases/updated-at"
+
+	"github.com/samlitowitz/protoc-gen-crud/runtime"
 )
 
 // updatedAtComponentUnderTest is to be implemented to do setup and tear down for each implementation
-type updatedAtComponentUnderTest func(t *testing.T) updated_at.UpdatedAtRepository
+type updatedAtComponentUnderTest func(t *testing.T, opts ...runtime.Option) updated_at.UpdatedAtRepository
@@ -7,10 +7,12 @@ import (
 
 	updated_at "github.com/samlitowitz/protoc-gen-crud/test-cases/updated-at"
 
+	"github.com/samlitowitz/protoc-gen-crud/runtime"
+
 	test_cases "github.com/samlitowitz/protoc-gen-crud/test-cases"
 )
 
-func pgsqlUpdatedAtComponentUnderTest(t *testing.T) updated_at.UpdatedAtRepository {
+func pgsqlUpdatedAtComponentUnderTest(t *testing.T, opts ...runtime.Option) updated_at.UpdatedAtRepository {
 	dburl, err := test_cases.PgSQLDBURLFromEnv()
 	if err != nil {
 		t.Fatal("pgsql: dburl: ", err)
@@ -36,7 +38,7 @@ func pgsqlUpdatedAtComponentUnderTest(t *testing.T) updated_at.UpdatedAtReposito
 		t.Fatal("pgsql: executing setup SQL: ", err)
 	}
 
-	repo, err := updated_at.NewPgSQLUpdatedAtRepository(db)
+	repo, err := updated_at.NewPgSQLUpdatedAtRepository(db, opts...)
 	if err != nil {
 		t.Fatal("pgsql: creating repository: ", err)
 	}
@@ -6,6 +6,8 @@ import (
 	"testing"
 
 	updated_at "github.com/samlitowitz/protoc-gen-crud/test-cases/updated-at"
+
+	"github.com/samlitowitz/protoc-gen-crud/runtime"
 )
 
 func sqliteExecSQLFile(db *sql.DB, file string) error {
@@ -24,7 +26,7 @@ func sqliteExecSQLFile(db *sql.DB, file string) error {
 	return nil
 }
 
-func sqliteUpdatedAtComponentUnderTest(t *testing.T) updated_at.UpdatedAtRepository {
+func sqliteUpdatedAtComponentUnderTest(t *testing.T, opts ...runtime.Option) updated_at.UpdatedAtRepository {
 	db, err := sql.Open("sqlite", ":memory:")
 	if err != nil {
 		t.Fatal("sqlite: ", err)
@@ -46,7 +48,7 @@ func sqliteUpdatedAtComponentUnderTest(t *testing.T) updated_at.UpdatedAtReposit
 		t.Fatal("sqlite: executing setup SQL: ", err)
 	}
 
-	repo, err := updated_at.NewSQLiteUpdatedAtRepository(db)
+	repo, err := updated_at.NewSQLiteUpdatedAtRepository(db, opts...)
 	if err != nil {
 		t.Fatal("sqlite: creating repository: ", err)
 	}
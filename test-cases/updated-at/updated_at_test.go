@@ -2,16 +2,20 @@ package updated_at_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	updated_at "github.com/samlitowitz/protoc-gen-crud/test-cases/updated-at"
 
+	"google.golang.org/protobuf/testing/protocmp"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	test_cases "github.com/samlitowitz/protoc-gen-crud/test-cases"
 
 	"github.com/samlitowitz/protoc-gen-crud/options"
+	"github.com/samlitowitz/protoc-gen-crud/runtime"
 
 	"github.com/samlitowitz/expressions"
 
@@ -298,10 +302,11 @@ func TestUpdatedAtRepository_Update_WithUnLocatablePrimaryKeyUpdatesNothing(t *t
 			expected = append(expected, builder.Build())
 		}
 		_, err = repoImpl.Update(context.Background(), expected)
-		if err != nil {
+		if !errors.Is(err, runtime.ErrNotFound) {
 			t.Fatalf(
-				"%s: Update(): %s",
+				"%s: Update(): expected %v, got %v",
 				repoDesc,
+				runtime.ErrNotFound,
 				err,
 			)
 		}
@@ -610,7 +615,7 @@ func TestUpdatedAtRepository_Delete_WithLocatablePrimaryKeySucceeds(t *testing.T
 				)
 			}
 
-			err = repoImpl.Delete(context.Background(), testCase.deleteExpression)
+			deleted, err := repoImpl.Delete(context.Background(), testCase.deleteExpression)
 			if err != nil {
 				t.Fatalf(
 					"%s: %s: Delete(): %s",
@@ -619,6 +624,15 @@ func TestUpdatedAtRepository_Delete_WithLocatablePrimaryKeySucceeds(t *testing.T
 					err,
 				)
 			}
+			if deleted != int64(len(testCase.initial)-len(testCase.expected)) {
+				t.Fatalf(
+					"%s: %s: Delete(): expected %d deleted, got %d",
+					testDesc,
+					repoDesc,
+					len(testCase.initial)-len(testCase.expected),
+					deleted,
+				)
+			}
 
 			res, err = repoImpl.Read(context.Background(), nil)
 			if err != nil {
@@ -743,6 +757,70 @@ func TestUpdatedAtRepository_Create_CorrectlySetsUpdatedAt(t *testing.T) {
 	}
 }
 
+func TestUpdatedAtRepository_Update_SetsUpdatedAtFromClock(t *testing.T) {
+	provided := time.Date(2020, time.June, 7, 8, 9, 10, 0, time.UTC)
+	for repoType, componentUnderTest := range updatedAtImplementationsToTest() {
+		repoDesc := repoType.String()
+		clock := &frozenClock{now: time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC)}
+		// Call setup function, inject t *testing.T, and use t.Cleanup
+		repoImpl := componentUnderTest(t, runtime.WithClock(clock))
+		if repoImpl == nil {
+			t.Fatalf(
+				"%s: no implementation provided",
+				repoDesc,
+			)
+		}
+
+		_, err := repoImpl.Create(context.Background(), []*updated_at.UpdatedAt{
+			updated_at.UpdatedAt_builder{Id: 0, Data: "zero"}.Build(),
+			updated_at.UpdatedAt_builder{Id: 1, Data: "one"}.Build(),
+		})
+		if err != nil {
+			t.Fatalf(
+				"%s: Create(): %s",
+				repoDesc,
+				err,
+			)
+		}
+
+		clock.now = clock.now.Add(time.Hour)
+		updated, err := repoImpl.Update(context.Background(), []*updated_at.UpdatedAt{
+			updated_at.UpdatedAt_builder{Id: 0, Data: "zero"}.Build(),
+			updated_at.UpdatedAt_builder{Id: 1, Data: "uno", UpdatedAt: timestamppb.New(provided)}.Build(),
+		})
+		if err != nil {
+			t.Fatalf(
+				"%s: Update(): %s",
+				repoDesc,
+				err,
+			)
+		}
+
+		// updated at times already set are kept
+		expected := []*updated_at.UpdatedAt{
+			updated_at.UpdatedAt_builder{Id: 0, Data: "zero", UpdatedAt: timestamppb.New(clock.now)}.Build(),
+			updated_at.UpdatedAt_builder{Id: 1, Data: "uno", UpdatedAt: timestamppb.New(provided)}.Build(),
+		}
+		if diff := cmp.Diff(expected, updated, protocmp.Transform()); diff != "" {
+			t.Fatal(mismatch(fmt.Sprintf("%s: Update():", repoDesc), diff))
+		}
+
+		res, err := repoImpl.Read(context.Background(), nil)
+		if err != nil {
+			t.Fatalf(
+				"%s: Read(): %s",
+				repoDesc,
+				err,
+			)
+		}
+		if diff := cmp.Diff(expected, res, protocmp.Transform(), cmpopts.SortSlices(func(x, y *updated_at.UpdatedAt) bool {
+			return x.GetId() < y.GetId()
+		})); diff != "" {
+			t.Fatal(mismatch(fmt.Sprintf("%s: Read():", repoDesc), diff))
+		}
+	}
+}
+
 func updatedAtImplementationsToTest() map[options.Implementation]updatedAtComponentUnderTest {
 	return map[options.Implementation]updatedAtComponentUnderTest{
 		options.Implementation_IMPLEMENTATION_SQLITE: sqliteUpdatedAtComponentUnderTest,
@@ -760,3 +838,12 @@ func updatedAtDefaultCmpOpts() cmp.Options {
 		}),
 	}
 }
+
+// frozenClock tells the same time until it is moved
+type frozenClock struct {
+	now time.Time
+}
+
+func (c *frozenClock) Now() time.Time {
+	return c.now
+}
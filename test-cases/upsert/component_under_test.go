@@ -0,0 +1,16 @@
+package upsert_test
+
+import (
+	"testing"
+
+	"github.com/samlitowitz/protoc-gen-crud/test-cases/upsert"
+)
+
+type upsertRepositories struct {
+	items           upsert.ItemRepository
+	maskedItems     upsert.MaskedItemRepository
+	sequentialItems upsert.SequentialItemRepository
+}
+
+// upsertComponentUnderTest is to be implemented to do setup and tear down for each implementation
+type upsertComponentUnderTest func(t *testing.T) *upsertRepositories
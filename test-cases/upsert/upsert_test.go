@@ -0,0 +1,385 @@
+package upsert_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/samlitowitz/protoc-gen-crud/test-cases/upsert"
+
+	"github.com/samlitowitz/protoc-gen-crud/options"
+	crudruntime "github.com/samlitowitz/protoc-gen-crud/runtime"
+
+	"github.com/samlitowitz/expressions"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestItemRepository_Upsert_CreatesAndUpdates(t *testing.T) {
+	createdAt := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	for repoType, componentUnderTest := range upsertImplementationsToTest() {
+		repoDesc := repoType.String()
+		// Call setup function, inject t *testing.T, and use t.Cleanup
+		repos := componentUnderTest(t)
+		if repos == nil {
+			t.Fatalf(
+				"%s: no implementation provided",
+				repoDesc,
+			)
+		}
+
+		_, err := repos.items.Create(context.Background(), []*upsert.Item{
+			upsert.Item_builder{Id: 1, Data: "one", CreatedAt: timestamppb.New(createdAt)}.Build(),
+		})
+		if err != nil {
+			t.Fatalf(
+				"%s: Create(): %s",
+				repoDesc,
+				err,
+			)
+		}
+
+		_, err = repos.items.Upsert(context.Background(), []*upsert.Item{
+			upsert.Item_builder{Id: 1, Data: "uno"}.Build(),
+			upsert.Item_builder{Id: 2, Data: "two"}.Build(),
+		})
+		if err != nil {
+			t.Fatalf(
+				"%s: Upsert(): %s",
+				repoDesc,
+				err,
+			)
+		}
+
+		res, err := repos.items.Read(context.Background(), nil)
+		if err != nil {
+			t.Fatalf(
+				"%s: Read(): %s",
+				repoDesc,
+				err,
+			)
+		}
+		sort.Slice(res, func(i, j int) bool { return res[i].GetId() < res[j].GetId() })
+		var data []string
+		for _, item := range res {
+			data = append(data, item.GetData())
+			if item.GetUpdatedAt() == nil {
+				t.Fatalf(
+					"%s: Read(): %d: expected updated at to be set",
+					repoDesc,
+					item.GetId(),
+				)
+			}
+		}
+		if diff := cmp.Diff([]string{"uno", "two"}, data); diff != "" {
+			t.Fatal(
+				mismatch(
+					fmt.Sprintf(
+						"%s: Read():",
+						repoDesc,
+					),
+					diff,
+				),
+			)
+		}
+		if !res[0].GetCreatedAt().AsTime().Equal(createdAt) {
+			t.Fatalf(
+				"%s: Read(): expected created at of the existing item to be kept, got %s",
+				repoDesc,
+				res[0].GetCreatedAt().AsTime(),
+			)
+		}
+		if !res[1].GetCreatedAt().AsTime().After(createdAt) {
+			t.Fatalf(
+				"%s: Read(): expected created at of the new item to be set, got %s",
+				repoDesc,
+				res[1].GetCreatedAt().AsTime(),
+			)
+		}
+	}
+}
+
+func TestItemRepository_Upsert_SoftDeletedFailsAndIsLeftUnchanged(t *testing.T) {
+	for repoType, componentUnderTest := range upsertImplementationsToTest() {
+		repoDesc := repoType.String()
+		// Call setup function, inject t *testing.T, and use t.Cleanup
+		repos := componentUnderTest(t)
+		if repos == nil {
+			t.Fatalf(
+				"%s: no implementation provided",
+				repoDesc,
+			)
+		}
+
+		_, err := repos.items.Create(context.Background(), []*upsert.Item{
+			upsert.Item_builder{Id: 1, Data: "one"}.Build(),
+		})
+		if err != nil {
+			t.Fatalf(
+				"%s: Create(): %s",
+				repoDesc,
+				err,
+			)
+		}
+		byID := expressions.NewEquals(
+			expressions.NewIdentifier(upsert.Item_Id_Field),
+			expressions.NewScalar(int32(1)),
+		)
+		if _, err := repos.items.Delete(context.Background(), byID); err != nil {
+			t.Fatalf(
+				"%s: Delete(): %s",
+				repoDesc,
+				err,
+			)
+		}
+
+		_, err = repos.items.Upsert(context.Background(), []*upsert.Item{
+			upsert.Item_builder{Id: 1, Data: "uno"}.Build(),
+		})
+		if !errors.Is(err, crudruntime.ErrNotFound) {
+			t.Fatalf(
+				"%s: Upsert(): expected %s, got %v",
+				repoDesc,
+				crudruntime.ErrNotFound,
+				err,
+			)
+		}
+
+		if _, err := repos.items.Restore(context.Background(), byID); err != nil {
+			t.Fatalf(
+				"%s: Restore(): %s",
+				repoDesc,
+				err,
+			)
+		}
+		res, err := repos.items.Read(context.Background(), nil)
+		if err != nil {
+			t.Fatalf(
+				"%s: Read(): %s",
+				repoDesc,
+				err,
+			)
+		}
+		if len(res) != 1 || res[0].GetData() != "one" {
+			t.Fatalf(
+				"%s: Read(): expected the soft deleted item to be unchanged, got %v",
+				repoDesc,
+				res,
+			)
+		}
+	}
+}
+
+func TestMaskedItemRepository_Upsert_SoftDeletedFails(t *testing.T) {
+	for repoType, componentUnderTest := range upsertImplementationsToTest() {
+		repoDesc := repoType.String()
+		// Call setup function, inject t *testing.T, and use t.Cleanup
+		repos := componentUnderTest(t)
+		if repos == nil {
+			t.Fatalf(
+				"%s: no implementation provided",
+				repoDesc,
+			)
+		}
+
+		_, err := repos.maskedItems.Create(context.Background(), []*upsert.MaskedItem{
+			upsert.MaskedItem_builder{Id: 1, Data: "one", Quantity: 1}.Build(),
+		})
+		if err != nil {
+			t.Fatalf(
+				"%s: Create(): %s",
+				repoDesc,
+				err,
+			)
+		}
+		_, err = repos.maskedItems.Delete(context.Background(), expressions.NewEquals(
+			expressions.NewIdentifier(upsert.MaskedItem_Id_Field),
+			expressions.NewScalar(int32(1)),
+		))
+		if err != nil {
+			t.Fatalf(
+				"%s: Delete(): %s",
+				repoDesc,
+				err,
+			)
+		}
+
+		for _, paths := range [][]string{{"id", "data"}, {"id"}} {
+			_, err = repos.maskedItems.Upsert(context.Background(), []*upsert.MaskedItem{
+				upsert.MaskedItem_builder{
+					FieldMask: &field_mask.FieldMask{Paths: paths},
+					Id:        1,
+					Data:      "uno",
+				}.Build(),
+			})
+			if !errors.Is(err, crudruntime.ErrNotFound) {
+				t.Fatalf(
+					"%s: Upsert(): %v: expected %s, got %v",
+					repoDesc,
+					paths,
+					crudruntime.ErrNotFound,
+					err,
+				)
+			}
+		}
+	}
+}
+
+func TestMaskedItemRepository_Upsert_HonorsFieldMask(t *testing.T) {
+	for repoType, componentUnderTest := range upsertImplementationsToTest() {
+		repoDesc := repoType.String()
+		// Call setup function, inject t *testing.T, and use t.Cleanup
+		repos := componentUnderTest(t)
+		if repos == nil {
+			t.Fatalf(
+				"%s: no implementation provided",
+				repoDesc,
+			)
+		}
+
+		_, err := repos.maskedItems.Create(context.Background(), []*upsert.MaskedItem{
+			upsert.MaskedItem_builder{Id: 1, Data: "one", Quantity: 1}.Build(),
+		})
+		if err != nil {
+			t.Fatalf(
+				"%s: Create(): %s",
+				repoDesc,
+				err,
+			)
+		}
+
+		_, err = repos.maskedItems.Upsert(context.Background(), []*upsert.MaskedItem{
+			upsert.MaskedItem_builder{
+				FieldMask: &field_mask.FieldMask{Paths: []string{"id", "data"}},
+				Id:        1,
+				Data:      "uno",
+				Quantity:  5,
+			}.Build(),
+			upsert.MaskedItem_builder{
+				FieldMask: &field_mask.FieldMask{Paths: []string{"id", "data"}},
+				Id:        2,
+				Data:      "two",
+				Quantity:  7,
+			}.Build(),
+			upsert.MaskedItem_builder{Id: 3, Data: "three", Quantity: 3}.Build(),
+		})
+		if err != nil {
+			t.Fatalf(
+				"%s: Upsert(): %s",
+				repoDesc,
+				err,
+			)
+		}
+
+		res, err := repos.maskedItems.Read(context.Background(), nil)
+		if err != nil {
+			t.Fatalf(
+				"%s: Read(): %s",
+				repoDesc,
+				err,
+			)
+		}
+		sort.Slice(res, func(i, j int) bool { return res[i].GetId() < res[j].GetId() })
+		var got []string
+		for _, item := range res {
+			got = append(got, fmt.Sprintf("%d:%s:%d", item.GetId(), item.GetData(), item.GetQuantity()))
+		}
+		if diff := cmp.Diff([]string{"1:uno:1", "2:two:0", "3:three:3"}, got); diff != "" {
+			t.Fatal(
+				mismatch(
+					fmt.Sprintf(
+						"%s: Read():",
+						repoDesc,
+					),
+					diff,
+				),
+			)
+		}
+	}
+}
+
+func TestSequentialItemRepository_Upsert_GeneratesMissingKeys(t *testing.T) {
+	for repoType, componentUnderTest := range upsertImplementationsToTest() {
+		repoDesc := repoType.String()
+		// Call setup function, inject t *testing.T, and use t.Cleanup
+		repos := componentUnderTest(t)
+		if repos == nil {
+			t.Fatalf(
+				"%s: no implementation provided",
+				repoDesc,
+			)
+		}
+
+		created, err := repos.sequentialItems.Create(context.Background(), []*upsert.SequentialItem{
+			upsert.SequentialItem_builder{Data: "one"}.Build(),
+		})
+		if err != nil {
+			t.Fatalf(
+				"%s: Create(): %s",
+				repoDesc,
+				err,
+			)
+		}
+
+		upserted, err := repos.sequentialItems.Upsert(context.Background(), []*upsert.SequentialItem{
+			upsert.SequentialItem_builder{Id: created[0].GetId(), Data: "uno"}.Build(),
+			upsert.SequentialItem_builder{Data: "two"}.Build(),
+		})
+		if err != nil {
+			t.Fatalf(
+				"%s: Upsert(): %s",
+				repoDesc,
+				err,
+			)
+		}
+		if upserted[1].GetId() == 0 || upserted[1].GetId() == created[0].GetId() {
+			t.Fatalf(
+				"%s: Upsert(): expected a generated key, got %d",
+				repoDesc,
+				upserted[1].GetId(),
+			)
+		}
+
+		res, err := repos.sequentialItems.Read(context.Background(), nil)
+		if err != nil {
+			t.Fatalf(
+				"%s: Read(): %s",
+				repoDesc,
+				err,
+			)
+		}
+		dataByID := make(map[int64]string, len(res))
+		for _, item := range res {
+			dataByID[item.GetId()] = item.GetData()
+		}
+		expected := map[int64]string{
+			created[0].GetId():  "uno",
+			upserted[1].GetId(): "two",
+		}
+		if diff := cmp.Diff(expected, dataByID); diff != "" {
+			t.Fatal(
+				mismatch(
+					fmt.Sprintf(
+						"%s: Read():",
+						repoDesc,
+					),
+					diff,
+				),
+			)
+		}
+	}
+}
+
+func upsertImplementationsToTest() map[options.Implementation]upsertComponentUnderTest {
+	return map[options.Implementation]upsertComponentUnderTest{
+		options.Implementation_IMPLEMENTATION_SQLITE: sqliteUpsertComponentUnderTest,
+		options.Implementation_IMPLEMENTATION_PGSQL:  pgsqlUpsertComponentUnderTest,
+	}
+}
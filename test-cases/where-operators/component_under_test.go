@@ -0,0 +1,10 @@
+package where_operators_test
+
+import (
+	"testing"
+
+	where_operators "github.com/samlitowitz/protoc-gen-crud/test-cases/where-operators"
+)
+
+// operatorsComponentUnderTest is to be implemented to do setup and tear down for each implementation
+type operatorsComponentUnderTest func(t *testing.T) where_operators.OperatorsRepository
@@ -0,0 +1,260 @@
+package where_operators_test
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	where_operators "github.com/samlitowitz/protoc-gen-crud/test-cases/where-operators"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/samlitowitz/protoc-gen-crud/options"
+
+	crudexpressions "github.com/samlitowitz/protoc-gen-crud/expressions"
+
+	"github.com/samlitowitz/expressions"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestOperatorsRepository_Read_TranslatesOperators(t *testing.T) {
+	id := expressions.NewIdentifier(where_operators.Operators_Id_Field)
+	data := expressions.NewIdentifier(where_operators.Operators_Data_Field)
+	occurredAt := expressions.NewIdentifier(where_operators.Operators_OccurredAt_Field)
+
+	testCases := map[string]struct {
+		expression  expressions.Expression
+		expectedIDs []int32
+	}{
+		"not equals": {
+			expression:  crudexpressions.NewNotEquals(id, expressions.NewScalar(2)),
+			expectedIDs: []int32{0, 1, 3, 4},
+		},
+		"less than": {
+			expression:  crudexpressions.NewLessThan(id, expressions.NewScalar(2)),
+			expectedIDs: []int32{0, 1},
+		},
+		"less than or equal": {
+			expression:  crudexpressions.NewLessThanOrEqual(id, expressions.NewScalar(2)),
+			expectedIDs: []int32{0, 1, 2},
+		},
+		"greater than": {
+			expression:  crudexpressions.NewGreaterThan(id, expressions.NewScalar(2)),
+			expectedIDs: []int32{3, 4},
+		},
+		"greater than or equal": {
+			expression:  crudexpressions.NewGreaterThanOrEqual(id, expressions.NewScalar(2)),
+			expectedIDs: []int32{2, 3, 4},
+		},
+		"greater than timestamp in another time zone": {
+			expression: crudexpressions.NewGreaterThan(
+				occurredAt,
+				// 2000-01-02T23:00:00Z
+				expressions.NewTimestamp(time.Date(2000, 1, 3, 1, 0, 0, 0, time.FixedZone("UTC+2", 2*60*60))),
+			),
+			expectedIDs: []int32{2, 3, 4},
+		},
+		"between": {
+			expression:  crudexpressions.NewBetween(id, expressions.NewScalar(1), expressions.NewScalar(3)),
+			expectedIDs: []int32{1, 2, 3},
+		},
+		"between timestamps": {
+			expression: crudexpressions.NewBetween(
+				occurredAt,
+				expressions.NewTimestamp(operatorsOccurredAt(1)),
+				expressions.NewTimestamp(operatorsOccurredAt(2)),
+			),
+			expectedIDs: []int32{1, 2},
+		},
+		"between and equals": {
+			expression: expressions.NewAnd(
+				crudexpressions.NewBetween(id, expressions.NewScalar(1), expressions.NewScalar(3)),
+				expressions.NewEquals(data, expressions.NewScalar("three")),
+			),
+			expectedIDs: []int32{3},
+		},
+		"in": {
+			expression: crudexpressions.NewIn(
+				id,
+				expressions.NewScalar(0),
+				expressions.NewScalar(2),
+				expressions.NewScalar(4),
+			),
+			expectedIDs: []int32{0, 2, 4},
+		},
+		"in without values": {
+			expression:  crudexpressions.NewIn(id),
+			expectedIDs: nil,
+		},
+		"not in": {
+			expression: expressions.NewNot(
+				crudexpressions.NewIn(
+					id,
+					expressions.NewScalar(0),
+					expressions.NewScalar(2),
+					expressions.NewScalar(4),
+				),
+			),
+			expectedIDs: []int32{1, 3},
+		},
+		"like": {
+			expression:  crudexpressions.NewLike(data, expressions.NewScalar("%r%")),
+			expectedIDs: []int32{0, 3, 4},
+		},
+		"ilike": {
+			expression:  crudexpressions.NewILike(data, expressions.NewScalar("t%")),
+			expectedIDs: []int32{2, 3},
+		},
+		"is null": {
+			expression:  crudexpressions.NewIsNull(data),
+			expectedIDs: nil,
+		},
+		"is not null": {
+			expression:  crudexpressions.NewIsNotNull(data),
+			expectedIDs: []int32{0, 1, 2, 3, 4},
+		},
+	}
+
+	for repoType, componentUnderTest := range operatorsImplementationsToTest() {
+		repoDesc := repoType.String()
+		// Call setup function, inject t *testing.T, and use t.Cleanup
+		repoImpl := componentUnderTest(t)
+		if repoImpl == nil {
+			t.Fatalf(
+				"%s: no implementation provided",
+				repoDesc,
+			)
+		}
+
+		_, err := repoImpl.Create(context.Background(), operatorsInitial())
+		if err != nil {
+			t.Fatalf(
+				"%s: Create(): %s",
+				repoDesc,
+				err,
+			)
+		}
+
+		for testDesc, testCase := range testCases {
+			res, err := repoImpl.Read(context.Background(), testCase.expression)
+			if err != nil {
+				t.Fatalf(
+					"%s: %s: Read(): %s",
+					repoDesc,
+					testDesc,
+					err,
+				)
+			}
+			var ids []int32
+			for _, operators := range res {
+				ids = append(ids, operators.GetId())
+			}
+			sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+			if diff := cmp.Diff(testCase.expectedIDs, ids); diff != "" {
+				t.Fatal(
+					mismatch(
+						fmt.Sprintf(
+							"%s: %s: Read():",
+							repoDesc,
+							testDesc,
+						),
+						diff,
+					),
+				)
+			}
+		}
+	}
+}
+
+func TestOperatorsRepository_Delete_TranslatesOperators(t *testing.T) {
+	for repoType, componentUnderTest := range operatorsImplementationsToTest() {
+		repoDesc := repoType.String()
+		// Call setup function, inject t *testing.T, and use t.Cleanup
+		repoImpl := componentUnderTest(t)
+		if repoImpl == nil {
+			t.Fatalf(
+				"%s: no implementation provided",
+				repoDesc,
+			)
+		}
+
+		_, err := repoImpl.Create(context.Background(), operatorsInitial())
+		if err != nil {
+			t.Fatalf(
+				"%s: Create(): %s",
+				repoDesc,
+				err,
+			)
+		}
+
+		_, err = repoImpl.Delete(
+			context.Background(),
+			crudexpressions.NewLessThan(
+				expressions.NewIdentifier(where_operators.Operators_OccurredAt_Field),
+				expressions.NewTimestamp(operatorsOccurredAt(2)),
+			),
+		)
+		if err != nil {
+			t.Fatalf(
+				"%s: Delete(): %s",
+				repoDesc,
+				err,
+			)
+		}
+
+		res, err := repoImpl.Read(context.Background(), nil)
+		if err != nil {
+			t.Fatalf(
+				"%s: Read(): %s",
+				repoDesc,
+				err,
+			)
+		}
+		var ids []int32
+		for _, operators := range res {
+			ids = append(ids, operators.GetId())
+		}
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+		if diff := cmp.Diff([]int32{2, 3, 4}, ids); diff != "" {
+			t.Fatal(
+				mismatch(
+					fmt.Sprintf(
+						"%s: Read():",
+						repoDesc,
+					),
+					diff,
+				),
+			)
+		}
+	}
+}
+
+func operatorsOccurredAt(id int32) time.Time {
+	return time.Date(2000, 1, 1+int(id), 0, 0, 0, 0, time.UTC)
+}
+
+func operatorsInitial() []*where_operators.Operators {
+	data := []string{"zero", "one", "Two", "three", "four"}
+	initial := make([]*where_operators.Operators, 0, len(data))
+	for id, datum := range data {
+		initial = append(
+			initial,
+			where_operators.Operators_builder{
+				Id:         int32(id),
+				Data:       datum,
+				OccurredAt: timestamppb.New(operatorsOccurredAt(int32(id))),
+			}.Build(),
+		)
+	}
+	return initial
+}
+
+func operatorsImplementationsToTest() map[options.Implementation]operatorsComponentUnderTest {
+	return map[options.Implementation]operatorsComponentUnderTest{
+		options.Implementation_IMPLEMENTATION_SQLITE: sqliteOperatorsComponentUnderTest,
+		options.Implementation_IMPLEMENTATION_PGSQL:  pgsqlOperatorsComponentUnderTest,
+	}
+}
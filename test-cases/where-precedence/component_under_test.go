@@ -0,0 +1,10 @@
+package where_precedence_test
+
+import (
+	"testing"
+
+	where_precedence "github.com/samlitowitz/protoc-gen-crud/test-cases/where-precedence"
+)
+
+// precedenceComponentUnderTest is to be implemented to do setup and tear down for each implementation
+type precedenceComponentUnderTest func(t *testing.T) where_precedence.PrecedenceRepository
@@ -0,0 +1,291 @@
+package where_precedence_test
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"sort"
+	"testing"
+	"time"
+
+	where_precedence "github.com/samlitowitz/protoc-gen-crud/test-cases/where-precedence"
+
+	"github.com/samlitowitz/protoc-gen-crud/options"
+
+	crudexpressions "github.com/samlitowitz/protoc-gen-crud/expressions"
+
+	"github.com/samlitowitz/expressions"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const (
+	precedenceRowCount        = 20
+	precedenceExpressionCount = 250
+	precedenceMaxDepth        = 4
+)
+
+func TestPrecedenceRepository_Read_PreservesExpressionStructure(t *testing.T) {
+	id := expressions.NewIdentifier(where_precedence.Precedence_Id_Field)
+	a := expressions.NewIdentifier(where_precedence.Precedence_A_Field)
+	b := expressions.NewIdentifier(where_precedence.Precedence_B_Field)
+
+	testCases := map[string]expressions.Expression{
+		"and of or": expressions.NewAnd(
+			expressions.NewOr(
+				expressions.NewEquals(a, expressions.NewScalar(int32(0))),
+				expressions.NewEquals(b, expressions.NewScalar(int32(0))),
+			),
+			crudexpressions.NewLessThan(id, expressions.NewScalar(int32(10))),
+		),
+		"or of and": expressions.NewOr(
+			expressions.NewEquals(a, expressions.NewScalar(int32(0))),
+			expressions.NewAnd(
+				expressions.NewEquals(b, expressions.NewScalar(int32(0))),
+				crudexpressions.NewLessThan(id, expressions.NewScalar(int32(10))),
+			),
+		),
+		"not of and": expressions.NewNot(
+			expressions.NewAnd(
+				expressions.NewEquals(a, expressions.NewScalar(int32(0))),
+				expressions.NewEquals(b, expressions.NewScalar(int32(0))),
+			),
+		),
+		"not of or": expressions.NewNot(
+			expressions.NewOr(
+				expressions.NewEquals(a, expressions.NewScalar(int32(0))),
+				expressions.NewEquals(b, expressions.NewScalar(int32(0))),
+			),
+		),
+	}
+
+	for repoType, componentUnderTest := range precedenceImplementationsToTest() {
+		repoDesc := repoType.String()
+		// Call setup function, inject t *testing.T, and use t.Cleanup
+		repoImpl := componentUnderTest(t)
+		if repoImpl == nil {
+			t.Fatalf(
+				"%s: no implementation provided",
+				repoDesc,
+			)
+		}
+
+		initial := precedenceInitial()
+		_, err := repoImpl.Create(context.Background(), initial)
+		if err != nil {
+			t.Fatalf(
+				"%s: Create(): %s",
+				repoDesc,
+				err,
+			)
+		}
+
+		for testDesc, expr := range testCases {
+			assertPrecedenceRead(t, repoImpl, initial, expr, fmt.Sprintf("%s: %s", repoDesc, testDesc))
+		}
+	}
+}
+
+func TestPrecedenceRepository_Read_MatchesReferenceEvaluatorForRandomExpressions(t *testing.T) {
+	seed := uint64(time.Now().UnixNano())
+
+	for repoType, componentUnderTest := range precedenceImplementationsToTest() {
+		repoDesc := repoType.String()
+		// Call setup function, inject t *testing.T, and use t.Cleanup
+		repoImpl := componentUnderTest(t)
+		if repoImpl == nil {
+			t.Fatalf(
+				"%s: no implementation provided",
+				repoDesc,
+			)
+		}
+
+		initial := precedenceInitial()
+		_, err := repoImpl.Create(context.Background(), initial)
+		if err != nil {
+			t.Fatalf(
+				"%s: Create(): %s",
+				repoDesc,
+				err,
+			)
+		}
+
+		rng := rand.New(rand.NewPCG(seed, 0))
+		for i := 0; i < precedenceExpressionCount; i++ {
+			expr := precedenceRandomExpression(rng, precedenceMaxDepth)
+			assertPrecedenceRead(t, repoImpl, initial, expr, fmt.Sprintf("%s: seed %d: expression %d", repoDesc, seed, i))
+		}
+	}
+}
+
+func assertPrecedenceRead(t *testing.T, repoImpl where_precedence.PrecedenceRepository, initial []*where_precedence.Precedence, expr expressions.Expression, prefix string) {
+	t.Helper()
+
+	var expectedIDs []int32
+	for _, precedence := range initial {
+		if precedenceEvaluate(expr, precedence) {
+			expectedIDs = append(expectedIDs, precedence.GetId())
+		}
+	}
+
+	res, err := repoImpl.Read(context.Background(), expr)
+	if err != nil {
+		t.Fatalf(
+			"%s: Read(%s): %s",
+			prefix,
+			expr,
+			err,
+		)
+	}
+	var ids []int32
+	for _, precedence := range res {
+		ids = append(ids, precedence.GetId())
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	if diff := cmp.Diff(expectedIDs, ids); diff != "" {
+		t.Fatal(
+			mismatch(
+				fmt.Sprintf(
+					"%s: Read(%s):",
+					prefix,
+					expr,
+				),
+				diff,
+			),
+		)
+	}
+}
+
+// precedenceRandomExpression builds a random expression tree of at most depth logical operators
+func precedenceRandomExpression(rng *rand.Rand, depth int) expressions.Expression {
+	if depth == 0 || rng.IntN(4) == 0 {
+		return precedenceRandomComparison(rng)
+	}
+	switch rng.IntN(3) {
+	case 0:
+		return expressions.NewAnd(
+			precedenceRandomExpression(rng, depth-1),
+			precedenceRandomExpression(rng, depth-1),
+		)
+	case 1:
+		return expressions.NewOr(
+			precedenceRandomExpression(rng, depth-1),
+			precedenceRandomExpression(rng, depth-1),
+		)
+	default:
+		return expressions.NewNot(precedenceRandomExpression(rng, depth-1))
+	}
+}
+
+func precedenceRandomComparison(rng *rand.Rand) expressions.Expression {
+	fieldIDs := []expressions.ID{
+		where_precedence.Precedence_Id_Field,
+		where_precedence.Precedence_A_Field,
+		where_precedence.Precedence_B_Field,
+	}
+	field := expressions.NewIdentifier(fieldIDs[rng.IntN(len(fieldIDs))])
+	value := func() expressions.Expression {
+		if field.ID() == where_precedence.Precedence_Id_Field {
+			return expressions.NewScalar(rng.Int32N(precedenceRowCount))
+		}
+		return expressions.NewScalar(rng.Int32N(5))
+	}
+
+	switch rng.IntN(8) {
+	case 0:
+		return expressions.NewEquals(field, value())
+	case 1:
+		return crudexpressions.NewNotEquals(field, value())
+	case 2:
+		return crudexpressions.NewLessThan(field, value())
+	case 3:
+		return crudexpressions.NewLessThanOrEqual(field, value())
+	case 4:
+		return crudexpressions.NewGreaterThan(field, value())
+	case 5:
+		return crudexpressions.NewGreaterThanOrEqual(field, value())
+	case 6:
+		return crudexpressions.NewBetween(field, value(), value())
+	default:
+		return crudexpressions.NewIn(field, value(), value(), value())
+	}
+}
+
+// precedenceEvaluate is the reference evaluator the generated SQL is checked against
+func precedenceEvaluate(expr expressions.Expression, precedence *where_precedence.Precedence) bool {
+	switch expr := expr.(type) {
+	case *expressions.And:
+		return precedenceEvaluate(expr.Left(), precedence) && precedenceEvaluate(expr.Right(), precedence)
+	case *expressions.Or:
+		return precedenceEvaluate(expr.Left(), precedence) || precedenceEvaluate(expr.Right(), precedence)
+	case *expressions.Not:
+		return !precedenceEvaluate(expr.Operand(), precedence)
+	case *expressions.Equals:
+		return precedenceValue(expr.Left(), precedence) == precedenceValue(expr.Right(), precedence)
+	case *crudexpressions.NotEquals:
+		return precedenceValue(expr.Left(), precedence) != precedenceValue(expr.Right(), precedence)
+	case *crudexpressions.LessThan:
+		return precedenceValue(expr.Left(), precedence) < precedenceValue(expr.Right(), precedence)
+	case *crudexpressions.LessThanOrEqual:
+		return precedenceValue(expr.Left(), precedence) <= precedenceValue(expr.Right(), precedence)
+	case *crudexpressions.GreaterThan:
+		return precedenceValue(expr.Left(), precedence) > precedenceValue(expr.Right(), precedence)
+	case *crudexpressions.GreaterThanOrEqual:
+		return precedenceValue(expr.Left(), precedence) >= precedenceValue(expr.Right(), precedence)
+	case *crudexpressions.Between:
+		operand := precedenceValue(expr.Operand(), precedence)
+		return precedenceValue(expr.Low(), precedence) <= operand && operand <= precedenceValue(expr.High(), precedence)
+	case *crudexpressions.In:
+		operand := precedenceValue(expr.Operand(), precedence)
+		for _, value := range expr.Values() {
+			if operand == precedenceValue(value, precedence) {
+				return true
+			}
+		}
+		return false
+	default:
+		panic(fmt.Sprintf("unhandled expression %T", expr))
+	}
+}
+
+func precedenceValue(expr expressions.Expression, precedence *where_precedence.Precedence) int32 {
+	switch expr := expr.(type) {
+	case *expressions.Identifier:
+		switch expr.ID() {
+		case where_precedence.Precedence_Id_Field:
+			return precedence.GetId()
+		case where_precedence.Precedence_A_Field:
+			return precedence.GetA()
+		case where_precedence.Precedence_B_Field:
+			return precedence.GetB()
+		default:
+			panic(fmt.Sprintf("unhandled field id %s", expr.ID()))
+		}
+	case *expressions.Scalar:
+		return expr.Value().(int32)
+	default:
+		panic(fmt.Sprintf("unhandled value expression %T", expr))
+	}
+}
+
+func precedenceInitial() []*where_precedence.Precedence {
+	initial := make([]*where_precedence.Precedence, 0, precedenceRowCount)
+	for id := int32(0); id < precedenceRowCount; id++ {
+		initial = append(
+			initial,
+			where_precedence.Precedence_builder{
+				Id: id,
+				A:  id % 3,
+				B:  (id * 7) % 5,
+			}.Build(),
+		)
+	}
+	return initial
+}
+
+func precedenceImplementationsToTest() map[options.Implementation]precedenceComponentUnderTest {
+	return map[options.Implementation]precedenceComponentUnderTest{
+		options.Implementation_IMPLEMENTATION_SQLITE: sqlitePrecedenceComponentUnderTest,
+		options.Implementation_IMPLEMENTATION_PGSQL:  pgsqlPrecedenceComponentUnderTest,
+	}
+}
@@ -2,6 +2,7 @@ package with_no_implementations_set_test
 
 import (
 	"context"
+	"iter"
 	"strings"
 	"testing"
 
@@ -47,6 +48,58 @@ func TestEmptyImplementationsRepository_HasCompleteInterface(t *testing.T) {
 		t.Errorf("Read() mistmatch (-want +got):\n%s", diff)
 	}
 
+	_, err = iface.Get(context.Background(), tested.EmptyImplementationsKey{})
+	if err != nil {
+		t.Errorf("Get(): error %s", err)
+	}
+
+	resp, err = iface.GetMany(context.Background(), nil)
+	if err != nil {
+		t.Errorf("GetMany(): error %s", err)
+	}
+	if diff := cmp.Diff(expected, resp, opts); diff != "" {
+		t.Errorf("GetMany() mistmatch (-want +got):\n%s", diff)
+	}
+
+	for item, err := range iface.ReadIter(context.Background(), nil) {
+		t.Errorf("ReadIter(): unexpected item %v, error %v", item, err)
+	}
+
+	resp, nextPageToken, err := iface.ReadWithOptions(context.Background(), nil, nil)
+	if err != nil {
+		t.Errorf("ReadWithOptions(): error %s", err)
+	}
+	if diff := cmp.Diff(expected, resp, opts); diff != "" {
+		t.Errorf("ReadWithOptions() mistmatch (-want +got):\n%s", diff)
+	}
+	if nextPageToken != "" {
+		t.Errorf("ReadWithOptions(): unexpected next page token %q", nextPageToken)
+	}
+
+	count, err := iface.Count(context.Background(), nil)
+	if err != nil {
+		t.Errorf("Count(): error %s", err)
+	}
+	if count != 0 {
+		t.Errorf("Count(): unexpected count %d", count)
+	}
+
+	exists, err := iface.Exists(context.Background(), nil)
+	if err != nil {
+		t.Errorf("Exists(): error %s", err)
+	}
+	if exists {
+		t.Errorf("Exists(): unexpected existence")
+	}
+
+	aggregates, err := iface.Aggregate(context.Background(), nil, nil)
+	if err != nil {
+		t.Errorf("Aggregate(): error %s", err)
+	}
+	if len(aggregates) != 0 {
+		t.Errorf("Aggregate(): unexpected results %v", aggregates)
+	}
+
 	resp, err = iface.Update(context.Background(), expected)
 	if err != nil {
 		t.Errorf("Update(): error %s", err)
@@ -55,7 +108,15 @@ func TestEmptyImplementationsRepository_HasCompleteInterface(t *testing.T) {
 		t.Errorf("Update() mistmatch (-want +got):\n%s", diff)
 	}
 
-	err = iface.Delete(context.Background(), nil)
+	resp, err = iface.Upsert(context.Background(), expected)
+	if err != nil {
+		t.Errorf("Upsert(): error %s", err)
+	}
+	if diff := cmp.Diff(expected, resp, opts); diff != "" {
+		t.Errorf("Upsert() mistmatch (-want +got):\n%s", diff)
+	}
+
+	_, err = iface.Delete(context.Background(), nil)
 	if err != nil {
 		t.Errorf("Delete(): error %s", err)
 	}
@@ -71,10 +132,42 @@ func (r *testEmptyImplementationsRepository) Read(ctx context.Context, expressio
 	return []*tested.EmptyImplementations{}, nil
 }
 
+func (r *testEmptyImplementationsRepository) Get(ctx context.Context, key tested.EmptyImplementationsKey) (*tested.EmptyImplementations, error) {
+	return &tested.EmptyImplementations{}, nil
+}
+
+func (r *testEmptyImplementationsRepository) GetMany(ctx context.Context, keys []tested.EmptyImplementationsKey) ([]*tested.EmptyImplementations, error) {
+	return []*tested.EmptyImplementations{}, nil
+}
+
+func (r *testEmptyImplementationsRepository) ReadIter(ctx context.Context, expression expressions.Expression) iter.Seq2[*tested.EmptyImplementations, error] {
+	return func(yield func(*tested.EmptyImplementations, error) bool) {}
+}
+
+func (r *testEmptyImplementationsRepository) ReadWithOptions(ctx context.Context, expression expressions.Expression, opts *tested.EmptyImplementationsReadOptions) ([]*tested.EmptyImplementations, string, error) {
+	return []*tested.EmptyImplementations{}, "", nil
+}
+
+func (r *testEmptyImplementationsRepository) Count(ctx context.Context, expression expressions.Expression) (int64, error) {
+	return 0, nil
+}
+
+func (r *testEmptyImplementationsRepository) Exists(ctx context.Context, expression expressions.Expression) (bool, error) {
+	return false, nil
+}
+
+func (r *testEmptyImplementationsRepository) Aggregate(ctx context.Context, expression expressions.Expression, opts *tested.EmptyImplementationsAggregateOptions) ([]*tested.EmptyImplementationsAggregateResult, error) {
+	return nil, nil
+}
+
 func (r *testEmptyImplementationsRepository) Update(ctx context.Context, implementations []*tested.EmptyImplementations) ([]*tested.EmptyImplementations, error) {
 	return []*tested.EmptyImplementations{}, nil
 }
 
-func (r *testEmptyImplementationsRepository) Delete(ctx context.Context, expression expressions.Expression) error {
-	return nil
+func (r *testEmptyImplementationsRepository) Upsert(ctx context.Context, implementations []*tested.EmptyImplementations) ([]*tested.EmptyImplementations, error) {
+	return []*tested.EmptyImplementations{}, nil
+}
+
+func (r *testEmptyImplementationsRepository) Delete(ctx context.Context, expression expressions.Expression) (int64, error) {
+	return 0, nil
 }
@@ -2,6 +2,7 @@ package with_no_implementations_set_test
 
 import (
 	"context"
+	"iter"
 	"strings"
 	"testing"
 
@@ -47,6 +48,58 @@ func TestNoImplementationsRepository_HasCompleteInterface(t *testing.T) {
 		t.Errorf("Read() mistmatch (-want +got):\n%s", diff)
 	}
 
+	_, err = iface.Get(context.Background(), tested.NoImplementationsKey{})
+	if err != nil {
+		t.Errorf("Get(): error %s", err)
+	}
+
+	resp, err = iface.GetMany(context.Background(), nil)
+	if err != nil {
+		t.Errorf("GetMany(): error %s", err)
+	}
+	if diff := cmp.Diff(expected, resp, opts); diff != "" {
+		t.Errorf("GetMany() mistmatch (-want +got):\n%s", diff)
+	}
+
+	for item, err := range iface.ReadIter(context.Background(), nil) {
+		t.Errorf("ReadIter(): unexpected item %v, error %v", item, err)
+	}
+
+	resp, nextPageToken, err := iface.ReadWithOptions(context.Background(), nil, nil)
+	if err != nil {
+		t.Errorf("ReadWithOptions(): error %s", err)
+	}
+	if diff := cmp.Diff(expected, resp, opts); diff != "" {
+		t.Errorf("ReadWithOptions() mistmatch (-want +got):\n%s", diff)
+	}
+	if nextPageToken != "" {
+		t.Errorf("ReadWithOptions(): unexpected next page token %q", nextPageToken)
+	}
+
+	count, err := iface.Count(context.Background(), nil)
+	if err != nil {
+		t.Errorf("Count(): error %s", err)
+	}
+	if count != 0 {
+		t.Errorf("Count(): unexpected count %d", count)
+	}
+
+	exists, err := iface.Exists(context.Background(), nil)
+	if err != nil {
+		t.Errorf("Exists(): error %s", err)
+	}
+	if exists {
+		t.Errorf("Exists(): unexpected existence")
+	}
+
+	aggregates, err := iface.Aggregate(context.Background(), nil, nil)
+	if err != nil {
+		t.Errorf("Aggregate(): error %s", err)
+	}
+	if len(aggregates) != 0 {
+		t.Errorf("Aggregate(): unexpected results %v", aggregates)
+	}
+
 	resp, err = iface.Update(context.Background(), expected)
 	if err != nil {
 		t.Errorf("Update(): error %s", err)
@@ -55,7 +108,15 @@ func TestNoImplementationsRepository_HasCompleteInterface(t *testing.T) {
 		t.Errorf("Update() mistmatch (-want +got):\n%s", diff)
 	}
 
-	err = iface.Delete(context.Background(), nil)
+	resp, err = iface.Upsert(context.Background(), expected)
+	if err != nil {
+		t.Errorf("Upsert(): error %s", err)
+	}
+	if diff := cmp.Diff(expected, resp, opts); diff != "" {
+		t.Errorf("Upsert() mistmatch (-want +got):\n%s", diff)
+	}
+
+	_, err = iface.Delete(context.Background(), nil)
 	if err != nil {
 		t.Errorf("Delete(): error %s", err)
 	}
@@ -71,10 +132,42 @@ func (r *testNoImplementationsRepository) Read(ctx context.Context, expression e
 	return []*tested.NoImplementations{}, nil
 }
 
+func (r *testNoImplementationsRepository) Get(ctx context.Context, key tested.NoImplementationsKey) (*tested.NoImplementations, error) {
+	return &tested.NoImplementations{}, nil
+}
+
+func (r *testNoImplementationsRepository) GetMany(ctx context.Context, keys []tested.NoImplementationsKey) ([]*tested.NoImplementations, error) {
+	return []*tested.NoImplementations{}, nil
+}
+
+func (r *testNoImplementationsRepository) ReadIter(ctx context.Context, expression expressions.Expression) iter.Seq2[*tested.NoImplementations, error] {
+	return func(yield func(*tested.NoImplementations, error) bool) {}
+}
+
+func (r *testNoImplementationsRepository) ReadWithOptions(ctx context.Context, expression expressions.Expression, opts *tested.NoImplementationsReadOptions) ([]*tested.NoImplementations, string, error) {
+	return []*tested.NoImplementations{}, "", nil
+}
+
+func (r *testNoImplementationsRepository) Count(ctx context.Context, expression expressions.Expression) (int64, error) {
+	return 0, nil
+}
+
+func (r *testNoImplementationsRepository) Exists(ctx context.Context, expression expressions.Expression) (bool, error) {
+	return false, nil
+}
+
+func (r *testNoImplementationsRepository) Aggregate(ctx context.Context, expression expressions.Expression, opts *tested.NoImplementationsAggregateOptions) ([]*tested.NoImplementationsAggregateResult, error) {
+	return nil, nil
+}
+
 func (r *testNoImplementationsRepository) Update(ctx context.Context, implementations []*tested.NoImplementations) ([]*tested.NoImplementations, error) {
 	return []*tested.NoImplementations{}, nil
 }
 
-func (r *testNoImplementationsRepository) Delete(ctx context.Context, expression expressions.Expression) error {
-	return nil
+func (r *testNoImplementationsRepository) Upsert(ctx context.Context, implementations []*tested.NoImplementations) ([]*tested.NoImplementations, error) {
+	return []*tested.NoImplementations{}, nil
+}
+
+func (r *testNoImplementationsRepository) Delete(ctx context.Context, expression expressions.Expression) (int64, error) {
+	return 0, nil
 }